@@ -7,14 +7,23 @@ import (
 	rediscache "auth/internal/infrastructure/redis-cache"
 	"auth/internal/infrastructure/sqlstore"
 	"auth/internal/usecase"
+	"auth/pkg/healthcheck"
+	"auth/pkg/metrics"
+	"auth/pkg/oidc"
+	"auth/pkg/password"
 	tokenjwt "auth/pkg/token"
+	"auth/pkg/tracing"
+	authv1 "auth/proto/auth/v1"
+	"auth/provider"
 	"context"
 	"flag"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	_ "github.com/lib/pq"
@@ -38,6 +47,16 @@ func main() {
 	}
 	logger := config.NewLogger(cfg)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "auth-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	db, err := sqlstore.NewDB(cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal(err)
@@ -58,17 +77,98 @@ func main() {
 		}
 	}()
 
+	var breached *password.Corpus
+	if cfg.PasswordPolicy.BreachedCorpusPath != "" {
+		breached, err = password.OpenCorpus(cfg.PasswordPolicy.BreachedCorpusPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() { _ = breached.Close() }()
+		logger.Info("breached passwords corpus opened", slog.Int64("bytes", breached.Size()))
+	} else {
+		logger.Warn("breached_corpus_path is empty, passwords are not checked against breaches")
+	}
+
+	passwords := password.NewChecker(password.Policy{
+		MinLength:     cfg.PasswordPolicy.MinLength,
+		MaxBytes:      cfg.PasswordPolicy.MaxBytes,
+		RequireLower:  cfg.PasswordPolicy.RequireLower,
+		RequireUpper:  cfg.PasswordPolicy.RequireUpper,
+		RequireDigit:  cfg.PasswordPolicy.RequireDigit,
+		RequireSymbol: cfg.PasswordPolicy.RequireSymbol,
+	}, breached)
+
+	hasher, err := password.NewHasher(password.HashParams{
+		Algorithm:     password.Algorithm(cfg.PasswordHash.Algorithm),
+		BcryptCost:    cfg.PasswordHash.BcryptCost,
+		Argon2Time:    cfg.PasswordHash.Argon2Time,
+		Argon2Memory:  cfg.PasswordHash.Argon2Memory,
+		Argon2Threads: cfg.PasswordHash.Argon2Threads,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	audit := usecase.NewAuditUseCase(sqlstore.NewAuditRepository(db), *logger)
+
 	auth := usecase.NewAuthUseCase(
 		sqlstore.NewUserRepository(db),
 		sqlstore.NewSessionRepository(db),
+		sqlstore.NewAppRepository(db),
 		cache,
 		tokenjwt.NewTokenProvider(cfg.JWTSecret),
+		passwords,
+		hasher,
+		audit,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
 	)
 
-	application := app.New(logger, cfg.BindAddr, auth)
+	oidcKey, err := tokenjwt.LoadRSAKey(cfg.OIDCSigningKeyPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.OIDCSigningKeyPath == "" {
+		logger.Warn("oidc_signing_key_path is empty, ID tokens are signed with an ephemeral key")
+	}
+
+	oauth := usecase.NewOAuthUseCase(
+		auth,
+		sqlstore.NewAuthCodeRepository(db),
+		tokenjwt.NewIDTokenSigner(oidcKey, cfg.OAuthIssuer),
+		*logger,
+	)
+
+	providers := make(map[string]provider.IdentityProvider, len(cfg.ExternalProviders))
+	for _, p := range cfg.ExternalProviders {
+		providers[p.Name] = oidc.NewClient(oidc.Config{
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		}, &http.Client{Timeout: 10 * time.Second})
+	}
+
+	external := usecase.NewExternalLoginUseCase(
+		auth,
+		sqlstore.NewIdentityRepository(db),
+		sqlstore.NewExternalLoginRepository(db),
+		providers,
+		*logger,
+	)
+
+	profile := usecase.NewProfileUseCase(sqlstore.NewProfileRepository(db), *logger)
+
+	// Пока Postgres или Redis недоступны, grpc.health.v1 отвечает NOT_SERVING
+	health := healthcheck.NewChecker(logger, cfg.HealthInterval, map[string]healthcheck.CheckFunc{
+		"postgres": db.PingContext,
+		"redis":    cache.Ping,
+	}, authv1.AuthService_ServiceDesc.ServiceName, authv1.OAuthService_ServiceDesc.ServiceName, authv1.AdminService_ServiceDesc.ServiceName, authv1.ProfileService_ServiceDesc.ServiceName)
+	go health.Run()
+
+	application := app.New(logger, cfg.BindAddr, auth, external, oauth, audit, profile, audit, health)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -79,6 +179,37 @@ func main() {
 		}
 	}()
 
+	// Роли кэшируются для IntrospectToken, сбрасываем их по role.changed из журнала
+	go func() {
+		for {
+			if err := audit.WatchRoleChanges(ctx, auth.InvalidateRoles); err != nil {
+				logger.Error("role changes watch stopped with error", slog.String("err", err.Error()))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+
+	metricsServer := metrics.NewServer(logger, cfg.MetricsAddr)
+	go func() {
+		if err := metricsServer.Run(); err != nil {
+			logger.Error("metrics server stopped with error", slog.String("err", err.Error()))
+		}
+	}()
+
 	<-ctx.Done()
+	health.Stop()
 	application.GRPCServer.Stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := metricsServer.Stop(shutdownCtx); err != nil {
+		logger.Error("metrics server stopped with error", slog.String("err", err.Error()))
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("tracing shutdown with error", slog.String("err", err.Error()))
+	}
 }
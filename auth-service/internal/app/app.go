@@ -4,6 +4,7 @@ package app
 import (
 	grpcapp "auth/internal/app/grpc"
 	grpcauth "auth/internal/grpc/auth"
+	"auth/pkg/healthcheck"
 	"log/slog"
 )
 
@@ -13,8 +14,8 @@ type App struct {
 }
 
 // New ...
-func New(log *slog.Logger, port string, auth grpcauth.Auth) *App {
-	gRPCApp := grpcapp.New(log, port, auth)
+func New(log *slog.Logger, port string, auth grpcauth.Auth, external grpcauth.ExternalLogin, oauth grpcauth.OAuth, admin grpcauth.Admin, profile grpcauth.Profile, events grpcauth.UserEvents, health *healthcheck.Checker) *App {
+	gRPCApp := grpcapp.New(log, port, auth, external, oauth, admin, profile, events, health)
 	return &App{
 		GRPCServer: gRPCApp,
 	}
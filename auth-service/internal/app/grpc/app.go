@@ -3,10 +3,19 @@ package grpcapp
 
 import (
 	grpcauth "auth/internal/grpc/auth"
+	"auth/pkg/authn"
+	"auth/pkg/clientinfo"
+	"auth/pkg/healthcheck"
+	"auth/pkg/metrics"
+	"auth/pkg/requestid"
+	"auth/pkg/tracing"
+	"auth/pkg/validate"
+	authv1 "auth/proto/auth/v1"
 	"fmt"
 	"log/slog"
 	"net"
 
+	"buf.build/go/protovalidate"
 	"google.golang.org/grpc"
 )
 
@@ -18,9 +27,39 @@ type App struct {
 }
 
 // New ...
-func New(log *slog.Logger, port string, auth grpcauth.Auth) *App {
-	gRPCServer := grpc.NewServer()
-	grpcauth.Register(gRPCServer, auth, log)
+func New(log *slog.Logger, port string, auth grpcauth.Auth, external grpcauth.ExternalLogin, oauth grpcauth.OAuth, admin grpcauth.Admin, profile grpcauth.Profile, events grpcauth.UserEvents, health *healthcheck.Checker) *App {
+	// Токен нужен AdminService, ProfileService и смене пароля, остальные методы auth-service его и выдают.
+	// BatchGetProfiles и GetProfileByUsername вызывают другие сервисы, без токена пользователя.
+	withToken := []authn.Option{
+		authn.WithServices(authv1.AdminService_ServiceDesc.ServiceName, authv1.ProfileService_ServiceDesc.ServiceName),
+		authn.WithMethods(authv1.AuthService_ChangePassword_FullMethodName),
+		authn.WithPublicMethods(
+			authv1.ProfileService_BatchGetProfiles_FullMethodName,
+			authv1.ProfileService_GetProfileByUsername_FullMethodName,
+		),
+	}
+
+	gRPCServer := grpc.NewServer(
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			requestid.UnaryServerInterceptor(),
+			clientinfo.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+			authn.UnaryServerInterceptor(grpcauth.Verifier(auth), withToken...),
+			validate.UnaryServerInterceptor(protovalidate.GlobalValidator),
+		),
+		grpc.ChainStreamInterceptor(
+			requestid.StreamServerInterceptor(),
+			clientinfo.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(),
+			authn.StreamServerInterceptor(grpcauth.Verifier(auth), withToken...),
+		),
+	)
+	grpcauth.Register(gRPCServer, auth, external, events, log)
+	grpcauth.RegisterOAuth(gRPCServer, oauth, log)
+	grpcauth.RegisterAdmin(gRPCServer, admin, log)
+	grpcauth.RegisterProfile(gRPCServer, profile, log)
+	health.Register(gRPCServer)
 
 	return &App{
 		logger:     log,
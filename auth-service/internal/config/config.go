@@ -5,21 +5,89 @@ import "time"
 
 // Config ...
 type Config struct {
-	DatabaseURL     string        `toml:"database_url"`
-	TestDatabaseURL string        `toml:"test_database_url"`
-	RedisAddr       string        `toml:"redis_addr"`
-	TestRedisAddr   string        `toml:"test_redis_addr"`
-	BindAddr        string        `toml:"bind_addr"`
-	AccessTokenTTL  time.Duration `toml:"access_token_ttl"`
-	RefreshTokenTTL time.Duration `toml:"refresh_token_ttl"`
-	LogLevel        string        `toml:"log_level"`
-	JWTSecret       string        `toml:"jwt_secret"`
+	DatabaseURL        string        `toml:"database_url"`
+	TestDatabaseURL    string        `toml:"test_database_url"`
+	RedisAddr          string        `toml:"redis_addr"`
+	TestRedisAddr      string        `toml:"test_redis_addr"`
+	BindAddr           string        `toml:"bind_addr"`
+	MetricsAddr        string        `toml:"metrics_addr"`
+	HealthInterval     time.Duration `toml:"health_check_interval"`
+	AccessTokenTTL     time.Duration `toml:"access_token_ttl"`
+	RefreshTokenTTL    time.Duration `toml:"refresh_token_ttl"`
+	LogLevel           string        `toml:"log_level"`
+	JWTSecret          string        `toml:"jwt_secret"`
+	OAuthIssuer        string        `toml:"oauth_issuer"`
+	OIDCSigningKeyPath string        `toml:"oidc_signing_key_path"`
+
+	ExternalProviders []ExternalProvider `toml:"external_providers"`
+	PasswordPolicy    PasswordPolicy     `toml:"password_policy"`
+	PasswordHash      PasswordHash       `toml:"password_hash"`
+	Tracing           Tracing            `toml:"tracing"`
+}
+
+// PasswordPolicy - требования к паролю при регистрации и смене пароля.
+// Пустой breached_corpus_path выключает проверку по базе утечек.
+type PasswordPolicy struct {
+	MinLength          int    `toml:"min_length"`
+	MaxBytes           int    `toml:"max_bytes"`
+	RequireLower       bool   `toml:"require_lower"`
+	RequireUpper       bool   `toml:"require_upper"`
+	RequireDigit       bool   `toml:"require_digit"`
+	RequireSymbol      bool   `toml:"require_symbol"`
+	BreachedCorpusPath string `toml:"breached_corpus_path"`
+}
+
+// ExternalProvider - внешний OIDC провайдер для входа без пароля.
+type ExternalProvider struct {
+	Name         string   `toml:"name"`
+	Issuer       string   `toml:"issuer"`
+	ClientID     string   `toml:"client_id"`
+	ClientSecret string   `toml:"client_secret"`
+	RedirectURL  string   `toml:"redirect_url"`
+	Scopes       []string `toml:"scopes"`
+}
+
+// PasswordHash - схема хэширования новых паролей. Старые хэши пересчитываются
+// при входе, если они сделаны другой схемой или с параметрами слабее этих.
+type PasswordHash struct {
+	Algorithm     string `toml:"algorithm"` // argon2id или bcrypt.
+	BcryptCost    int    `toml:"bcrypt_cost"`
+	Argon2Time    uint32 `toml:"argon2_time"`
+	Argon2Memory  uint32 `toml:"argon2_memory"` // В KiB.
+	Argon2Threads uint8  `toml:"argon2_threads"`
+}
+
+// Tracing - экспорт спанов OpenTelemetry. Пустой exporter - спаны не пишутся.
+type Tracing struct {
+	Exporter    string  `toml:"exporter"` // otlp или stdout.
+	Endpoint    string  `toml:"endpoint"` // host:port OTLP/gRPC коллектора.
+	Insecure    bool    `toml:"insecure"`
+	SampleRatio float64 `toml:"sample_ratio"`
 }
 
 // NewConfig ...
 func NewConfig() *Config {
 	return &Config{
-		BindAddr: ":8080",
-		LogLevel: "info",
+		BindAddr:       ":8080",
+		MetricsAddr:    ":9091",
+		HealthInterval: 5 * time.Second,
+		LogLevel:       "info",
+		OAuthIssuer:    "http://localhost:8080",
+		PasswordPolicy: PasswordPolicy{
+			MinLength: 8,
+			MaxBytes:  72,
+		},
+		PasswordHash: PasswordHash{
+			Algorithm:     "argon2id",
+			BcryptCost:    10,
+			Argon2Time:    2,
+			Argon2Memory:  19 * 1024,
+			Argon2Threads: 1,
+		},
+		Tracing: Tracing{
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
 	}
 }
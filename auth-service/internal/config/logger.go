@@ -2,6 +2,7 @@
 package config
 
 import (
+	"auth/pkg/requestid"
 	"log/slog"
 	"os"
 )
@@ -18,5 +19,6 @@ func NewLogger(config *Config) *slog.Logger {
 		Level: &lvl,
 	})
 
-	return slog.New(handler)
+	// request_id из контекста в каждой записи, см. requestid.Handler
+	return slog.New(requestid.NewHandler(handler))
 }
@@ -0,0 +1,19 @@
+// Package domain ...
+package domain
+
+import "time"
+
+// App ...
+type App struct {
+	ID   int
+	Name string
+	// RedirectURIs сравниваются с redirect_uri запроса посимвольно.
+	RedirectURIs []string
+	// Scopes - что приложение может запросить через client_credentials.
+	Scopes          []string
+	AccessTokenTTL  time.Duration // 0 = значение из конфига
+	RefreshTokenTTL time.Duration // 0 = значение из конфига
+	Enabled         bool
+	// ClientSecretHash пустой у публичных OAuth клиентов.
+	ClientSecretHash []byte
+}
@@ -0,0 +1,50 @@
+// Package domain ...
+package domain
+
+import "time"
+
+// AuditEventType - тип события безопасности. Значения хранятся в audit_events
+// и отдаются в API, поэтому менять их нельзя.
+type AuditEventType string
+
+// Типы событий аудита.
+const (
+	AuditUserRegistered  AuditEventType = "user.registered"
+	AuditLoginSucceeded  AuditEventType = "login.succeeded"
+	AuditLoginFailed     AuditEventType = "login.failed"
+	AuditLogout          AuditEventType = "logout"
+	AuditTokenRefreshed  AuditEventType = "token.refreshed"
+	AuditSessionRevoked  AuditEventType = "session.revoked"
+	AuditPasswordChanged AuditEventType = "password.changed"
+	// AuditRoleChanged пишет триггер в БД при смене users.is_admin.
+	AuditRoleChanged AuditEventType = "role.changed"
+	// AuditUserDeleted пишет триггер в БД при удалении из users.
+	AuditUserDeleted AuditEventType = "user.deleted"
+	// AuditAdminAction - действие админа через AdminService, что именно - в details.action.
+	AuditAdminAction AuditEventType = "admin.action"
+)
+
+// AuditEvent - запись журнала аудита. Нулевые ID - не известны или не относятся к событию.
+type AuditEvent struct {
+	ID        int64
+	Type      AuditEventType
+	UserID    int // Кого касается событие.
+	ActorID   int // Кто его вызвал, если не сам пользователь (админ).
+	AppID     int
+	SessionID int
+	IP        string
+	UserAgent string
+	RequestID string
+	Details   map[string]string
+	CreatedAt time.Time
+}
+
+// AuditFilter - выборка событий по возрастанию id. Пустые поля не фильтруют.
+type AuditFilter struct {
+	UserID  int
+	Types   []AuditEventType
+	From    time.Time // Включительно.
+	To      time.Time // Не включительно.
+	AfterID int64     // Курсор: события с id больше этого.
+	Limit   int
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Identity - привязка пользователя к аккаунту во внешнем OIDC провайдере.
+type Identity struct {
+	ID       int
+	UserID   int
+	Provider string
+	Subject  string
+	Email    string
+}
+
+// ExternalLoginState ...
+type ExternalLoginState struct {
+	StateHash    string
+	Provider     string
+	AppID        int
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// AuthCode ...
+type AuthCode struct {
+	CodeHash            string
+	AppID               int
+	UserID              int
+	RedirectURI         string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Scope               string
+	Nonce               string
+	ExpiresAt           time.Time
+}
+
+// AuthorizeRequest ...
+type AuthorizeRequest struct {
+	Email               string
+	Password            string
+	ClientID            string
+	RedirectURI         string
+	ResponseType        string
+	Scope               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+}
+
+// TokenRequest ...
+type TokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthToken ...
+type OAuthToken struct {
+	AccessToken    string
+	AccessExpireAt time.Time
+	RefreshToken   string
+	IDToken        string
+	Scope          string
+}
+
+// RevokeRequest ...
+type RevokeRequest struct {
+	Token         string
+	TokenTypeHint string
+	ClientID      string
+	ClientSecret  string
+}
+
+// IntrospectRequest ...
+type IntrospectRequest struct {
+	Token        string
+	ClientID     string
+	ClientSecret string
+}
+
+// Introspection ...
+type Introspection struct {
+	Active    bool
+	Subject   string
+	ClientID  string
+	Scope     string
+	TokenType string
+	ExpiresAt time.Time
+	UserID    int
+	SessionID int
+	AppID     int
+}
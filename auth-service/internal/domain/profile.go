@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Profile - публичные данные пользователя, которые видят другие пользователи.
+type Profile struct {
+	UserID      int
+	Username    string // Без "@", пустой - не задан.
+	DisplayName string
+	AvatarURL   string
+	Bio         string
+	UpdatedAt   time.Time // Нулевой - профиль ещё не заполнялся.
+}
+
+// ProfileUpdate - изменение профиля: nil поля не меняются, пустая строка очищает поле.
+type ProfileUpdate struct {
+	Username    *string
+	DisplayName *string
+	AvatarURL   *string
+	Bio         *string
+}
@@ -10,4 +10,5 @@ type Session struct {
 	AppID            int
 	RefreshExpiresAt time.Time
 	Status           string
+	Scope            string
 }
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// TokenInfo - результат IntrospectToken.
+type TokenInfo struct {
+	Active    bool
+	UserID    int
+	SessionID int
+	AppID     int
+	Roles     []string
+	ExpiresAt time.Time
+}
@@ -7,3 +7,11 @@ type User struct {
 	Email    string
 	PassHash []byte
 }
+
+// Роли пользователя, которые видят другие сервисы через IntrospectToken.
+const (
+	// RoleUser ...
+	RoleUser = "user"
+	// RoleAdmin ...
+	RoleAdmin = "admin"
+)
@@ -0,0 +1,151 @@
+package grpcauth
+
+import (
+	"auth/internal/domain"
+	"auth/pkg/apierr"
+	"auth/pkg/authn"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Admin ...
+type Admin interface {
+	ListEvents(ctx context.Context, actorID int, filter domain.AuditFilter) (events []domain.AuditEvent, nextAfterID int64, err error)
+	ExportEvents(ctx context.Context, actorID int, filter domain.AuditFilter, fn func(domain.AuditEvent) error) error
+}
+
+type adminServerAPI struct {
+	authv1.UnimplementedAdminServiceServer
+	admin  Admin
+	logger *slog.Logger
+}
+
+// RegisterAdmin ... Токен проверяет authn интерсептор (см. Verifier), здесь - только роль.
+func RegisterAdmin(gRPCServer *grpc.Server, admin Admin, log *slog.Logger) {
+	authv1.RegisterAdminServiceServer(gRPCServer, &adminServerAPI{admin: admin, logger: log})
+}
+
+// Verifier проверяет access токен тем же IntrospectToken, что и другие сервисы,
+// но без сетевого вызова.
+func Verifier(auth Auth) authn.Verifier {
+	return authn.VerifierFunc(func(ctx context.Context, accessToken string) (authn.Principal, error) {
+		info, err := auth.IntrospectToken(ctx, accessToken, "")
+		if err != nil {
+			return authn.Principal{}, err
+		}
+		if !info.Active {
+			return authn.Principal{}, authn.ErrInvalidToken
+		}
+
+		return authn.Principal{
+			UserID:    info.UserID,
+			SessionID: info.SessionID,
+			AppID:     info.AppID,
+			Roles:     info.Roles,
+			ExpiresAt: info.ExpiresAt,
+		}, nil
+	})
+}
+
+// ListAuditEvents ...
+func (s *adminServerAPI) ListAuditEvents(ctx context.Context, req *authv1.ListAuditEventsRequest) (*authv1.ListAuditEventsResponse, error) {
+	actorID, err := adminID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := auditFilter(req.GetUserId(), req.GetTypes(), req.GetFrom(), req.GetTo())
+	filter.AfterID = req.GetAfterId()
+	filter.Limit = int(req.GetLimit())
+
+	events, next, err := s.admin.ListEvents(ctx, actorID, filter)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WarnContext(ctx, err.Error())
+		}
+		return nil, apierr.Internal()
+	}
+
+	resp := &authv1.ListAuditEventsResponse{
+		Events:      make([]*authv1.AuditEvent, 0, len(events)),
+		NextAfterId: next,
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, auditEventToProto(e))
+	}
+
+	return resp, nil
+}
+
+// ExportAuditEvents ...
+func (s *adminServerAPI) ExportAuditEvents(req *authv1.ExportAuditEventsRequest, stream grpc.ServerStreamingServer[authv1.AuditEvent]) error {
+	ctx := stream.Context()
+
+	actorID, err := adminID(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := auditFilter(req.GetUserId(), req.GetTypes(), req.GetFrom(), req.GetTo())
+	err = s.admin.ExportEvents(ctx, actorID, filter, func(e domain.AuditEvent) error {
+		return stream.Send(auditEventToProto(e))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if s.logger != nil {
+			s.logger.WarnContext(ctx, err.Error())
+		}
+		return apierr.Internal()
+	}
+
+	return nil
+}
+
+// adminID - пользователь из токена, если у него есть роль admin.
+func adminID(ctx context.Context) (int, error) {
+	p, ok := authn.FromContext(ctx)
+	if !ok || p.UserID == 0 {
+		return 0, apierr.New(codes.Unauthenticated, apierr.CodeUnauthenticated, "authentication required")
+	}
+	if !p.HasRole(domain.RoleAdmin) {
+		return 0, apierr.New(codes.PermissionDenied, apierr.CodePermissionDenied, "admin role required")
+	}
+	return p.UserID, nil
+}
+
+func auditFilter(userID int64, types []string, from *timestamppb.Timestamp, to *timestamppb.Timestamp) domain.AuditFilter {
+	filter := domain.AuditFilter{UserID: int(userID)}
+	for _, t := range types {
+		filter.Types = append(filter.Types, domain.AuditEventType(t))
+	}
+	if from != nil {
+		filter.From = from.AsTime()
+	}
+	if to != nil {
+		filter.To = to.AsTime()
+	}
+	return filter
+}
+
+func auditEventToProto(e domain.AuditEvent) *authv1.AuditEvent {
+	return &authv1.AuditEvent{
+		Id:        e.ID,
+		Type:      string(e.Type),
+		UserId:    int64(e.UserID),
+		ActorId:   int64(e.ActorID),
+		AppId:     int32(e.AppID), // #nosec G115 -- app_id в БД INT
+		SessionId: int64(e.SessionID),
+		Ip:        e.IP,
+		UserAgent: e.UserAgent,
+		RequestId: e.RequestID,
+		Details:   e.Details,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+}
@@ -0,0 +1,124 @@
+package grpcauth
+
+import (
+	"auth/internal/domain"
+	authMocks "auth/mocks/auth"
+	"auth/pkg/apierr"
+	"auth/pkg/authn"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGRPCAdmin_ListAuditEvents(t *testing.T) {
+	admin := new(authMocks.Admin)
+	server := adminServerAPI{admin: admin}
+
+	adminCtx := authn.NewContext(ctx, authn.Principal{UserID: 1, Roles: []string{domain.RoleUser, domain.RoleAdmin}})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	admin.
+		On("ListEvents", adminCtx, 1, domain.AuditFilter{
+			UserID:  42,
+			Types:   []domain.AuditEventType{domain.AuditLoginFailed},
+			From:    from,
+			AfterID: 10,
+			Limit:   20,
+		}).
+		Return([]domain.AuditEvent{{
+			ID:        11,
+			Type:      domain.AuditLoginFailed,
+			UserID:    42,
+			AppID:     1,
+			IP:        "203.0.113.7",
+			Details:   map[string]string{"reason": "invalid_password"},
+			CreatedAt: created,
+		}}, int64(11), nil)
+
+	resp, err := server.ListAuditEvents(adminCtx, &authv1.ListAuditEventsRequest{
+		UserId:  42,
+		Types:   []string{"login.failed"},
+		From:    timestamppb.New(from),
+		AfterId: 10,
+		Limit:   20,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 1)
+	e := resp.GetEvents()[0]
+	assert.Equal(t, "login.failed", e.GetType())
+	assert.Equal(t, "203.0.113.7", e.GetIp())
+	assert.Equal(t, "invalid_password", e.GetDetails()["reason"])
+	assert.Equal(t, created, e.GetCreatedAt().AsTime())
+	assert.Equal(t, int64(11), resp.GetNextAfterId())
+
+	admin.AssertExpectations(t)
+}
+
+func TestGRPCAdmin_ListAuditEvents_Access(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+		wantAPI  apierr.Code
+	}{
+		{"no principal", ctx, codes.Unauthenticated, apierr.CodeUnauthenticated},
+		{"not admin", authn.NewContext(ctx, authn.Principal{UserID: 2, Roles: []string{domain.RoleUser}}), codes.PermissionDenied, apierr.CodePermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := new(authMocks.Admin)
+			server := adminServerAPI{admin: admin}
+
+			resp, err := server.ListAuditEvents(tt.ctx, &authv1.ListAuditEventsRequest{})
+
+			assert.Nil(t, resp)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.True(t, apierr.Is(err, tt.wantAPI))
+			admin.AssertNotCalled(t, "ListEvents", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGRPCAdmin_ListAuditEvents_Error(t *testing.T) {
+	admin := new(authMocks.Admin)
+	server := adminServerAPI{admin: admin}
+
+	adminCtx := authn.NewContext(ctx, authn.Principal{UserID: 1, Roles: []string{domain.RoleAdmin}})
+	admin.On("ListEvents", adminCtx, 1, domain.AuditFilter{}).Return(nil, int64(0), errors.New("db down"))
+
+	_, err := server.ListAuditEvents(adminCtx, &authv1.ListAuditEventsRequest{})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestVerifier(t *testing.T) {
+	auth := new(authMocks.Auth)
+	v := Verifier(auth)
+
+	auth.
+		On("IntrospectToken", ctx, "ACCESS", "").
+		Return(domain.TokenInfo{Active: true, UserID: 1, SessionID: 3, AppID: 1, Roles: []string{domain.RoleUser, domain.RoleAdmin}}, nil)
+	auth.
+		On("IntrospectToken", ctx, "REVOKED", "").
+		Return(domain.TokenInfo{Active: false}, nil)
+
+	p, err := v.Verify(ctx, "ACCESS")
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.UserID)
+	assert.True(t, p.HasRole(domain.RoleAdmin))
+
+	_, err = v.Verify(ctx, "REVOKED")
+	assert.ErrorIs(t, err, authn.ErrInvalidToken)
+}
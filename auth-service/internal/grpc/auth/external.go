@@ -0,0 +1,68 @@
+package grpcauth
+
+import (
+	"auth/internal/repository"
+	"auth/pkg/apierr"
+	tokenjwt "auth/pkg/token"
+	authv1 "auth/proto/auth/v1"
+	"auth/provider"
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExternalLogin ...
+type ExternalLogin interface {
+	StartExternalLogin(ctx context.Context, providerName string, appID int) (authURL string, state string, err error)
+	CompleteExternalLogin(ctx context.Context, providerName string, code string, state string) (token tokenjwt.Token, err error)
+}
+
+// StartExternalLogin ...
+func (s *serverAPI) StartExternalLogin(ctx context.Context, req *authv1.StartExternalLoginRequest) (*authv1.StartExternalLoginResponse, error) {
+	authURL, state, err := s.external.StartExternalLogin(ctx, req.GetProvider(), int(req.GetAppId()))
+	if err != nil {
+		return nil, s.externalStatus(ctx, err)
+	}
+
+	return &authv1.StartExternalLoginResponse{
+		AuthUrl: authURL,
+		State:   state,
+	}, nil
+}
+
+// CompleteExternalLogin ...
+func (s *serverAPI) CompleteExternalLogin(ctx context.Context, req *authv1.CompleteExternalLoginRequest) (*authv1.LoginResponse, error) {
+	token, err := s.external.CompleteExternalLogin(ctx, req.GetProvider(), req.GetCode(), req.GetState())
+	if err != nil {
+		return nil, s.externalStatus(ctx, err)
+	}
+
+	return &authv1.LoginResponse{
+		AccessToken:      token.AccessToken,
+		RefreshToken:     token.RefreshToken,
+		AccessExpiresAt:  timestamppb.New(token.AccessExpireAt),
+		RefreshExpiresAt: timestamppb.New(token.RefreshExpireAt),
+	}, nil
+}
+
+func (s *serverAPI) externalStatus(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, provider.ErrUnknownIdentityProvider):
+		return apierr.New(codes.NotFound, apierr.CodeUnknownProvider, "unknown identity provider")
+	case errors.Is(err, provider.ErrExternalLoginFailed):
+		return apierr.New(codes.Unauthenticated, apierr.CodeExternalLoginFailed, "external login failed")
+	case errors.Is(err, repository.ErrUserAlreadyExists):
+		return apierr.New(codes.AlreadyExists, apierr.CodeUserAlreadyExists, "user with this email already exists")
+	case errors.Is(err, repository.ErrAppNotFound):
+		return apierr.New(codes.InvalidArgument, apierr.CodeUnknownApp, "unknown app")
+	case errors.Is(err, repository.ErrAppDisabled):
+		return apierr.New(codes.PermissionDenied, apierr.CodeAppDisabled, "app is disabled")
+	}
+	if s.logger != nil {
+		s.logger.WarnContext(ctx, err.Error())
+	}
+
+	return apierr.Internal()
+}
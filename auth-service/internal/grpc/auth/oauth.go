@@ -0,0 +1,179 @@
+package grpcauth
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	tokenjwt "auth/pkg/token"
+	authv1 "auth/proto/auth/v1"
+	"auth/provider"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OAuth ...
+type OAuth interface {
+	Authorize(ctx context.Context, req domain.AuthorizeRequest) (code string, err error)
+	Token(ctx context.Context, req domain.TokenRequest) (token domain.OAuthToken, err error)
+	Revoke(ctx context.Context, req domain.RevokeRequest) error
+	Introspect(ctx context.Context, req domain.IntrospectRequest) (info domain.Introspection, err error)
+	JWKS() []tokenjwt.JWK
+}
+
+type oauthServerAPI struct {
+	authv1.UnimplementedOAuthServiceServer
+	oauth  OAuth
+	logger *slog.Logger
+}
+
+// RegisterOAuth ...
+func RegisterOAuth(gRPCServer *grpc.Server, oauth OAuth, log *slog.Logger) {
+	authv1.RegisterOAuthServiceServer(gRPCServer, &oauthServerAPI{oauth: oauth, logger: log})
+}
+
+// oauthErrors - ошибки OAuth2 уходят в gateway кодом из RFC в тексте статуса.
+var oauthErrors = []struct {
+	err  error
+	code codes.Code
+}{
+	{provider.ErrInvalidRequest, codes.InvalidArgument},
+	{provider.ErrUnsupportedGrantType, codes.InvalidArgument},
+	{provider.ErrUnsupportedResponseType, codes.InvalidArgument},
+	{provider.ErrInvalidScope, codes.InvalidArgument},
+	{provider.ErrInvalidGrant, codes.FailedPrecondition},
+	{provider.ErrUnauthorizedClient, codes.PermissionDenied},
+	{provider.ErrInvalidClient, codes.Unauthenticated},
+}
+
+func oauthStatus(ctx context.Context, log *slog.Logger, err error) error {
+	for _, e := range oauthErrors {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, e.err.Error())
+		}
+	}
+	if errors.Is(err, repository.ErrInvalidCredentials) {
+		return status.Error(codes.Unauthenticated, "login_required")
+	}
+	if log != nil {
+		log.WarnContext(ctx, err.Error())
+	}
+
+	return status.Error(codes.Internal, "server_error")
+}
+
+// Authorize ...
+func (s *oauthServerAPI) Authorize(ctx context.Context, req *authv1.AuthorizeRequest) (*authv1.AuthorizeResponse, error) {
+	code, err := s.oauth.Authorize(ctx, domain.AuthorizeRequest{
+		Email:               req.GetEmail(),
+		Password:            req.GetPassword(),
+		ClientID:            req.GetClientId(),
+		RedirectURI:         req.GetRedirectUri(),
+		ResponseType:        req.GetResponseType(),
+		Scope:               req.GetScope(),
+		CodeChallenge:       req.GetCodeChallenge(),
+		CodeChallengeMethod: req.GetCodeChallengeMethod(),
+		Nonce:               req.GetNonce(),
+	})
+	if err != nil {
+		return nil, oauthStatus(ctx, s.logger, err)
+	}
+
+	return &authv1.AuthorizeResponse{
+		Code:        code,
+		RedirectUri: req.GetRedirectUri(),
+	}, nil
+}
+
+// Token ...
+func (s *oauthServerAPI) Token(ctx context.Context, req *authv1.TokenRequest) (*authv1.TokenResponse, error) {
+	token, err := s.oauth.Token(ctx, domain.TokenRequest{
+		GrantType:    req.GetGrantType(),
+		ClientID:     req.GetClientId(),
+		ClientSecret: req.GetClientSecret(),
+		Code:         req.GetCode(),
+		RedirectURI:  req.GetRedirectUri(),
+		CodeVerifier: req.GetCodeVerifier(),
+		RefreshToken: req.GetRefreshToken(),
+		Scope:        req.GetScope(),
+	})
+	if err != nil {
+		return nil, oauthStatus(ctx, s.logger, err)
+	}
+
+	return &authv1.TokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(token.AccessExpireAt).Seconds()),
+		RefreshToken: token.RefreshToken,
+		IdToken:      token.IDToken,
+		Scope:        token.Scope,
+	}, nil
+}
+
+// Revoke ...
+func (s *oauthServerAPI) Revoke(ctx context.Context, req *authv1.RevokeRequest) (*authv1.RevokeResponse, error) {
+	if err := s.oauth.Revoke(ctx, domain.RevokeRequest{
+		Token:         req.GetToken(),
+		TokenTypeHint: req.GetTokenTypeHint(),
+		ClientID:      req.GetClientId(),
+		ClientSecret:  req.GetClientSecret(),
+	}); err != nil {
+		return nil, oauthStatus(ctx, s.logger, err)
+	}
+
+	return &authv1.RevokeResponse{}, nil
+}
+
+// Introspect ...
+func (s *oauthServerAPI) Introspect(ctx context.Context, req *authv1.IntrospectRequest) (*authv1.IntrospectResponse, error) {
+	info, err := s.oauth.Introspect(ctx, domain.IntrospectRequest{
+		Token:        req.GetToken(),
+		ClientID:     req.GetClientId(),
+		ClientSecret: req.GetClientSecret(),
+	})
+	if err != nil {
+		return nil, oauthStatus(ctx, s.logger, err)
+	}
+	if !info.Active {
+		return &authv1.IntrospectResponse{Active: false}, nil
+	}
+
+	return &authv1.IntrospectResponse{
+		Active:    true,
+		Sub:       info.Subject,
+		ClientId:  info.ClientID,
+		Scope:     info.Scope,
+		TokenType: info.TokenType,
+		ExpiresAt: timestamppb.New(info.ExpiresAt),
+		UserId:    int64(info.UserID),
+		SessionId: int64(info.SessionID),
+		AppId:     int32(info.AppID), // #nosec G115 -- app_id в БД INT
+	}, nil
+}
+
+// GetJWKS ...
+func (s *oauthServerAPI) GetJWKS(_ context.Context, _ *authv1.GetJWKSRequest) (*authv1.GetJWKSResponse, error) {
+	jwks := s.oauth.JWKS()
+
+	keys := make([]*authv1.JWK, len(jwks))
+	for i, k := range jwks {
+		keys[i] = &authv1.JWK{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: k.Use,
+			Alg: k.Alg,
+			N:   k.N,
+			E:   k.E,
+		}
+	}
+
+	return &authv1.GetJWKSResponse{
+		Keys: keys,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package grpcauth
+
+import (
+	"auth/internal/domain"
+	authMocks "auth/mocks/auth"
+	authv1 "auth/proto/auth/v1"
+	"auth/provider"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCOAuth_TokenSuccess(t *testing.T) {
+	oauth := new(authMocks.OAuth)
+	req := &authv1.TokenRequest{
+		GrantType:    "authorization_code",
+		ClientId:     "web",
+		Code:         "CODE",
+		RedirectUri:  "https://app.example.com/callback",
+		CodeVerifier: "verifier",
+	}
+
+	server := oauthServerAPI{
+		oauth: oauth,
+	}
+
+	oauth.
+		On("Token", ctx, mock.AnythingOfType("domain.TokenRequest")).
+		Return(domain.OAuthToken{
+			AccessToken:    "ACCESS",
+			AccessExpireAt: time.Now().Add(15 * time.Minute),
+			RefreshToken:   "REFRESH",
+			IDToken:        "ID_TOKEN",
+			Scope:          "openid",
+		}, nil)
+
+	resp, err := server.Token(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, "ACCESS", resp.GetAccessToken())
+	assert.Equal(t, "Bearer", resp.GetTokenType())
+	assert.Equal(t, "ID_TOKEN", resp.GetIdToken())
+	assert.Positive(t, resp.GetExpiresIn())
+
+	oauth.AssertExpectations(t)
+}
+
+func TestGRPCOAuth_TokenErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"invalid grant", fmt.Errorf("wrap: %w", provider.ErrInvalidGrant), status.Error(codes.FailedPrecondition, "invalid_grant")},
+		{"invalid client", provider.ErrInvalidClient, status.Error(codes.Unauthenticated, "invalid_client")},
+		{"unsupported grant", provider.ErrUnsupportedGrantType, status.Error(codes.InvalidArgument, "unsupported_grant_type")},
+		{"invalid scope", provider.ErrInvalidScope, status.Error(codes.InvalidArgument, "invalid_scope")},
+		{"internal", fmt.Errorf("db down"), status.Error(codes.Internal, "server_error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oauth := new(authMocks.OAuth)
+			server := oauthServerAPI{
+				oauth: oauth,
+			}
+
+			oauth.
+				On("Token", ctx, mock.AnythingOfType("domain.TokenRequest")).
+				Return(domain.OAuthToken{}, tt.err)
+
+			resp, err := server.Token(ctx, &authv1.TokenRequest{GrantType: "authorization_code"})
+
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, resp)
+		})
+	}
+}
+
+func TestGRPCOAuth_RevokePassesClientCredentials(t *testing.T) {
+	oauth := new(authMocks.OAuth)
+	server := oauthServerAPI{
+		oauth: oauth,
+	}
+
+	oauth.
+		On("Revoke", ctx, domain.RevokeRequest{
+			Token:         "REFRESH",
+			TokenTypeHint: "refresh_token",
+			ClientID:      "billing",
+			ClientSecret:  "s3cret",
+		}).
+		Return(provider.ErrUnauthorizedClient)
+
+	_, err := server.Revoke(ctx, &authv1.RevokeRequest{
+		Token:         "REFRESH",
+		TokenTypeHint: "refresh_token",
+		ClientId:      "billing",
+		ClientSecret:  "s3cret",
+	})
+
+	require.ErrorIs(t, err, status.Error(codes.PermissionDenied, "unauthorized_client"))
+	oauth.AssertExpectations(t)
+}
+
+func TestGRPCOAuth_IntrospectPassesClientCredentials(t *testing.T) {
+	oauth := new(authMocks.OAuth)
+	server := oauthServerAPI{
+		oauth: oauth,
+	}
+
+	oauth.
+		On("Introspect", ctx, domain.IntrospectRequest{
+			Token:        "ACCESS",
+			ClientID:     "billing",
+			ClientSecret: "s3cret",
+		}).
+		Return(domain.Introspection{Active: true, Subject: "42", UserID: 42, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+
+	resp, err := server.Introspect(ctx, &authv1.IntrospectRequest{
+		Token:        "ACCESS",
+		ClientId:     "billing",
+		ClientSecret: "s3cret",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, resp.GetActive())
+	assert.Equal(t, int64(42), resp.GetUserId())
+	oauth.AssertExpectations(t)
+}
@@ -0,0 +1,138 @@
+package grpcauth
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"auth/pkg/apierr"
+	"auth/pkg/authn"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Profile ...
+type Profile interface {
+	GetProfile(ctx context.Context, userID int) (profile domain.Profile, err error)
+	BatchGetProfiles(ctx context.Context, userIDs []int) (profiles []domain.Profile, err error)
+	GetProfileByUsername(ctx context.Context, username string) (profile domain.Profile, err error)
+	SearchUsers(ctx context.Context, query string, limit int) (profiles []domain.Profile, err error)
+	UpdateProfile(ctx context.Context, userID int, update domain.ProfileUpdate) (profile domain.Profile, err error)
+}
+
+type profileServerAPI struct {
+	authv1.UnimplementedProfileServiceServer
+	profile Profile
+	logger  *slog.Logger
+}
+
+// RegisterProfile ...
+func RegisterProfile(gRPCServer *grpc.Server, profile Profile, log *slog.Logger) {
+	authv1.RegisterProfileServiceServer(gRPCServer, &profileServerAPI{profile: profile, logger: log})
+}
+
+// GetProfile ...
+func (s *profileServerAPI) GetProfile(ctx context.Context, req *authv1.GetProfileRequest) (*authv1.Profile, error) {
+	profile, err := s.profile.GetProfile(ctx, int(req.GetUserId()))
+	if err != nil {
+		return nil, s.profileError(ctx, err)
+	}
+
+	return profileToProto(profile), nil
+}
+
+// BatchGetProfiles ...
+func (s *profileServerAPI) BatchGetProfiles(ctx context.Context, req *authv1.BatchGetProfilesRequest) (*authv1.BatchGetProfilesResponse, error) {
+	ids := make([]int, len(req.GetUserIds()))
+	for i, id := range req.GetUserIds() {
+		ids[i] = int(id)
+	}
+
+	profiles, err := s.profile.BatchGetProfiles(ctx, ids)
+	if err != nil {
+		return nil, s.profileError(ctx, err)
+	}
+
+	resp := &authv1.BatchGetProfilesResponse{Profiles: make([]*authv1.Profile, 0, len(profiles))}
+	for _, p := range profiles {
+		resp.Profiles = append(resp.Profiles, profileToProto(p))
+	}
+
+	return resp, nil
+}
+
+// GetProfileByUsername ...
+func (s *profileServerAPI) GetProfileByUsername(ctx context.Context, req *authv1.GetProfileByUsernameRequest) (*authv1.Profile, error) {
+	profile, err := s.profile.GetProfileByUsername(ctx, req.GetUsername())
+	if err != nil {
+		return nil, s.profileError(ctx, err)
+	}
+
+	return profileToProto(profile), nil
+}
+
+// SearchUsers ...
+func (s *profileServerAPI) SearchUsers(ctx context.Context, req *authv1.SearchUsersRequest) (*authv1.SearchUsersResponse, error) {
+	profiles, err := s.profile.SearchUsers(ctx, req.GetQuery(), int(req.GetLimit()))
+	if err != nil {
+		return nil, s.profileError(ctx, err)
+	}
+
+	resp := &authv1.SearchUsersResponse{Users: make([]*authv1.Profile, 0, len(profiles))}
+	for _, p := range profiles {
+		resp.Users = append(resp.Users, profileToProto(p))
+	}
+
+	return resp, nil
+}
+
+// UpdateProfile ...
+func (s *profileServerAPI) UpdateProfile(ctx context.Context, req *authv1.UpdateProfileRequest) (*authv1.Profile, error) {
+	userID, ok := authn.UserID(ctx)
+	if !ok {
+		return nil, apierr.New(codes.Unauthenticated, apierr.CodeUnauthenticated, "authentication required")
+	}
+
+	profile, err := s.profile.UpdateProfile(ctx, userID, domain.ProfileUpdate{
+		Username:    req.Username,
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarUrl,
+		Bio:         req.Bio,
+	})
+	if err != nil {
+		return nil, s.profileError(ctx, err)
+	}
+
+	return profileToProto(profile), nil
+}
+
+func (s *profileServerAPI) profileError(ctx context.Context, err error) error {
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return apierr.New(codes.NotFound, apierr.CodeUserNotFound, "user not found")
+	}
+	if errors.Is(err, repository.ErrUsernameTaken) {
+		return apierr.New(codes.AlreadyExists, apierr.CodeUsernameTaken, "username is already taken")
+	}
+	if s.logger != nil {
+		s.logger.WarnContext(ctx, err.Error())
+	}
+	return apierr.Internal()
+}
+
+func profileToProto(p domain.Profile) *authv1.Profile {
+	resp := &authv1.Profile{
+		UserId:      int64(p.UserID),
+		Username:    p.Username,
+		DisplayName: p.DisplayName,
+		AvatarUrl:   p.AvatarURL,
+		Bio:         p.Bio,
+	}
+	if !p.UpdatedAt.IsZero() {
+		resp.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+	return resp
+}
@@ -0,0 +1,123 @@
+package grpcauth
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	authMocks "auth/mocks/auth"
+	"auth/pkg/apierr"
+	"auth/pkg/authn"
+	authv1 "auth/proto/auth/v1"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGRPCProfile_GetProfile(t *testing.T) {
+	profile := new(authMocks.Profile)
+	server := profileServerAPI{profile: profile}
+
+	updated := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	profile.On("GetProfile", ctx, 42).Return(domain.Profile{UserID: 42, Username: "alice", DisplayName: "Alice", UpdatedAt: updated}, nil)
+	profile.On("GetProfile", ctx, 43).Return(domain.Profile{UserID: 43}, nil)
+	profile.On("GetProfile", ctx, 44).Return(domain.Profile{}, fmt.Errorf("wrap: %w", repository.ErrUserNotFound))
+
+	resp, err := server.GetProfile(ctx, &authv1.GetProfileRequest{UserId: 42})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", resp.GetUsername())
+	assert.Equal(t, updated, resp.GetUpdatedAt().AsTime())
+
+	// Незаполненный профиль - без updated_at
+	resp, err = server.GetProfile(ctx, &authv1.GetProfileRequest{UserId: 43})
+	require.NoError(t, err)
+	assert.Nil(t, resp.GetUpdatedAt())
+
+	_, err = server.GetProfile(ctx, &authv1.GetProfileRequest{UserId: 44})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.True(t, apierr.Is(err, apierr.CodeUserNotFound))
+}
+
+func TestGRPCProfile_BatchGetProfiles(t *testing.T) {
+	profile := new(authMocks.Profile)
+	server := profileServerAPI{profile: profile}
+
+	profile.On("BatchGetProfiles", ctx, []int{3, 7}).Return([]domain.Profile{{UserID: 3}, {UserID: 7, DisplayName: "Bob"}}, nil)
+
+	resp, err := server.BatchGetProfiles(ctx, &authv1.BatchGetProfilesRequest{UserIds: []int64{3, 7}})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetProfiles(), 2)
+	assert.Equal(t, "Bob", resp.GetProfiles()[1].GetDisplayName())
+}
+
+func TestGRPCProfile_UpdateProfile(t *testing.T) {
+	profile := new(authMocks.Profile)
+	server := profileServerAPI{profile: profile}
+
+	userCtx := authn.NewContext(ctx, authn.Principal{UserID: 42, Roles: []string{domain.RoleUser}})
+
+	// Не заданные в запросе поля остаются nil
+	profile.
+		On("UpdateProfile", userCtx, 42, mock.MatchedBy(func(u domain.ProfileUpdate) bool {
+			return u.DisplayName != nil && *u.DisplayName == "Alice" && u.Username == nil && u.Bio == nil && u.AvatarURL == nil
+		})).
+		Return(domain.Profile{UserID: 42, DisplayName: "Alice"}, nil)
+
+	resp, err := server.UpdateProfile(userCtx, &authv1.UpdateProfileRequest{DisplayName: proto.String("Alice")})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), resp.GetUserId())
+	profile.AssertExpectations(t)
+}
+
+func TestGRPCProfile_UpdateProfile_Errors(t *testing.T) {
+	profile := new(authMocks.Profile)
+	server := profileServerAPI{profile: profile}
+
+	_, err := server.UpdateProfile(ctx, &authv1.UpdateProfileRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	userCtx := authn.NewContext(ctx, authn.Principal{UserID: 42})
+	profile.
+		On("UpdateProfile", userCtx, 42, mock.Anything).
+		Return(domain.Profile{}, fmt.Errorf("wrap: %w", repository.ErrUsernameTaken))
+
+	_, err = server.UpdateProfile(userCtx, &authv1.UpdateProfileRequest{Username: proto.String("bob")})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.True(t, apierr.Is(err, apierr.CodeUsernameTaken))
+}
+
+func TestGRPCProfile_GetProfileByUsername(t *testing.T) {
+	profile := new(authMocks.Profile)
+	server := profileServerAPI{profile: profile}
+
+	profile.On("GetProfileByUsername", ctx, "alice").Return(domain.Profile{UserID: 42, Username: "alice"}, nil)
+	profile.On("GetProfileByUsername", ctx, "ghost").Return(domain.Profile{}, fmt.Errorf("wrap: %w", repository.ErrUserNotFound))
+
+	resp, err := server.GetProfileByUsername(ctx, &authv1.GetProfileByUsernameRequest{Username: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), resp.GetUserId())
+
+	_, err = server.GetProfileByUsername(ctx, &authv1.GetProfileByUsernameRequest{Username: "ghost"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.True(t, apierr.Is(err, apierr.CodeUserNotFound))
+}
+
+func TestGRPCProfile_SearchUsers(t *testing.T) {
+	profile := new(authMocks.Profile)
+	server := profileServerAPI{profile: profile}
+
+	profile.On("SearchUsers", ctx, "ali", 5).Return([]domain.Profile{{UserID: 1, Username: "alice"}, {UserID: 2}}, nil)
+
+	resp, err := server.SearchUsers(ctx, &authv1.SearchUsersRequest{Query: "ali", Limit: 5})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetUsers(), 2)
+	assert.Equal(t, "alice", resp.GetUsers()[0].GetUsername())
+}
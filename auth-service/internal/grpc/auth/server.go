@@ -2,7 +2,11 @@
 package grpcauth
 
 import (
+	"auth/internal/domain"
 	"auth/internal/repository"
+	"auth/pkg/apierr"
+	"auth/pkg/authn"
+	"auth/pkg/password"
 	tokenjwt "auth/pkg/token"
 	authv1 "auth/proto/auth/v1"
 	"auth/provider"
@@ -12,7 +16,6 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -24,36 +27,46 @@ type Auth interface {
 	Logout(ctx context.Context, refreshToken string) (success bool, err error)
 	RefreshToken(ctx context.Context, refreshToken string) (token tokenjwt.Token, err error)
 	ValidateSession(ctx context.Context, sessionID int) (active bool, err error)
+	IntrospectToken(ctx context.Context, accessToken string, audience string) (info domain.TokenInfo, err error)
+	UsersExist(ctx context.Context, userIDs []int) (existing []int, err error)
+	ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) error
+}
+
+// UserEvents ...
+type UserEvents interface {
+	WatchUserEvents(ctx context.Context, afterID int64, fn func(e domain.AuditEvent, cursor int64) error) error
 }
 
 type serverAPI struct {
 	authv1.UnimplementedAuthServiceServer
-	auth   Auth
-	logger *slog.Logger
+	auth     Auth
+	external ExternalLogin
+	events   UserEvents
+	logger   *slog.Logger
 }
 
 // Register ...
-func Register(gRPCServer *grpc.Server, auth Auth, log *slog.Logger) {
-	authv1.RegisterAuthServiceServer(gRPCServer, &serverAPI{auth: auth, logger: log})
+func Register(gRPCServer *grpc.Server, auth Auth, external ExternalLogin, events UserEvents, log *slog.Logger) {
+	authv1.RegisterAuthServiceServer(gRPCServer, &serverAPI{auth: auth, external: external, events: events, logger: log})
 }
 
 // Ниже бизнес логика сервиса, rpc методы.
 
 // Register ...
 func (s *serverAPI) Register(ctx context.Context, req *authv1.RegisterRequest) (*authv1.RegisterResponse, error) {
-	if err := ValidateRegisterRequest(req); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "internal error")
-	}
-
 	userID, err := s.auth.Register(ctx, req.GetEmail(), req.GetPassword())
 	if err != nil {
 		if errors.Is(err, repository.ErrUserAlreadyExists) {
-			return nil, status.Error(codes.AlreadyExists, "user with this email already exists")
+			return nil, apierr.New(codes.AlreadyExists, apierr.CodeUserAlreadyExists, "user with this email already exists")
 		}
 		if errors.Is(err, repository.ErrInvalidCredentials) {
-			return nil, status.Error(codes.Unauthenticated, "invalid user")
+			return nil, apierr.New(codes.Unauthenticated, apierr.CodeInvalidCredentials, "invalid user")
 		}
-		return nil, status.Error(codes.Internal, "internal error")
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return nil, weakPassword("password", policyErr)
+		}
+		return nil, apierr.Internal()
 	}
 
 	return &authv1.RegisterResponse{
@@ -66,10 +79,18 @@ func (s *serverAPI) Login(ctx context.Context, req *authv1.LoginRequest) (*authv
 	token, err := s.auth.Login(ctx, req.GetEmail(), req.GetPassword(), int(req.GetAppId()))
 	if err != nil {
 		if errors.Is(err, repository.ErrInvalidCredentials) {
-			return nil, status.Error(codes.Unauthenticated, "wrong email or password")
+			return nil, apierr.New(codes.Unauthenticated, apierr.CodeInvalidCredentials, "wrong email or password")
+		}
+		if errors.Is(err, repository.ErrAppNotFound) {
+			return nil, apierr.New(codes.InvalidArgument, apierr.CodeUnknownApp, "unknown app")
+		}
+		if errors.Is(err, repository.ErrAppDisabled) {
+			return nil, apierr.New(codes.PermissionDenied, apierr.CodeAppDisabled, "app is disabled")
 		}
-		s.logger.Warn(err.Error())
-		return nil, status.Error(codes.Internal, "internal error")
+		if s.logger != nil {
+			s.logger.WarnContext(ctx, err.Error())
+		}
+		return nil, apierr.Internal()
 	}
 
 	return &authv1.LoginResponse{
@@ -80,14 +101,42 @@ func (s *serverAPI) Login(ctx context.Context, req *authv1.LoginRequest) (*authv
 	}, nil
 }
 
+// ChangePassword ...
+func (s *serverAPI) ChangePassword(ctx context.Context, req *authv1.ChangePasswordRequest) (*authv1.ChangePasswordResponse, error) {
+	userID, ok := authn.UserID(ctx)
+	if !ok {
+		return nil, apierr.New(codes.Unauthenticated, apierr.CodeUnauthenticated, "authentication required")
+	}
+
+	err := s.auth.ChangePassword(ctx, userID, req.GetCurrentPassword(), req.GetNewPassword())
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCredentials) {
+			return nil, apierr.New(codes.Unauthenticated, apierr.CodeInvalidCredentials, "wrong current password")
+		}
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, apierr.New(codes.NotFound, apierr.CodeUserNotFound, "user not found")
+		}
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			return nil, weakPassword("new_password", policyErr)
+		}
+		if s.logger != nil {
+			s.logger.WarnContext(ctx, err.Error())
+		}
+		return nil, apierr.Internal()
+	}
+
+	return &authv1.ChangePasswordResponse{}, nil
+}
+
 // IsAdmin ...
 func (s *serverAPI) IsAdmin(ctx context.Context, req *authv1.IsAdminRequest) (*authv1.IsAdminResponse, error) {
 	isAdmin, err := s.auth.IsAdmin(ctx, int(req.GetUserId()))
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			return nil, status.Error(codes.NotFound, "user not found")
+			return nil, apierr.New(codes.NotFound, apierr.CodeUserNotFound, "user not found")
 		}
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, apierr.Internal()
 	}
 
 	return &authv1.IsAdminResponse{
@@ -100,9 +149,9 @@ func (s *serverAPI) Logout(ctx context.Context, req *authv1.LogoutRequest) (*aut
 	success, err := s.auth.Logout(ctx, req.GetRefreshToken())
 	if err != nil {
 		if errors.Is(err, repository.ErrInvalidCredentials) {
-			return nil, status.Error(codes.Unauthenticated, "invalid user")
+			return nil, apierr.New(codes.Unauthenticated, apierr.CodeInvalidRefreshToken, "invalid refresh token")
 		}
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, apierr.Internal()
 	}
 
 	return &authv1.LogoutResponse{
@@ -114,13 +163,13 @@ func (s *serverAPI) Logout(ctx context.Context, req *authv1.LogoutRequest) (*aut
 func (s *serverAPI) RefreshToken(ctx context.Context, req *authv1.RefreshTokenRequest) (*authv1.RefreshTokenResponse, error) {
 	token, err := s.auth.RefreshToken(ctx, req.RefreshToken)
 	if err != nil {
-		if errors.Is(err, repository.ErrInvalidCredentials) {
-			return nil, status.Error(codes.Unauthenticated, "invalid user")
+		if errors.Is(err, repository.ErrInvalidCredentials) || errors.Is(err, provider.ErrInvalidRefreshToken) {
+			return nil, apierr.New(codes.Unauthenticated, apierr.CodeInvalidRefreshToken, "invalid refresh token")
 		}
-		if errors.Is(err, provider.ErrInvalidRefreshToken) {
-			return nil, status.Error(codes.Unauthenticated, "invalid user")
+		if errors.Is(err, repository.ErrAppNotFound) || errors.Is(err, repository.ErrAppDisabled) {
+			return nil, apierr.New(codes.PermissionDenied, apierr.CodeAppDisabled, "app is disabled")
 		}
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, apierr.Internal()
 	}
 
 	return &authv1.RefreshTokenResponse{
@@ -136,10 +185,105 @@ func (s *serverAPI) ValidateSession(ctx context.Context, req *authv1.ValidateSes
 
 	active, err := s.auth.ValidateSession(ctx, int(req.GetSessionId()))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, apierr.Internal()
 	}
 
 	return &authv1.ValidateSessionResponse{
 		Active: active,
 	}, nil
 }
+
+// IntrospectToken ...
+func (s *serverAPI) IntrospectToken(ctx context.Context, req *authv1.IntrospectTokenRequest) (*authv1.IntrospectTokenResponse, error) {
+	info, err := s.auth.IntrospectToken(ctx, req.GetAccessToken(), req.GetAudience())
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WarnContext(ctx, err.Error())
+		}
+		return nil, apierr.Internal()
+	}
+	if !info.Active {
+		return &authv1.IntrospectTokenResponse{Active: false}, nil
+	}
+
+	return &authv1.IntrospectTokenResponse{
+		Active:    true,
+		UserId:    int64(info.UserID),
+		SessionId: int64(info.SessionID),
+		AppId:     int32(info.AppID), // #nosec G115 -- app_id в БД INT
+		Roles:     info.Roles,
+		ExpiresAt: timestamppb.New(info.ExpiresAt),
+	}, nil
+}
+
+// UsersExist ...
+func (s *serverAPI) UsersExist(ctx context.Context, req *authv1.UsersExistRequest) (*authv1.UsersExistResponse, error) {
+	ids := make([]int, len(req.GetUserIds()))
+	for i, id := range req.GetUserIds() {
+		ids[i] = int(id)
+	}
+
+	existing, err := s.auth.UsersExist(ctx, ids)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WarnContext(ctx, err.Error())
+		}
+		return nil, apierr.Internal()
+	}
+
+	resp := &authv1.UsersExistResponse{ExistingUserIds: make([]int64, len(existing))}
+	for i, id := range existing {
+		resp.ExistingUserIds[i] = int64(id)
+	}
+
+	return resp, nil
+}
+
+// WatchUserEvents ...
+func (s *serverAPI) WatchUserEvents(req *authv1.WatchUserEventsRequest, stream grpc.ServerStreamingServer[authv1.UserEvent]) error {
+	ctx := stream.Context()
+
+	err := s.events.WatchUserEvents(ctx, req.GetAfterId(), func(e domain.AuditEvent, cursor int64) error {
+		return stream.Send(userEventToProto(e, cursor))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if s.logger != nil {
+			s.logger.WarnContext(ctx, err.Error())
+		}
+		return apierr.Internal()
+	}
+
+	return nil
+}
+
+func userEventToProto(e domain.AuditEvent, cursor int64) *authv1.UserEvent {
+	t := authv1.UserEventType_USER_EVENT_TYPE_UNSPECIFIED
+	if e.Type == domain.AuditUserDeleted {
+		t = authv1.UserEventType_USER_EVENT_TYPE_DELETED
+	}
+
+	return &authv1.UserEvent{
+		Id:        e.ID,
+		Type:      t,
+		UserId:    int64(e.UserID),
+		CreatedAt: timestamppb.New(e.CreatedAt),
+		Cursor:    cursor,
+	}
+}
+
+// weakPassword - WEAK_PASSWORD с нарушением на каждое правило политики,
+// чтобы клиент мог подсветить все сразу.
+func weakPassword(field string, err *password.PolicyError) error {
+	violations := make([]apierr.FieldViolation, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		violations = append(violations, apierr.FieldViolation{
+			Field:       field,
+			Description: v.Message,
+			Reason:      string(v.Rule),
+		})
+	}
+	return apierr.InvalidCode(apierr.CodeWeakPassword, "password does not meet policy", violations...)
+}
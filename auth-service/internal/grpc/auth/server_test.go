@@ -1,7 +1,11 @@
 package grpcauth
 
 import (
+	"auth/internal/repository"
 	authMocks "auth/mocks/auth"
+	"auth/pkg/apierr"
+	"auth/pkg/authn"
+	"auth/pkg/password"
 	tokenjwt "auth/pkg/token"
 	authv1 "auth/proto/auth/v1"
 	"context"
@@ -71,6 +75,109 @@ func TestGRPCAuth_RegisterInternalError(t *testing.T) {
 	auth.AssertExpectations(t)
 }
 
+func TestGRPCAuth_RegisterAlreadyExists(t *testing.T) {
+	auth := new(authMocks.Auth)
+	req := &authv1.RegisterRequest{
+		Email:    "user@example.org",
+		Password: "password",
+	}
+
+	server := serverAPI{
+		auth: auth,
+	}
+
+	auth.
+		On("Register", ctx, req.GetEmail(), req.GetPassword()).
+		Return(0, fmt.Errorf("Auth.Register: %w", repository.ErrUserAlreadyExists))
+
+	_, err := server.Register(ctx, req)
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.True(t, apierr.Is(err, apierr.CodeUserAlreadyExists))
+
+	auth.AssertExpectations(t)
+}
+
+func TestGRPCAuth_RegisterWeakPassword(t *testing.T) {
+	auth := new(authMocks.Auth)
+	req := &authv1.RegisterRequest{
+		Email:    "user@example.org",
+		Password: "short",
+	}
+
+	server := serverAPI{
+		auth: auth,
+	}
+
+	auth.
+		On("Register", ctx, req.GetEmail(), req.GetPassword()).
+		Return(0, fmt.Errorf("Auth.Register: %w", &password.PolicyError{Violations: []password.Violation{
+			{Rule: password.RuleTooShort, Message: "must be at least 8 characters long"},
+			{Rule: password.RuleNoDigit, Message: "must contain a digit"},
+		}}))
+
+	_, err := server.Register(ctx, req)
+
+	apiErr := apierr.Parse(err)
+	assert.Equal(t, codes.InvalidArgument, apiErr.Status)
+	assert.Equal(t, apierr.CodeWeakPassword, apiErr.Code)
+	assert.Equal(t, []apierr.FieldViolation{
+		{Field: "password", Description: "must be at least 8 characters long", Reason: "PASSWORD_TOO_SHORT"},
+		{Field: "password", Description: "must contain a digit", Reason: "PASSWORD_NO_DIGIT"},
+	}, apiErr.Violations)
+
+	auth.AssertExpectations(t)
+}
+
+func TestGRPCAuth_ChangePassword(t *testing.T) {
+	req := &authv1.ChangePasswordRequest{CurrentPassword: "old-Passw0rd", NewPassword: "short"}
+	authed := authn.NewContext(ctx, authn.Principal{UserID: 42})
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		err        error
+		wantStatus codes.Code
+		wantCode   apierr.Code
+	}{
+		{"success", authed, nil, codes.OK, ""},
+		{"no principal", ctx, nil, codes.Unauthenticated, apierr.CodeUnauthenticated},
+		{"wrong current password", authed, fmt.Errorf("Auth.ChangePassword: %w", repository.ErrInvalidCredentials), codes.Unauthenticated, apierr.CodeInvalidCredentials},
+		{"weak new password", authed, fmt.Errorf("Auth.ChangePassword: %w", &password.PolicyError{Violations: []password.Violation{
+			{Rule: password.RuleTooShort, Message: "must be at least 8 characters long"},
+		}}), codes.InvalidArgument, apierr.CodeWeakPassword},
+		{"internal", authed, fmt.Errorf("failed"), codes.Internal, apierr.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := new(authMocks.Auth)
+			server := serverAPI{auth: auth}
+
+			auth.
+				On("ChangePassword", tt.ctx, 42, req.GetCurrentPassword(), req.GetNewPassword()).
+				Return(tt.err).
+				Maybe()
+
+			_, err := server.ChangePassword(tt.ctx, req)
+
+			if tt.wantStatus == codes.OK {
+				require.NoError(t, err)
+				auth.AssertExpectations(t)
+				return
+			}
+			apiErr := apierr.Parse(err)
+			assert.Equal(t, tt.wantStatus, apiErr.Status)
+			assert.Equal(t, tt.wantCode, apiErr.Code)
+			if tt.wantCode == apierr.CodeWeakPassword {
+				assert.Equal(t, []apierr.FieldViolation{
+					{Field: "new_password", Description: "must be at least 8 characters long", Reason: "PASSWORD_TOO_SHORT"},
+				}, apiErr.Violations)
+			}
+		})
+	}
+}
+
 func TestGRPCAuth_LoginSuccess(t *testing.T) {
 	auth := new(authMocks.Auth)
 	req := &authv1.LoginRequest{
@@ -132,6 +239,32 @@ func TestGRPCAuth_LoginInternalError(t *testing.T) {
 	auth.AssertExpectations(t)
 }
 
+func TestGRPCAuth_LoginUnknownApp(t *testing.T) {
+	unknownApp := apierr.New(codes.InvalidArgument, apierr.CodeUnknownApp, "unknown app")
+
+	auth := new(authMocks.Auth)
+	req := &authv1.LoginRequest{
+		Email:    "user@example.org",
+		Password: "password",
+		AppId:    7,
+	}
+
+	server := serverAPI{
+		auth: auth,
+	}
+
+	auth.
+		On("Login", ctx, req.GetEmail(), req.GetPassword(), int(req.GetAppId())).
+		Return(tokenjwt.Token{}, fmt.Errorf("Auth.Login: %w", repository.ErrAppNotFound))
+
+	resp, err := server.Login(ctx, req)
+
+	require.ErrorIs(t, err, unknownApp)
+	assert.Nil(t, resp)
+
+	auth.AssertExpectations(t)
+}
+
 func TestGRPCAuth_IsAdminSuccessTrue(t *testing.T) {
 	auth := new(authMocks.Auth)
 	req := &authv1.IsAdminRequest{
@@ -0,0 +1,80 @@
+package grpcauth
+
+import (
+	"auth/internal/domain"
+	authMocks "auth/mocks/auth"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userEventStream - серверный стрим WatchUserEvents без сети.
+type userEventStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*authv1.UserEvent
+}
+
+func (s *userEventStream) Context() context.Context { return s.ctx }
+
+func (s *userEventStream) Send(e *authv1.UserEvent) error {
+	s.sent = append(s.sent, e)
+	return nil
+}
+
+func TestGRPCAuth_UsersExist(t *testing.T) {
+	auth := new(authMocks.Auth)
+	server := serverAPI{auth: auth}
+
+	auth.On("UsersExist", ctx, []int{1, 2}).Return([]int{2}, nil)
+
+	resp, err := server.UsersExist(ctx, &authv1.UsersExistRequest{UserIds: []int64{1, 2}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, resp.GetExistingUserIds())
+}
+
+func TestGRPCAuth_WatchUserEvents(t *testing.T) {
+	events := new(authMocks.UserEvents)
+	server := serverAPI{events: events}
+	stream := &userEventStream{ctx: ctx}
+
+	deletedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	events.
+		On("WatchUserEvents", ctx, int64(5), mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(domain.AuditEvent, int64) error)
+			_ = fn(domain.AuditEvent{ID: 6, Type: domain.AuditUserDeleted, UserID: 42, CreatedAt: deletedAt}, 5)
+		}).
+		Return(nil)
+
+	err := server.WatchUserEvents(&authv1.WatchUserEventsRequest{AfterId: 5}, stream)
+
+	require.NoError(t, err)
+	require.Len(t, stream.sent, 1)
+	assert.Equal(t, int64(6), stream.sent[0].GetId())
+	assert.Equal(t, authv1.UserEventType_USER_EVENT_TYPE_DELETED, stream.sent[0].GetType())
+	assert.Equal(t, int64(42), stream.sent[0].GetUserId())
+	assert.Equal(t, deletedAt, stream.sent[0].GetCreatedAt().AsTime())
+	assert.Equal(t, int64(5), stream.sent[0].GetCursor())
+}
+
+func TestGRPCAuth_WatchUserEvents_Error(t *testing.T) {
+	events := new(authMocks.UserEvents)
+	server := serverAPI{events: events}
+
+	events.On("WatchUserEvents", ctx, int64(0), mock.Anything).Return(errors.New("db down"))
+
+	err := server.WatchUserEvents(&authv1.WatchUserEventsRequest{}, &userEventStream{ctx: ctx})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -9,9 +9,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
+// rolesTTL ограничивает жизнь ролей в кэше, если сброс по role.changed не дошёл.
+const rolesTTL = 10 * time.Minute
+
 // Store ...
 type Store struct {
 	client *redis.Client
@@ -24,6 +28,11 @@ func NewCacheStore(cfg config.Config) (*Store, error) {
 		Addr: cfg.RedisAddr,
 	})
 
+	// Спан на каждую команду Redis
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, err
+	}
+
 	if err := rdb.Ping(context.Background()).Err(); err != nil {
 		return nil, err
 	}
@@ -35,6 +44,11 @@ func NewCacheStore(cfg config.Config) (*Store, error) {
 	}, nil
 }
 
+// Ping ...
+func (s *Store) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
 // Close ...
 func (s *Store) Close() error {
 	return s.client.Close()
@@ -85,3 +99,40 @@ func (s *Store) GetSession(ctx context.Context, keyID int) (bool, domain.Session
 func (s *Store) DelSession(ctx context.Context, keyID int) error {
 	return s.client.Del(ctx, sessionKey(keyID)).Err()
 }
+
+func rolesKey(userID int) string {
+	return fmt.Sprintf("roles:%d", userID)
+}
+
+// SetRoles ...
+func (s *Store) SetRoles(ctx context.Context, userID int, roles []string) error {
+	data, err := json.Marshal(roles)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, rolesKey(userID), data, rolesTTL).Err()
+}
+
+// GetRoles ...
+func (s *Store) GetRoles(ctx context.Context, userID int) (bool, []string, error) {
+	b, err := s.client.Get(ctx, rolesKey(userID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+
+	var roles []string
+	if err := json.Unmarshal(b, &roles); err != nil {
+		return false, nil, err
+	}
+
+	return true, roles, nil
+}
+
+// DelRoles ...
+func (s *Store) DelRoles(ctx context.Context, userID int) error {
+	return s.client.Del(ctx, rolesKey(userID)).Err()
+}
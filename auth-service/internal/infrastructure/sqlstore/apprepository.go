@@ -0,0 +1,92 @@
+package sqlstore
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const appColumns = `id, name, redirect_uris, scopes, access_token_ttl_sec, refresh_token_ttl_sec, enabled, client_secret_hash`
+
+// AppRepository ...
+type AppRepository struct {
+	db *sql.DB
+}
+
+// NewAppRepository ...
+func NewAppRepository(db *sql.DB) *AppRepository {
+	return &AppRepository{db: db}
+}
+
+// AppByID ...
+func (r *AppRepository) AppByID(ctx context.Context, id int) (domain.App, error) {
+	const op = "AppRepository.AppByID"
+
+	q := `SELECT ` + appColumns + `
+	      FROM apps
+	      WHERE id = $1`
+
+	a, err := scanApp(r.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		return domain.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return a, nil
+}
+
+// AppByName ...
+func (r *AppRepository) AppByName(ctx context.Context, name string) (domain.App, error) {
+	const op = "AppRepository.AppByName"
+
+	q := `SELECT ` + appColumns + `
+	      FROM apps
+	      WHERE name = $1`
+
+	a, err := scanApp(r.db.QueryRowContext(ctx, q, name))
+	if err != nil {
+		return domain.App{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return a, nil
+}
+
+func scanApp(row *sql.Row) (domain.App, error) {
+	var (
+		a          domain.App
+		accessTTL  sql.NullInt64
+		refreshTTL sql.NullInt64
+		secretHash sql.NullString
+	)
+
+	err := row.Scan(
+		&a.ID,
+		&a.Name,
+		pq.Array(&a.RedirectURIs),
+		pq.Array(&a.Scopes),
+		&accessTTL,
+		&refreshTTL,
+		&a.Enabled,
+		&secretHash,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.App{}, repository.ErrAppNotFound
+		}
+
+		return domain.App{}, err
+	}
+
+	a.AccessTokenTTL = time.Duration(accessTTL.Int64) * time.Second
+	a.RefreshTokenTTL = time.Duration(refreshTTL.Int64) * time.Second
+	if secretHash.Valid {
+		a.ClientSecretHash = []byte(secretHash.String)
+	}
+
+	return a, nil
+}
@@ -0,0 +1,31 @@
+package sqlstore_test
+
+import (
+	"auth/internal/infrastructure/sqlstore"
+	"auth/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppRepository_FindByID(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown()
+	a := sqlstore.NewAppRepository(db)
+
+	// app_id = 1 создаётся миграцией 0002_apps
+	app, err := a.AppByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "messenger", app.Name)
+	assert.True(t, app.Enabled)
+	assert.Zero(t, app.AccessTokenTTL)
+}
+
+func TestAppRepository_NotFound(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown()
+	a := sqlstore.NewAppRepository(db)
+
+	_, err := a.AppByID(ctx, -1)
+	assert.ErrorIs(t, err, repository.ErrAppNotFound)
+}
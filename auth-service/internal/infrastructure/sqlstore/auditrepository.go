@@ -0,0 +1,141 @@
+package sqlstore
+
+import (
+	"auth/internal/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// AuditRepository ...
+type AuditRepository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository ...
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// SaveEvent ...
+func (r *AuditRepository) SaveEvent(ctx context.Context, e domain.AuditEvent) error {
+	const op = "AuditRepository.SaveEvent"
+
+	details, err := json.Marshal(e.Details)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if e.Details == nil {
+		details = []byte("{}")
+	}
+
+	q := `INSERT INTO audit_events (type, user_id, actor_id, app_id, session_id, ip, user_agent, request_id, details)
+	      VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = r.db.ExecContext(ctx, q,
+		string(e.Type),
+		nullID(e.UserID),
+		nullID(e.ActorID),
+		nullID(e.AppID),
+		nullID(e.SessionID),
+		e.IP,
+		e.UserAgent,
+		e.RequestID,
+		details,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListEvents ...
+func (r *AuditRepository) ListEvents(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEvent, error) {
+	const op = "AuditRepository.ListEvents"
+
+	var (
+		where []string
+		args  []any
+	)
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.AfterID > 0 {
+		add("id > $%d", f.AfterID)
+	}
+	if f.UserID != 0 {
+		add("user_id = $%d", f.UserID)
+	}
+	if len(f.Types) > 0 {
+		types := make([]string, len(f.Types))
+		for i, t := range f.Types {
+			types[i] = string(t)
+		}
+		add("type = ANY($%d)", pq.Array(types))
+	}
+	if !f.From.IsZero() {
+		add("created_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("created_at < $%d", f.To)
+	}
+
+	q := `SELECT id, type, COALESCE(user_id, 0), COALESCE(actor_id, 0), COALESCE(app_id, 0), COALESCE(session_id, 0),
+	             ip, user_agent, request_id, details, created_at
+	      FROM audit_events`
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, f.Limit)
+	q += fmt.Sprintf(" ORDER BY id LIMIT $%d", len(args))
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []domain.AuditEvent
+	for rows.Next() {
+		var (
+			e       domain.AuditEvent
+			details []byte
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.Type,
+			&e.UserID,
+			&e.ActorID,
+			&e.AppID,
+			&e.SessionID,
+			&e.IP,
+			&e.UserAgent,
+			&e.RequestID,
+			&details,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := json.Unmarshal(details, &e.Details); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// nullID - 0 в колонку как NULL: "не известен", а не ссылка на несуществующий id.
+func nullID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
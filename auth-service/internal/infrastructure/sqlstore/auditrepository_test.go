@@ -0,0 +1,81 @@
+package sqlstore_test
+
+import (
+	"auth/internal/domain"
+	"auth/internal/infrastructure/sqlstore"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepository_SaveAndList(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("audit_events")
+	a := sqlstore.NewAuditRepository(db)
+
+	events := []domain.AuditEvent{
+		{Type: domain.AuditLoginFailed, UserID: 7, AppID: 1, IP: "203.0.113.7", Details: map[string]string{"reason": "invalid_password"}},
+		{Type: domain.AuditLoginSucceeded, UserID: 7, AppID: 1, SessionID: 3},
+		{Type: domain.AuditLoginFailed, AppID: 1, Details: map[string]string{"email": "nobody@example.org"}},
+	}
+	for _, e := range events {
+		require.NoError(t, a.SaveEvent(ctx, e))
+	}
+
+	all, err := a.ListEvents(ctx, domain.AuditFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Less(t, all[0].ID, all[1].ID)
+	assert.Equal(t, "invalid_password", all[0].Details["reason"])
+	assert.Equal(t, "203.0.113.7", all[0].IP)
+	assert.Zero(t, all[2].UserID)
+	assert.Empty(t, all[1].Details)
+
+	byUser, err := a.ListEvents(ctx, domain.AuditFilter{UserID: 7, Types: []domain.AuditEventType{domain.AuditLoginFailed}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, byUser, 1)
+	assert.Equal(t, all[0].ID, byUser[0].ID)
+
+	page, err := a.ListEvents(ctx, domain.AuditFilter{AfterID: all[0].ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, all[1].ID, page[0].ID)
+
+	future, err := a.ListEvents(ctx, domain.AuditFilter{From: time.Now().Add(time.Hour), Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, future)
+}
+
+func TestAuditRepository_AppendOnly(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("audit_events")
+	a := sqlstore.NewAuditRepository(db)
+
+	require.NoError(t, a.SaveEvent(ctx, domain.AuditEvent{Type: domain.AuditLogout, UserID: 7}))
+
+	_, err := db.ExecContext(ctx, "UPDATE audit_events SET user_id = 8")
+	assert.Error(t, err)
+	_, err = db.ExecContext(ctx, "DELETE FROM audit_events")
+	assert.Error(t, err)
+}
+
+func TestAuditRepository_UserDeletedTrigger(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("users", "audit_events")
+	users := sqlstore.NewUserRepository(db)
+	a := sqlstore.NewAuditRepository(db)
+
+	require.NoError(t, users.SaveUser(ctx, "gone@example.org", nil))
+	u, err := users.UserByEmail(ctx, "gone@example.org")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", u.ID)
+	require.NoError(t, err)
+
+	events, err := a.ListEvents(ctx, domain.AuditFilter{Types: []domain.AuditEventType{domain.AuditUserDeleted}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, u.ID, events[0].UserID)
+}
@@ -0,0 +1,72 @@
+package sqlstore
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ExternalLoginRepository ...
+type ExternalLoginRepository struct {
+	db *sql.DB
+}
+
+// NewExternalLoginRepository ...
+func NewExternalLoginRepository(db *sql.DB) *ExternalLoginRepository {
+	return &ExternalLoginRepository{db: db}
+}
+
+// SaveState ...
+func (r *ExternalLoginRepository) SaveState(ctx context.Context, state domain.ExternalLoginState) error {
+	const op = "ExternalLoginRepository.SaveState"
+
+	q := `INSERT INTO external_login_states (state_hash, provider, app_id, nonce, code_verifier, expires_at)
+	      VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.ExecContext(ctx, q,
+		state.StateHash,
+		state.Provider,
+		state.AppID,
+		state.Nonce,
+		state.CodeVerifier,
+		state.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ConsumeState ...
+func (r *ExternalLoginRepository) ConsumeState(ctx context.Context, stateHash string) (domain.ExternalLoginState, error) {
+	const op = "ExternalLoginRepository.ConsumeState"
+
+	q := `UPDATE external_login_states
+	      SET used_at = now()
+	      WHERE state_hash = $1 AND used_at IS NULL
+	      RETURNING state_hash, provider, app_id, nonce, code_verifier, expires_at`
+
+	var s domain.ExternalLoginState
+
+	err := r.db.QueryRowContext(ctx, q, stateHash).Scan(
+		&s.StateHash,
+		&s.Provider,
+		&s.AppID,
+		&s.Nonce,
+		&s.CodeVerifier,
+		&s.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ExternalLoginState{}, fmt.Errorf("%s: %w", op, repository.ErrExternalStateNotFound)
+		}
+
+		return domain.ExternalLoginState{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
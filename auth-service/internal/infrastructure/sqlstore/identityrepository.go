@@ -0,0 +1,73 @@
+package sqlstore
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// IdentityRepository ...
+type IdentityRepository struct {
+	db *sql.DB
+}
+
+// NewIdentityRepository ...
+func NewIdentityRepository(db *sql.DB) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// IdentityBySubject ...
+func (r *IdentityRepository) IdentityBySubject(ctx context.Context, provider string, subject string) (domain.Identity, error) {
+	const op = "IdentityRepository.IdentityBySubject"
+
+	q := `SELECT id, user_id, provider, subject, email
+	      FROM identities
+	      WHERE provider = $1 AND subject = $2`
+
+	var i domain.Identity
+
+	err := r.db.QueryRowContext(ctx, q, provider, subject).Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Identity{}, fmt.Errorf("%s: %w", op, repository.ErrIdentityNotFound)
+		}
+
+		return domain.Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return i, nil
+}
+
+// LinkIdentity ...
+func (r *IdentityRepository) LinkIdentity(ctx context.Context, identity domain.Identity) error {
+	const op = "IdentityRepository.LinkIdentity"
+
+	q := `INSERT INTO identities (user_id, provider, subject, email) VALUES ($1, $2, $3, $4)`
+
+	_, err := r.db.ExecContext(ctx, q,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == pq.ErrorCode("23505") {
+			return fmt.Errorf("%s: %w", op, repository.ErrIdentityAlreadyLinked)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package sqlstore
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// AuthCodeRepository ...
+type AuthCodeRepository struct {
+	db *sql.DB
+}
+
+// NewAuthCodeRepository ...
+func NewAuthCodeRepository(db *sql.DB) *AuthCodeRepository {
+	return &AuthCodeRepository{db: db}
+}
+
+// SaveCode ...
+func (r *AuthCodeRepository) SaveCode(ctx context.Context, code domain.AuthCode) error {
+	const op = "AuthCodeRepository.SaveCode"
+
+	q := `INSERT INTO oauth_codes (code_hash, app_id, user_id, redirect_uri,
+	          code_challenge, code_challenge_method, scope, nonce, expires_at)
+	      VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.ExecContext(ctx, q,
+		code.CodeHash,
+		code.AppID,
+		code.UserID,
+		code.RedirectURI,
+		code.CodeChallenge,
+		code.CodeChallengeMethod,
+		code.Scope,
+		code.Nonce,
+		code.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ConsumeCode ...
+func (r *AuthCodeRepository) ConsumeCode(ctx context.Context, codeHash string) (domain.AuthCode, error) {
+	const op = "AuthCodeRepository.ConsumeCode"
+
+	q := `UPDATE oauth_codes
+	      SET used_at = now()
+	      WHERE code_hash = $1 AND used_at IS NULL
+	      RETURNING code_hash, app_id, user_id, redirect_uri,
+	          code_challenge, code_challenge_method, scope, nonce, expires_at`
+
+	var c domain.AuthCode
+
+	err := r.db.QueryRowContext(ctx, q, codeHash).Scan(
+		&c.CodeHash,
+		&c.AppID,
+		&c.UserID,
+		&c.RedirectURI,
+		&c.CodeChallenge,
+		&c.CodeChallengeMethod,
+		&c.Scope,
+		&c.Nonce,
+		&c.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.AuthCode{}, fmt.Errorf("%s: %w", op, repository.ErrAuthCodeNotFound)
+		}
+
+		return domain.AuthCode{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return c, nil
+}
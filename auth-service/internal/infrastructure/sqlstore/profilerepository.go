@@ -0,0 +1,171 @@
+package sqlstore
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ProfileRepository ...
+type ProfileRepository struct {
+	db *sql.DB
+}
+
+// NewProfileRepository ...
+func NewProfileRepository(db *sql.DB) *ProfileRepository {
+	return &ProfileRepository{db: db}
+}
+
+// ProfilesByUserIDs ...
+func (r *ProfileRepository) ProfilesByUserIDs(ctx context.Context, ids []int) ([]domain.Profile, error) {
+	const op = "ProfileRepository.ProfilesByUserIDs"
+
+	q := `SELECT u.id, COALESCE(p.username, ''), COALESCE(p.display_name, ''), COALESCE(p.avatar_url, ''),
+	             COALESCE(p.bio, ''), p.updated_at
+	      FROM users u
+	      LEFT JOIN profiles p ON p.user_id = u.id
+	      WHERE u.id = ANY($1)
+	      ORDER BY u.id`
+
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	profiles, err := scanProfiles(rows, len(ids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profiles, nil
+}
+
+// ProfileByUsername ...
+func (r *ProfileRepository) ProfileByUsername(ctx context.Context, username string) (domain.Profile, error) {
+	const op = "ProfileRepository.ProfileByUsername"
+
+	q := `SELECT u.id, COALESCE(p.username, ''), p.display_name, p.avatar_url, p.bio, p.updated_at
+	      FROM profiles p
+	      JOIN users u ON u.id = p.user_id
+	      WHERE lower(p.username) = lower($1)`
+
+	var (
+		p         domain.Profile
+		updatedAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, q, username).
+		Scan(&p.UserID, &p.Username, &p.DisplayName, &p.AvatarURL, &p.Bio, &updatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Profile{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+		return domain.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+	p.UpdatedAt = updatedAt.Time
+
+	return p, nil
+}
+
+// SearchProfiles ...
+// Кандидаты собираются отдельно по users и profiles, чтобы каждая ветка шла
+// по своим trigram индексам (миграция 0007_user_search).
+func (r *ProfileRepository) SearchProfiles(ctx context.Context, query string, limit int) ([]domain.Profile, error) {
+	const op = "ProfileRepository.SearchProfiles"
+
+	q := `WITH matches AS (
+	          SELECT id AS user_id FROM users
+	          WHERE lower(email) LIKE $2 OR lower(email) % $1
+	          UNION
+	          SELECT user_id FROM profiles
+	          WHERE username LIKE $2 OR username % $1
+	             OR lower(display_name) LIKE $2 OR lower(display_name) % $1
+	      )
+	      SELECT u.id, COALESCE(p.username, ''), COALESCE(p.display_name, ''), COALESCE(p.avatar_url, ''),
+	             COALESCE(p.bio, ''), p.updated_at
+	      FROM matches m
+	      JOIN users u ON u.id = m.user_id
+	      LEFT JOIN profiles p ON p.user_id = u.id
+	      ORDER BY (lower(u.email) LIKE $2
+	                OR COALESCE(p.username, '') LIKE $2
+	                OR lower(COALESCE(p.display_name, '')) LIKE $2) DESC,
+	               GREATEST(similarity(lower(u.email), $1),
+	                        similarity(COALESCE(p.username, ''), $1),
+	                        similarity(lower(COALESCE(p.display_name, '')), $1)) DESC,
+	               u.id
+	      LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, q, query, likePrefix(query), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	profiles, err := scanProfiles(rows, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profiles, nil
+}
+
+// SaveProfile ...
+func (r *ProfileRepository) SaveProfile(ctx context.Context, p domain.Profile) error {
+	const op = "ProfileRepository.SaveProfile"
+
+	q := `INSERT INTO profiles (user_id, username, display_name, avatar_url, bio)
+	      VALUES ($1, NULLIF($2, ''), $3, $4, $5)
+	      ON CONFLICT (user_id) DO UPDATE SET
+	          username     = EXCLUDED.username,
+	          display_name = EXCLUDED.display_name,
+	          avatar_url   = EXCLUDED.avatar_url,
+	          bio          = EXCLUDED.bio,
+	          updated_at   = now()`
+
+	_, err := r.db.ExecContext(ctx, q, p.UserID, p.Username, p.DisplayName, p.AvatarURL, p.Bio)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return fmt.Errorf("%s: %w", op, repository.ErrUsernameTaken)
+			case "23503":
+				return fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func scanProfiles(rows *sql.Rows, capacity int) ([]domain.Profile, error) {
+	profiles := make([]domain.Profile, 0, capacity)
+	for rows.Next() {
+		var (
+			p         domain.Profile
+			updatedAt sql.NullTime
+		)
+		if err := rows.Scan(&p.UserID, &p.Username, &p.DisplayName, &p.AvatarURL, &p.Bio, &updatedAt); err != nil {
+			return nil, err
+		}
+		p.UpdatedAt = updatedAt.Time
+		profiles = append(profiles, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// likePrefix - шаблон LIKE "начинается с s": _ в username не должен значить "любой символ".
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s) + "%"
+}
@@ -0,0 +1,107 @@
+package sqlstore_test
+
+import (
+	"auth/internal/domain"
+	"auth/internal/infrastructure/sqlstore"
+	"auth/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileRepository_SaveAndGet(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("users", "profiles")
+	users := sqlstore.NewUserRepository(db)
+	p := sqlstore.NewProfileRepository(db)
+
+	require.NoError(t, users.SaveUser(ctx, "alice@example.org", nil))
+	require.NoError(t, users.SaveUser(ctx, "bob@example.org", nil))
+	alice, err := users.UserByEmail(ctx, "alice@example.org")
+	require.NoError(t, err)
+	bob, err := users.UserByEmail(ctx, "bob@example.org")
+	require.NoError(t, err)
+
+	err = p.SaveProfile(ctx, domain.Profile{UserID: alice.ID, Username: "alice", DisplayName: "Alice"})
+	require.NoError(t, err)
+
+	profiles, err := p.ProfilesByUserIDs(ctx, []int{bob.ID, alice.ID, bob.ID + 1000})
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "alice", profiles[0].Username)
+	assert.Equal(t, "Alice", profiles[0].DisplayName)
+	assert.False(t, profiles[0].UpdatedAt.IsZero())
+	// Профиль bob не заполнен
+	assert.Equal(t, domain.Profile{UserID: bob.ID}, profiles[1])
+
+	// username уникален без учёта регистра
+	err = p.SaveProfile(ctx, domain.Profile{UserID: bob.ID, Username: "ALICE"})
+	assert.ErrorIs(t, err, repository.ErrUsernameTaken)
+
+	err = p.SaveProfile(ctx, domain.Profile{UserID: bob.ID + 1000, DisplayName: "ghost"})
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
+
+func TestProfileRepository_ProfileByUsername(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("users", "profiles")
+	users := sqlstore.NewUserRepository(db)
+	p := sqlstore.NewProfileRepository(db)
+
+	require.NoError(t, users.SaveUser(ctx, "alice@example.org", nil))
+	alice, err := users.UserByEmail(ctx, "alice@example.org")
+	require.NoError(t, err)
+	require.NoError(t, p.SaveProfile(ctx, domain.Profile{UserID: alice.ID, Username: "alice"}))
+
+	got, err := p.ProfileByUsername(ctx, "Alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, got.UserID)
+
+	_, err = p.ProfileByUsername(ctx, "bob")
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
+
+func TestProfileRepository_SearchProfiles(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("users", "profiles")
+	users := sqlstore.NewUserRepository(db)
+	p := sqlstore.NewProfileRepository(db)
+
+	ids := make(map[string]int)
+	for _, email := range []string{"alice@example.org", "alicia@example.org", "bob@example.org", "carol@example.org"} {
+		require.NoError(t, users.SaveUser(ctx, email, nil))
+		u, err := users.UserByEmail(ctx, email)
+		require.NoError(t, err)
+		ids[email] = u.ID
+	}
+	require.NoError(t, p.SaveProfile(ctx, domain.Profile{UserID: ids["bob@example.org"], Username: "bob_builder", DisplayName: "Robert Alison"}))
+	require.NoError(t, p.SaveProfile(ctx, domain.Profile{UserID: ids["carol@example.org"], Username: "carol"}))
+
+	// Префикс email и display_name
+	found, err := p.SearchProfiles(ctx, "ali", 10)
+	require.NoError(t, err)
+	var foundIDs []int
+	for _, f := range found {
+		foundIDs = append(foundIDs, f.UserID)
+	}
+	assert.Contains(t, foundIDs, ids["alice@example.org"])
+	assert.Contains(t, foundIDs, ids["alicia@example.org"])
+	assert.NotContains(t, foundIDs, ids["carol@example.org"])
+
+	// _ - обычный символ, а не "любой"
+	found, err = p.SearchProfiles(ctx, "bob_", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, found)
+	assert.Equal(t, "bob_builder", found[0].Username)
+
+	// Опечатка находится по похожести
+	found, err = p.SearchProfiles(ctx, "carrol", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, found)
+	assert.Equal(t, ids["carol@example.org"], found[0].UserID)
+
+	found, err = p.SearchProfiles(ctx, "ali", 1)
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+}
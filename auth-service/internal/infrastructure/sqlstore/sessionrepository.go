@@ -28,7 +28,7 @@ func NewSessionRepository(db *sql.DB) *SessionRepository {
 func (r *SessionRepository) SessionByID(ctx context.Context, id int) (domain.Session, error) {
 	const op = "SessionRepository.SessionByID"
 
-	q := `SELECT id, user_id, app_id, refresh_expires_at, status
+	q := `SELECT id, user_id, app_id, refresh_expires_at, status, scope
 	      FROM sessions
 	      WHERE id = $1`
 
@@ -40,6 +40,7 @@ func (r *SessionRepository) SessionByID(ctx context.Context, id int) (domain.Ses
 		&s.AppID,
 		&s.RefreshExpiresAt,
 		&s.Status,
+		&s.Scope,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -59,11 +60,12 @@ func (r *SessionRepository) CreateSession(
 	appID int,
 	refreshToken string,
 	refExpiresAt time.Time,
+	scope string,
 ) (sessionID int, err error) {
 	const op = "SessionRepository.CreateSession"
 
-	q := `INSERT INTO sessions (user_id, app_id, refresh_token, refresh_expires_at)
-	      VALUES ($1, $2, $3, $4)
+	q := `INSERT INTO sessions (user_id, app_id, refresh_token, refresh_expires_at, scope)
+	      VALUES ($1, $2, $3, $4, $5)
 	      RETURNING id`
 
 	err = r.db.QueryRowContext(ctx, q,
@@ -71,6 +73,7 @@ func (r *SessionRepository) CreateSession(
 		appID,
 		refreshToken,
 		refExpiresAt,
+		scope,
 	).Scan(&sessionID)
 	if err != nil {
 		return emptyID, fmt.Errorf("%s: %w", op, err)
@@ -100,11 +103,32 @@ func (r *SessionRepository) RevokeByRefreshToken(ctx context.Context, refreshTok
 	return rows > 0, nil
 }
 
+// RevokeByID ...
+func (r *SessionRepository) RevokeByID(ctx context.Context, id int) (revoked bool, err error) {
+	const op = "SessionRepository.RevokeByID"
+
+	q := `UPDATE sessions
+	      SET status = 'revoked', updated_at = now()
+	      WHERE id = $1 AND status = 'active'`
+
+	res, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rows > 0, nil
+}
+
 // SessionByRefreshToken ...
 func (r *SessionRepository) SessionByRefreshToken(ctx context.Context, refreshToken string) (session domain.Session, err error) {
 	const op = "SessionRepository.SessionByRefreshToken"
 
-	q := `SELECT id, user_id, app_id, refresh_expires_at, status
+	q := `SELECT id, user_id, app_id, refresh_expires_at, status, scope
 	      FROM sessions
 	      WHERE refresh_token = $1`
 
@@ -116,6 +140,7 @@ func (r *SessionRepository) SessionByRefreshToken(ctx context.Context, refreshTo
 		&s.AppID,
 		&s.RefreshExpiresAt,
 		&s.Status,
+		&s.Scope,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
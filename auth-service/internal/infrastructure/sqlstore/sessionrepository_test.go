@@ -22,12 +22,13 @@ func TestSessionrepository_CreateAndFindByID(t *testing.T) {
 	assert.NoError(t, err)
 	user.userID = domainUser.ID
 
-	sessionID, err := s.CreateSession(ctx, user.userID, user.appID, user.refreshToken, user.refreshTokenExp)
+	sessionID, err := s.CreateSession(ctx, user.userID, user.appID, user.refreshToken, user.refreshTokenExp, "openid profile")
 	assert.NoError(t, err)
 
 	domainSession, err := s.SessionByID(ctx, sessionID)
 	assert.NoError(t, err)
 	assert.WithinDuration(t, user.refreshTokenExp, domainSession.RefreshExpiresAt, time.Millisecond)
+	assert.Equal(t, "openid profile", domainSession.Scope)
 }
 
 func TestSessionrepository_CreateAndRevoke(t *testing.T) {
@@ -44,7 +45,7 @@ func TestSessionrepository_CreateAndRevoke(t *testing.T) {
 	assert.NoError(t, err)
 	user.userID = domainUser.ID
 
-	sessionID, err := s.CreateSession(ctx, user.userID, user.appID, user.refreshToken, user.refreshTokenExp)
+	sessionID, err := s.CreateSession(ctx, user.userID, user.appID, user.refreshToken, user.refreshTokenExp, "")
 	assert.NoError(t, err)
 
 	domainSessionByID, err := s.SessionByID(ctx, sessionID)
@@ -69,6 +69,33 @@ func (r *UserRepository) UserByEmail(ctx context.Context, email string) (domain.
 	return u, nil
 }
 
+// UserByID ...
+func (r *UserRepository) UserByID(ctx context.Context, userID int) (domain.User, error) {
+	const op = "UserRepository.UserByID"
+
+	q := `SELECT id, email, password_hash FROM users WHERE id = $1`
+
+	var u domain.User
+	var passHash string
+
+	err := r.db.QueryRowContext(ctx, q, userID).Scan(
+		&u.ID,
+		&u.Email,
+		&passHash,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+		}
+
+		return domain.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	u.PassHash = []byte(passHash)
+
+	return u, nil
+}
+
 // IsAdmin ...
 func (r *UserRepository) IsAdmin(ctx context.Context, userID int) (bool, error) {
 	const op = "UserRepository.IsAdmin"
@@ -88,3 +115,52 @@ func (r *UserRepository) IsAdmin(ctx context.Context, userID int) (bool, error)
 
 	return isAdmin, nil
 }
+
+// ExistingUserIDs ...
+func (r *UserRepository) ExistingUserIDs(ctx context.Context, ids []int) ([]int, error) {
+	const op = "UserRepository.ExistingUserIDs"
+
+	q := `SELECT id FROM users WHERE id = ANY($1) ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	existing := make([]int, 0, len(ids))
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		existing = append(existing, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return existing, nil
+}
+
+// UpdatePassHash заменяет хэш пароля, например после перехэширования по новой политике.
+func (r *UserRepository) UpdatePassHash(ctx context.Context, userID int, passHash []byte) error {
+	const op = "UserRepository.UpdatePassHash"
+
+	q := `UPDATE users SET password_hash = $2 WHERE id = $1`
+
+	res, err := r.db.ExecContext(ctx, q, userID, string(passHash))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w", op, repository.ErrUserNotFound)
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"auth/internal/infrastructure/sqlstore"
+	"auth/internal/repository"
 
 	"github.com/BurntSushi/toml"
 	_ "github.com/lib/pq"
@@ -111,3 +112,60 @@ func TestUserRepository_SaveAndCheckPermisions(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, isAdmin)
 }
+
+func TestUserRepository_UserByID(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("users", "sessions")
+	s := sqlstore.NewUserRepository(db)
+	user := newTestUser()
+
+	err := s.SaveUser(ctx, user.email, user.passHash)
+	assert.NoError(t, err)
+
+	byEmail, err := s.UserByEmail(ctx, user.email)
+	assert.NoError(t, err)
+
+	byID, err := s.UserByID(ctx, byEmail.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, byEmail, byID)
+
+	_, err = s.UserByID(ctx, byEmail.ID+1)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
+
+func TestUserRepository_UpdatePassHash(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("users", "sessions")
+	s := sqlstore.NewUserRepository(db)
+	user := newTestUser()
+
+	err := s.SaveUser(ctx, user.email, user.passHash)
+	assert.NoError(t, err)
+
+	domainUser, err := s.UserByEmail(ctx, user.email)
+	assert.NoError(t, err)
+
+	err = s.UpdatePassHash(ctx, domainUser.ID, []byte("$argon2id$new"))
+	assert.NoError(t, err)
+
+	domainUser, err = s.UserByEmail(ctx, user.email)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("$argon2id$new"), domainUser.PassHash)
+
+	err = s.UpdatePassHash(ctx, domainUser.ID+1, []byte("$argon2id$new"))
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
+
+func TestUserRepository_ExistingUserIDs(t *testing.T) {
+	db, teardown := testDB(t, cfg.TestDatabaseURL)
+	defer teardown("users")
+	s := sqlstore.NewUserRepository(db)
+
+	assert.NoError(t, s.SaveUser(ctx, "user@example.org", nil))
+	u, err := s.UserByEmail(ctx, "user@example.org")
+	assert.NoError(t, err)
+
+	existing, err := s.ExistingUserIDs(ctx, []int{u.ID + 1000, u.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{u.ID}, existing)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"auth/internal/domain"
+	"context"
+	"errors"
+)
+
+var (
+	// ErrAppNotFound ...
+	ErrAppNotFound = errors.New("app not found")
+	// ErrAppDisabled ...
+	ErrAppDisabled = errors.New("app is disabled")
+)
+
+// AppRepository ...
+type AppRepository interface {
+	AppByID(ctx context.Context, id int) (domain.App, error)
+	AppByName(ctx context.Context, name string) (domain.App, error)
+}
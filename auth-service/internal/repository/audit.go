@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"auth/internal/domain"
+	"context"
+)
+
+// AuditRepository - журнал аудита. Только дописывается: изменить или удалить событие нельзя.
+type AuditRepository interface {
+	SaveEvent(ctx context.Context, event domain.AuditEvent) error
+	ListEvents(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEvent, error)
+}
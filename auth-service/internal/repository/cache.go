@@ -10,4 +10,7 @@ type Cache interface {
 	SetSession(ctx context.Context, keyID int, value domain.Session) error
 	GetSession(ctx context.Context, keyID int) (ok bool, value domain.Session, err error)
 	DelSession(ctx context.Context, keyID int) error
+	SetRoles(ctx context.Context, userID int, roles []string) error
+	GetRoles(ctx context.Context, userID int) (ok bool, roles []string, err error)
+	DelRoles(ctx context.Context, userID int) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"auth/internal/domain"
+	"context"
+	"errors"
+)
+
+var (
+	// ErrExternalStateNotFound ...
+	ErrExternalStateNotFound = errors.New("external login state not found or already used")
+)
+
+// ExternalLoginRepository ...
+type ExternalLoginRepository interface {
+	SaveState(ctx context.Context, state domain.ExternalLoginState) error
+	// ConsumeState атомарно помечает state использованным и возвращает его.
+	ConsumeState(ctx context.Context, stateHash string) (domain.ExternalLoginState, error)
+}
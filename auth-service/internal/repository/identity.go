@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"auth/internal/domain"
+	"context"
+	"errors"
+)
+
+var (
+	// ErrIdentityNotFound ...
+	ErrIdentityNotFound = errors.New("identity not found")
+	// ErrIdentityAlreadyLinked ...
+	ErrIdentityAlreadyLinked = errors.New("identity already linked")
+)
+
+// IdentityRepository ...
+type IdentityRepository interface {
+	IdentityBySubject(ctx context.Context, provider string, subject string) (domain.Identity, error)
+	LinkIdentity(ctx context.Context, identity domain.Identity) error
+}
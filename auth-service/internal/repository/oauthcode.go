@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"auth/internal/domain"
+	"context"
+	"errors"
+)
+
+var (
+	// ErrAuthCodeNotFound ...
+	ErrAuthCodeNotFound = errors.New("authorization code not found or already used")
+)
+
+// AuthCodeRepository ...
+type AuthCodeRepository interface {
+	SaveCode(ctx context.Context, code domain.AuthCode) error
+	// ConsumeCode атомарно помечает код использованным и возвращает его.
+	ConsumeCode(ctx context.Context, codeHash string) (domain.AuthCode, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"auth/internal/domain"
+	"context"
+	"errors"
+)
+
+var (
+	// ErrUsernameTaken ...
+	ErrUsernameTaken = errors.New("username is already taken")
+)
+
+// ProfileRepository ...
+type ProfileRepository interface {
+	// ProfilesByUserIDs возвращает профили существующих пользователей из ids,
+	// незаполненные - пустыми. Несуществующие id пропускаются.
+	ProfilesByUserIDs(ctx context.Context, ids []int) ([]domain.Profile, error)
+	// ProfileByUsername ищет по username без учёта регистра, ErrUserNotFound - нет такого.
+	ProfileByUsername(ctx context.Context, username string) (domain.Profile, error)
+	// SearchProfiles ищет пользователей по началу и похожести email, username и display_name.
+	// Сначала совпадения по префиксу, затем по убыванию похожести.
+	SearchProfiles(ctx context.Context, query string, limit int) ([]domain.Profile, error)
+	// SaveProfile создаёт или заменяет профиль целиком.
+	SaveProfile(ctx context.Context, profile domain.Profile) error
+}
@@ -16,7 +16,8 @@ var (
 // SessionRepository ...
 type SessionRepository interface {
 	SessionByID(ctx context.Context, id int) (domain.Session, error)
-	CreateSession(ctx context.Context, userID int, appID int, refreshToken string, refExpiresAt time.Time) (sessionID int, err error)
+	CreateSession(ctx context.Context, userID int, appID int, refreshToken string, refExpiresAt time.Time, scope string) (sessionID int, err error)
 	RevokeByRefreshToken(ctx context.Context, refreshToken string) (revoked bool, err error)
+	RevokeByID(ctx context.Context, id int) (revoked bool, err error)
 	SessionByRefreshToken(ctx context.Context, refreshToken string) (session domain.Session, err error)
 }
@@ -19,5 +19,9 @@ var (
 type UserRepository interface {
 	SaveUser(ctx context.Context, email string, passHash []byte) error
 	UserByEmail(ctx context.Context, email string) (domain.User, error)
+	UserByID(ctx context.Context, userID int) (domain.User, error)
 	IsAdmin(ctx context.Context, userID int) (bool, error)
+	UpdatePassHash(ctx context.Context, userID int, passHash []byte) error
+	// ExistingUserIDs возвращает те из ids, что есть в users.
+	ExistingUserIDs(ctx context.Context, ids []int) ([]int, error)
 }
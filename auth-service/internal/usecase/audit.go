@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"auth/pkg/clientinfo"
+	"auth/pkg/requestid"
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	auditDefaultLimit = 100
+	auditMaxLimit     = 1000
+	auditExportBatch  = 500
+
+	// Как часто WatchUserEvents проверяет журнал, когда новых событий нет.
+	userEventsPollInterval = time.Second
+	// Сколько WatchUserEvents ждёт событий с меньшим id, закоммиченных позже: дольше
+	// не живёт ни одна транзакция, удаляющая пользователя.
+	userEventsSafetyWindow = time.Minute
+
+	// Значения details.action для AuditAdminAction.
+	auditActionList   = "audit.list"
+	auditActionExport = "audit.export"
+)
+
+// AuditUseCase ведёт журнал аудита: пишет события (provider.AuditLogger)
+// и отдаёт их админам.
+type AuditUseCase struct {
+	events repository.AuditRepository
+
+	logger slog.Logger
+}
+
+// NewAuditUseCase ...
+func NewAuditUseCase(events repository.AuditRepository, logger slog.Logger) *AuditUseCase {
+	return &AuditUseCase{
+		events: events,
+		logger: logger,
+	}
+}
+
+// Record дополняет событие адресом и User-Agent клиента и request_id из контекста
+// и сохраняет его. Запись не отменяется вместе с запросом: клиент, оборвавший
+// соединение после неудачного входа, не должен пропасть из журнала.
+func (a *AuditUseCase) Record(ctx context.Context, e domain.AuditEvent) {
+	if info, ok := clientinfo.FromContext(ctx); ok {
+		e.IP, e.UserAgent = info.IP, info.UserAgent
+	}
+	if id, ok := requestid.FromContext(ctx); ok {
+		e.RequestID = id
+	}
+
+	if err := a.events.SaveEvent(context.WithoutCancel(ctx), e); err != nil {
+		a.logger.ErrorContext(ctx, "audit event not saved",
+			slog.String("type", string(e.Type)),
+			slog.Int("userID", e.UserID),
+			slog.String("err", err.Error()),
+		)
+	}
+}
+
+// ListEvents - страница журнала для админа actorID. nextAfterID - AfterID следующей
+// страницы, 0 - страница последняя. Сам просмотр журнала тоже попадает в журнал.
+func (a *AuditUseCase) ListEvents(ctx context.Context, actorID int, filter domain.AuditFilter) (events []domain.AuditEvent, nextAfterID int64, err error) {
+	const op = "Audit.ListEvents"
+
+	log := a.logger.With(
+		slog.String("op", op),
+		slog.Int("actorID", actorID),
+	)
+
+	log.InfoContext(ctx, "list audit events")
+
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = auditDefaultLimit
+	case filter.Limit > auditMaxLimit:
+		filter.Limit = auditMaxLimit
+	}
+
+	a.Record(ctx, adminAction(actorID, auditActionList, filter))
+
+	// Лишняя запись показывает, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+
+	events, err = a.events.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(events) > limit {
+		events = events[:limit]
+		nextAfterID = events[limit-1].ID
+	}
+
+	return events, nextAfterID, nil
+}
+
+// ExportEvents передаёт в fn все события по фильтру (Limit игнорируется),
+// читая журнал пачками. Ошибка fn прерывает выгрузку.
+func (a *AuditUseCase) ExportEvents(ctx context.Context, actorID int, filter domain.AuditFilter, fn func(domain.AuditEvent) error) error {
+	const op = "Audit.ExportEvents"
+
+	log := a.logger.With(
+		slog.String("op", op),
+		slog.Int("actorID", actorID),
+	)
+
+	log.InfoContext(ctx, "export audit events")
+
+	filter.Limit = auditExportBatch
+	a.Record(ctx, adminAction(actorID, auditActionExport, filter))
+
+	for {
+		events, err := a.events.ListEvents(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, e := range events {
+			if err := fn(e); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		if len(events) < filter.Limit {
+			return nil
+		}
+		filter.AfterID = events[len(events)-1].ID
+	}
+}
+
+// WatchUserEvents передаёт в fn события о пользователях (сейчас только user.deleted)
+// с id больше afterID: сначала накопившиеся, затем новые по мере появления.
+// Журнал здесь - outbox: событие пишет триггер в той же транзакции, что и удаление.
+//
+// id события выдаётся при вставке, а видно оно после коммита, так что событие с меньшим
+// id может появиться позже большего. Поэтому журнал перечитывается от cursor - id, за
+// которым все события уже отданы, - а cursor сдвигается до события, только когда оно
+// видно дольше userEventsSafetyWindow: транзакция с меньшим id за это время закоммитится.
+// Уже отданные события не повторяются. fn получает событие и текущий cursor, с которого
+// надо продолжать после обрыва (события после него придут повторно).
+// Возвращает nil, когда ctx отменён, ошибку fn - сразу.
+func (a *AuditUseCase) WatchUserEvents(ctx context.Context, afterID int64, fn func(e domain.AuditEvent, cursor int64) error) error {
+	const op = "Audit.WatchUserEvents"
+
+	log := a.logger.With(
+		slog.String("op", op),
+	)
+
+	log.InfoContext(ctx, "watch user events", slog.Int64("afterID", afterID))
+
+	if err := a.watchEvents(ctx, []domain.AuditEventType{domain.AuditUserDeleted}, afterID, fn); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// WatchRoleChanges передаёт в fn пользователя из каждого события role.changed: сначала
+// из всего журнала, затем новые. Смены ролей редки, а перечитать журнал с начала дешевле,
+// чем хранить cursor: роли, закэшированные до рестарта, тоже сбросятся.
+// Возвращает nil, когда ctx отменён, ошибку fn - сразу.
+func (a *AuditUseCase) WatchRoleChanges(ctx context.Context, fn func(ctx context.Context, userID int) error) error {
+	const op = "Audit.WatchRoleChanges"
+
+	log := a.logger.With(
+		slog.String("op", op),
+	)
+
+	log.InfoContext(ctx, "watch role changes")
+
+	err := a.watchEvents(ctx, []domain.AuditEventType{domain.AuditRoleChanged}, 0, func(e domain.AuditEvent, _ int64) error {
+		return fn(ctx, e.UserID)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// watchEvents - общий цикл WatchUserEvents и WatchRoleChanges по событиям types.
+func (a *AuditUseCase) watchEvents(ctx context.Context, types []domain.AuditEventType, afterID int64, fn func(e domain.AuditEvent, cursor int64) error) error {
+	cursor := afterID
+	// События после cursor, уже отданные в fn, и когда их впервые увидели
+	seen := make(map[int64]time.Time)
+
+	ticker := time.NewTicker(userEventsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		filter := domain.AuditFilter{
+			Types:   types,
+			AfterID: cursor,
+			Limit:   auditExportBatch,
+		}
+
+		for {
+			events, err := a.events.ListEvents(ctx, filter)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+
+			for _, e := range events {
+				if _, ok := seen[e.ID]; ok {
+					continue
+				}
+				seen[e.ID] = now
+				if err := fn(e, cursor); err != nil {
+					return err
+				}
+			}
+
+			if len(events) < filter.Limit {
+				break
+			}
+			filter.AfterID = events[len(events)-1].ID
+		}
+
+		cursor = settleUserEvents(seen, cursor, now.Add(-userEventsSafetyWindow))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// settleUserEvents сдвигает cursor до последнего события, увиденного не позже before,
+// и забывает события до нового cursor: их больше не перечитываем.
+func settleUserEvents(seen map[int64]time.Time, cursor int64, before time.Time) int64 {
+	for id, at := range seen {
+		if id > cursor && !at.After(before) {
+			cursor = id
+		}
+	}
+	for id := range seen {
+		if id <= cursor {
+			delete(seen, id)
+		}
+	}
+	return cursor
+}
+
+// adminAction - событие о действии админа с журналом, фильтр сохраняется в details.
+func adminAction(actorID int, action string, filter domain.AuditFilter) domain.AuditEvent {
+	details := map[string]string{"action": action}
+	if filter.UserID != 0 {
+		details["user_id"] = strconv.Itoa(filter.UserID)
+	}
+	if !filter.From.IsZero() {
+		details["from"] = filter.From.UTC().Format(time.RFC3339)
+	}
+	if !filter.To.IsZero() {
+		details["to"] = filter.To.UTC().Format(time.RFC3339)
+	}
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			types[i] = string(t)
+		}
+		details["types"] = strings.Join(types, ",")
+	}
+
+	return domain.AuditEvent{
+		Type:    domain.AuditAdminAction,
+		UserID:  actorID,
+		ActorID: actorID,
+		Details: details,
+	}
+}
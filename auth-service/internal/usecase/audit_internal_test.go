@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettleUserEvents(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * userEventsSafetyWindow)
+	before := now.Add(-userEventsSafetyWindow)
+
+	tests := []struct {
+		name       string
+		seen       map[int64]time.Time
+		cursor     int64
+		wantCursor int64
+		wantSeen   []int64
+	}{
+		{
+			name:       "nothing seen",
+			seen:       map[int64]time.Time{},
+			cursor:     10,
+			wantCursor: 10,
+		},
+		{
+			name:       "all recent",
+			seen:       map[int64]time.Time{11: now, 13: now},
+			cursor:     10,
+			wantCursor: 10,
+			wantSeen:   []int64{11, 13},
+		},
+		{
+			name:       "old events settle",
+			seen:       map[int64]time.Time{11: old, 13: old},
+			cursor:     10,
+			wantCursor: 13,
+		},
+		{
+			// 12 увидели недавно, но он меньше 13, который уже устоялся
+			name:       "late event below settled one",
+			seen:       map[int64]time.Time{11: old, 12: now, 13: old, 15: now},
+			cursor:     10,
+			wantCursor: 13,
+			wantSeen:   []int64{15},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := settleUserEvents(tt.seen, tt.cursor, before)
+
+			assert.Equal(t, tt.wantCursor, cursor)
+			var left []int64
+			for id := range tt.seen {
+				left = append(left, id)
+			}
+			assert.ElementsMatch(t, tt.wantSeen, left)
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package usecase_test
+
+import (
+	"auth/internal/config"
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"auth/internal/usecase"
+	providerMocks "auth/mocks/provider"
+	repoMocks "auth/mocks/repository"
+	"auth/pkg/clientinfo"
+	"auth/pkg/requestid"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthUseCase_Login_AuditSuccess(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	tokenProv := new(providerMocks.TokenProvider)
+	audit := new(providerMocks.AuditLogger)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(userRepo, sessRepo, appRepo, nil, tokenProv, nil, testHasher, audit, *logger,
+		cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+
+	ctx := context.Background()
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+
+	appRepo.On("AppByID", ctx, 1).Return(testApp, nil)
+	userRepo.On("UserByEmail", ctx, "user@example.com").Return(domain.User{ID: 42, PassHash: hash}, nil)
+	tokenProv.On("CreateRefreshToken").Return("REFRESH", nil)
+	sessRepo.On("CreateSession", ctx, 42, 1, "REFRESH", mock.AnythingOfType("time.Time"), "").Return(100, nil)
+	tokenProv.On("CreateAccessToken", 42, 100, 1, testApp.Name, mock.AnythingOfType("time.Time")).Return("ACCESS", nil)
+	audit.On("Record", ctx, domain.AuditEvent{
+		Type:      domain.AuditLoginSucceeded,
+		UserID:    42,
+		AppID:     1,
+		SessionID: 100,
+		Details:   map[string]string{"method": "password"},
+	}).Return()
+
+	_, err := uc.Login(ctx, "user@example.com", "password", 1)
+
+	require.NoError(t, err)
+	audit.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Login_AuditFailure(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+
+	tests := []struct {
+		name     string
+		user     domain.User
+		userErr  error
+		password string
+		want     domain.AuditEvent
+	}{
+		{
+			name:     "wrong password",
+			user:     domain.User{ID: 42, PassHash: hash},
+			password: "wrong",
+			want: domain.AuditEvent{Type: domain.AuditLoginFailed, UserID: 42, AppID: 1,
+				Details: map[string]string{"email": "user@example.com", "reason": "invalid_password"}},
+		},
+		{
+			name:     "unknown user",
+			userErr:  repository.ErrUserNotFound,
+			password: "password",
+			want: domain.AuditEvent{Type: domain.AuditLoginFailed, AppID: 1,
+				Details: map[string]string{"email": "user@example.com", "reason": "user_not_found"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(repoMocks.UserRepository)
+			appRepo := new(repoMocks.AppRepository)
+			audit := new(providerMocks.AuditLogger)
+
+			logger := config.NewLogger(&cfg)
+
+			uc := usecase.NewAuthUseCase(userRepo, nil, appRepo, nil, nil, nil, testHasher, audit, *logger,
+				cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+
+			ctx := context.Background()
+			appRepo.On("AppByID", ctx, 1).Return(testApp, nil)
+			userRepo.On("UserByEmail", ctx, "user@example.com").Return(tt.user, tt.userErr)
+			audit.On("Record", ctx, tt.want).Return()
+
+			_, err := uc.Login(ctx, "user@example.com", tt.password, 1)
+
+			require.ErrorIs(t, err, repository.ErrInvalidCredentials)
+			audit.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUseCase_Logout_Audit(t *testing.T) {
+	sessRepo := new(repoMocks.SessionRepository)
+	cacheRepo := new(repoMocks.Cache)
+	audit := new(providerMocks.AuditLogger)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(nil, sessRepo, nil, cacheRepo, nil, nil, testHasher, audit, *logger,
+		cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+
+	ctx := context.Background()
+	sessRepo.On("SessionByRefreshToken", ctx, "REFRESH").Return(domain.Session{ID: 100, UserID: 42, AppID: 1}, nil)
+	cacheRepo.On("DelSession", ctx, 100).Return(nil)
+	sessRepo.On("RevokeByRefreshToken", ctx, "REFRESH").Return(true, nil)
+	audit.On("Record", ctx, domain.AuditEvent{Type: domain.AuditLogout, UserID: 42, AppID: 1, SessionID: 100}).Return()
+
+	ok, err := uc.Logout(ctx, "REFRESH")
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	audit.AssertExpectations(t)
+}
+
+func TestAuditUseCase_Record(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx := clientinfo.NewContext(context.Background(), clientinfo.Info{IP: "203.0.113.7", UserAgent: "Firefox"})
+	ctx = requestid.NewContext(ctx, "req-1")
+	// Запрос уже отменён клиентом - событие всё равно пишется
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	events.
+		On("SaveEvent", mock.MatchedBy(func(c context.Context) bool { return c.Err() == nil }), domain.AuditEvent{
+			Type:      domain.AuditLoginFailed,
+			UserID:    42,
+			IP:        "203.0.113.7",
+			UserAgent: "Firefox",
+			RequestID: "req-1",
+		}).
+		Return(nil)
+
+	uc.Record(ctx, domain.AuditEvent{Type: domain.AuditLoginFailed, UserID: 42})
+
+	events.AssertExpectations(t)
+}
+
+func TestAuditUseCase_Record_SaveError(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	events.On("SaveEvent", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	// Ошибка только логируется
+	uc.Record(context.Background(), domain.AuditEvent{Type: domain.AuditLogout})
+
+	events.AssertExpectations(t)
+}
+
+func TestAuditUseCase_ListEvents(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx := context.Background()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	events.
+		On("SaveEvent", mock.Anything, domain.AuditEvent{
+			Type:    domain.AuditAdminAction,
+			UserID:  1,
+			ActorID: 1,
+			Details: map[string]string{"action": "audit.list", "user_id": "42", "from": "2026-01-01T00:00:00Z"},
+		}).
+		Return(nil)
+	// Просим на одну запись больше, чтобы узнать о следующей странице
+	events.
+		On("ListEvents", ctx, domain.AuditFilter{UserID: 42, From: from, Limit: 3}).
+		Return([]domain.AuditEvent{{ID: 5}, {ID: 6}, {ID: 9}}, nil)
+
+	got, next, err := uc.ListEvents(ctx, 1, domain.AuditFilter{UserID: 42, From: from, Limit: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.AuditEvent{{ID: 5}, {ID: 6}}, got)
+	assert.Equal(t, int64(6), next)
+	events.AssertExpectations(t)
+}
+
+func TestAuditUseCase_ListEvents_LastPage(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx := context.Background()
+
+	events.On("SaveEvent", mock.Anything, mock.Anything).Return(nil)
+	// limit 0 - по умолчанию 100
+	events.
+		On("ListEvents", ctx, domain.AuditFilter{Limit: 101}).
+		Return([]domain.AuditEvent{{ID: 5}}, nil)
+
+	got, next, err := uc.ListEvents(ctx, 1, domain.AuditFilter{})
+
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Zero(t, next)
+	events.AssertExpectations(t)
+}
+
+func TestAuditUseCase_ExportEvents(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx := context.Background()
+
+	full := make([]domain.AuditEvent, 500)
+	for i := range full {
+		full[i].ID = int64(i + 1)
+	}
+
+	events.On("SaveEvent", mock.Anything, mock.Anything).Return(nil)
+	events.
+		On("ListEvents", ctx, domain.AuditFilter{UserID: 42, Limit: 500}).
+		Return(full, nil)
+	events.
+		On("ListEvents", ctx, domain.AuditFilter{UserID: 42, AfterID: 500, Limit: 500}).
+		Return([]domain.AuditEvent{{ID: 501}}, nil)
+
+	var got []int64
+	err := uc.ExportEvents(ctx, 1, domain.AuditFilter{UserID: 42, Limit: 7}, func(e domain.AuditEvent) error {
+		got = append(got, e.ID)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, got, 501)
+	assert.Equal(t, int64(501), got[500])
+	events.AssertExpectations(t)
+}
+
+func TestAuditUseCase_ExportEvents_CallbackError(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx := context.Background()
+	sendErr := errors.New("client gone")
+
+	events.On("SaveEvent", mock.Anything, mock.Anything).Return(nil)
+	events.On("ListEvents", ctx, mock.Anything).Return([]domain.AuditEvent{{ID: 1}, {ID: 2}}, nil).Once()
+
+	calls := 0
+	err := uc.ExportEvents(ctx, 1, domain.AuditFilter{}, func(domain.AuditEvent) error {
+		calls++
+		return sendErr
+	})
+
+	require.ErrorIs(t, err, sendErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestAuditUseCase_WatchUserEvents(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deleted := []domain.AuditEventType{domain.AuditUserDeleted}
+	filter := domain.AuditFilter{Types: deleted, AfterID: 10, Limit: 500}
+	events.
+		On("ListEvents", ctx, filter).
+		Return([]domain.AuditEvent{{ID: 11, UserID: 7}, {ID: 13, UserID: 8}}, nil).Once()
+	// Транзакция с id 12 закоммитилась после 13: следующий опрос снова читает от 10
+	events.
+		On("ListEvents", ctx, filter).
+		Return([]domain.AuditEvent{{ID: 11, UserID: 7}, {ID: 12, UserID: 9}, {ID: 13, UserID: 8}}, nil).Once()
+
+	var got []int
+	var cursors []int64
+	err := uc.WatchUserEvents(ctx, 10, func(e domain.AuditEvent, cursor int64) error {
+		got = append(got, e.UserID)
+		cursors = append(cursors, cursor)
+		// Третье событие приходит со следующего опроса, после него останавливаемся
+		if len(got) == 3 {
+			cancel()
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{7, 8, 9}, got, "late event delivered once, seen events not repeated")
+	// cursor не обгоняет события, которые ещё могут закоммититься
+	assert.Equal(t, []int64{10, 10, 10}, cursors)
+	events.AssertExpectations(t)
+}
+
+func TestAuditUseCase_WatchUserEvents_Paging(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	page := make([]domain.AuditEvent, 500)
+	for i := range page {
+		page[i] = domain.AuditEvent{ID: int64(i + 1), UserID: i + 1}
+	}
+
+	deleted := []domain.AuditEventType{domain.AuditUserDeleted}
+	events.
+		On("ListEvents", ctx, domain.AuditFilter{Types: deleted, AfterID: 0, Limit: 500}).
+		Return(page, nil).Once()
+	events.
+		On("ListEvents", ctx, domain.AuditFilter{Types: deleted, AfterID: 500, Limit: 500}).
+		Return([]domain.AuditEvent{{ID: 501, UserID: 501}}, nil).Once()
+
+	calls := 0
+	err := uc.WatchUserEvents(ctx, 0, func(domain.AuditEvent, int64) error {
+		calls++
+		if calls == 501 {
+			cancel()
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 501, calls)
+	events.AssertExpectations(t)
+}
+
+func TestAuditUseCase_WatchUserEvents_CallbackError(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx := context.Background()
+	sendErr := errors.New("client gone")
+
+	events.On("ListEvents", ctx, mock.Anything).Return([]domain.AuditEvent{{ID: 1}}, nil).Once()
+
+	err := uc.WatchUserEvents(ctx, 0, func(domain.AuditEvent, int64) error {
+		return sendErr
+	})
+
+	require.ErrorIs(t, err, sendErr)
+}
+
+func TestAuditUseCase_WatchRoleChanges(t *testing.T) {
+	events := new(repoMocks.AuditRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewAuditUseCase(events, *logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Журнал читается с начала: роли в кэше могли пережить рестарт
+	events.
+		On("ListEvents", ctx, domain.AuditFilter{Types: []domain.AuditEventType{domain.AuditRoleChanged}, AfterID: 0, Limit: 500}).
+		Return([]domain.AuditEvent{{ID: 3, UserID: 7}, {ID: 9, UserID: 8}}, nil).Once()
+
+	var got []int
+	err := uc.WatchRoleChanges(ctx, func(_ context.Context, userID int) error {
+		got = append(got, userID)
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{7, 8}, got)
+	events.AssertExpectations(t)
+}
@@ -10,9 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
 	"time"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -21,10 +21,14 @@ const (
 
 // AuthUseCase ...
 type AuthUseCase struct {
-	users    repository.UserRepository
-	sessions repository.SessionRepository
-	cache    repository.Cache
-	token    provider.TokenProvider
+	users     repository.UserRepository
+	sessions  repository.SessionRepository
+	apps      repository.AppRepository
+	cache     repository.Cache
+	token     provider.TokenProvider
+	passwords provider.PasswordPolicy
+	hasher    provider.PasswordHasher
+	audit     provider.AuditLogger
 
 	logger slog.Logger
 
@@ -36,16 +40,24 @@ type AuthUseCase struct {
 func NewAuthUseCase(
 	users repository.UserRepository,
 	sessions repository.SessionRepository,
+	apps repository.AppRepository,
 	cache repository.Cache,
 	token provider.TokenProvider,
+	passwords provider.PasswordPolicy,
+	hasher provider.PasswordHasher,
+	audit provider.AuditLogger,
 	logger slog.Logger,
 	accessTokenTTL time.Duration,
 	refreshTokenTTL time.Duration) *AuthUseCase {
 	return &AuthUseCase{
 		users:           users,
 		sessions:        sessions,
+		apps:            apps,
 		cache:           cache,
 		token:           token,
+		passwords:       passwords,
+		hasher:          hasher,
+		audit:           audit,
 		logger:          logger,
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
@@ -61,9 +73,13 @@ func (a *AuthUseCase) Register(ctx context.Context, email string, password strin
 		slog.String("username", email),
 	)
 
-	log.Info("register user")
+	log.InfoContext(ctx, "register user")
 
-	passHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err := a.passwords.Check(email, password); err != nil {
+		return emptyID, fmt.Errorf("%s: %w", op, err)
+	}
+
+	passHash, err := a.hasher.Hash(password)
 	if err != nil {
 		return emptyID, fmt.Errorf("%s: %w", op, err)
 	}
@@ -77,7 +93,9 @@ func (a *AuthUseCase) Register(ctx context.Context, email string, password strin
 		return emptyID, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Info("register success")
+	log.InfoContext(ctx, "register success")
+
+	a.record(ctx, domain.AuditEvent{Type: domain.AuditUserRegistered, UserID: user.ID})
 
 	return user.ID, nil
 }
@@ -86,56 +104,163 @@ func (a *AuthUseCase) Register(ctx context.Context, email string, password strin
 func (a *AuthUseCase) Login(ctx context.Context, email string, password string, appID int) (token tokenjwt.Token, err error) {
 	const op = "Auth.Login"
 
+	defer func() { countResult(loginsTotal, err) }()
+
 	log := a.logger.With(
 		slog.String("op", op),
 		slog.String("username", email),
 	)
 
-	log.Info("attempting to login user")
+	log.InfoContext(ctx, "attempting to login user")
+
+	app, err := a.enabledApp(ctx, appID)
+	if err != nil {
+		log.WarnContext(ctx, "login to unknown or disabled app", slog.Int("appID", appID))
+		a.loginFailed(ctx, emptyID, appID, email, "unknown_app")
+
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := a.authenticate(ctx, log, email, password, appID)
+	if err != nil {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err = a.issueTokens(ctx, user.ID, app, "", map[string]string{"method": "password"})
+	if err != nil {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// ChangePassword меняет пароль пользователя: нужен текущий пароль, новый проверяется
+// той же политикой, что и при регистрации. У пользователей внешних провайдеров пароля
+// нет, для них это ErrInvalidCredentials.
+func (a *AuthUseCase) ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) error {
+	const op = "Auth.ChangePassword"
+
+	log := a.logger.With(
+		slog.String("op", op),
+		slog.Int("userID", userID),
+	)
+
+	user, err := a.users.UserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.hasher.Compare(user.PassHash, currentPassword); err != nil {
+		log.InfoContext(ctx, "invalid current password")
+
+		return fmt.Errorf("%s: %w", op, repository.ErrInvalidCredentials)
+	}
+
+	if err := a.passwords.Check(user.Email, newPassword); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	passHash, err := a.hasher.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := a.users.UpdatePassHash(ctx, userID, passHash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "password changed")
+
+	a.record(ctx, domain.AuditEvent{Type: domain.AuditPasswordChanged, UserID: userID})
 
+	return nil
+}
+
+// authenticate проверяет email и пароль пользователя, входящего в приложение appID.
+// Неудачная попытка пишется в журнал аудита; устаревший хэш пароля пересчитывается.
+func (a *AuthUseCase) authenticate(ctx context.Context, log *slog.Logger, email string, password string, appID int) (domain.User, error) {
 	user, err := a.users.UserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			a.logger.Warn("user not found")
+			a.logger.WarnContext(ctx, "user not found")
+			a.loginFailed(ctx, emptyID, appID, email, "user_not_found")
 
-			return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidCredentials)
+			return domain.User{}, repository.ErrInvalidCredentials
 		}
 
-		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+		return domain.User{}, err
+	}
+
+	if err := a.hasher.Compare(user.PassHash, password); err != nil {
+		a.logger.InfoContext(ctx, "invalid credentials")
+		a.loginFailed(ctx, user.ID, appID, email, "invalid_password")
+
+		return domain.User{}, repository.ErrInvalidCredentials
 	}
 
-	if err := bcrypt.CompareHashAndPassword(user.PassHash, []byte(password)); err != nil {
-		a.logger.Info("invalid credentials")
+	if a.hasher.NeedsRehash(user.PassHash) {
+		a.rehash(ctx, log, user.ID, password)
+	}
+
+	return user, nil
+}
 
-		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, repository.ErrInvalidCredentials)
+// rehash пересчитывает хэш пароля по текущей политике, пока пароль известен (сразу после
+// успешного входа). Ошибка не мешает входу: попробуем при следующем.
+func (a *AuthUseCase) rehash(ctx context.Context, log *slog.Logger, userID int, password string) {
+	passHash, err := a.hasher.Hash(password)
+	if err != nil {
+		log.WarnContext(ctx, "password rehash failed", slog.String("err", err.Error()))
+		return
+	}
+
+	if err := a.users.UpdatePassHash(ctx, userID, passHash); err != nil {
+		log.WarnContext(ctx, "password rehash failed", slog.String("err", err.Error()))
+		return
 	}
 
+	log.InfoContext(ctx, "password rehashed", slog.Int("userID", userID))
+}
+
+// issueTokens создаёт новую сессию пользователя в приложении и выдаёт пару токенов.
+// Вход пишется в журнал аудита, details - чем именно вошли. scope сохраняется в сессии:
+// при refresh его можно только сузить.
+func (a *AuthUseCase) issueTokens(ctx context.Context, userID int, app domain.App, scope string, details map[string]string) (tokenjwt.Token, error) {
 	refreshToken, err := a.token.CreateRefreshToken()
 	if err != nil {
-		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+		return tokenjwt.Token{}, err
 	}
 
-	refExp := time.Now().Add(a.refreshTokenTTL)
+	accessTTL, refreshTTL := a.tokenTTLs(app)
+
+	refExp := time.Now().Add(refreshTTL)
 
-	sessionID, err := a.sessions.CreateSession(ctx, int(user.ID), int(appID), refreshToken, refExp)
+	sessionID, err := a.sessions.CreateSession(ctx, userID, app.ID, refreshToken, refExp, scope)
 	if err != nil {
-		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+		return tokenjwt.Token{}, err
 	}
 
-	accExp := time.Now().Add(a.accessTokenTTL)
+	accExp := time.Now().Add(accessTTL)
 
-	accessToken, err := a.token.CreateAccessToken(int(user.ID), sessionID, int(appID), accExp)
+	accessToken, err := a.token.CreateAccessToken(userID, sessionID, app.ID, app.Name, accExp)
 	if err != nil {
-		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+		return tokenjwt.Token{}, err
 	}
 
+	a.record(ctx, domain.AuditEvent{
+		Type:      domain.AuditLoginSucceeded,
+		UserID:    userID,
+		AppID:     app.ID,
+		SessionID: sessionID,
+		Details:   details,
+	})
+
 	return tokenjwt.Token{
 		AccessToken:     accessToken,
 		AccessExpireAt:  accExp,
 		RefreshToken:    refreshToken,
 		RefreshExpireAt: refExp,
 	}, nil
-
 }
 
 // IsAdmin ...
@@ -147,7 +272,7 @@ func (a *AuthUseCase) IsAdmin(ctx context.Context, userID int) (bool, error) {
 		slog.String("userID", fmt.Sprint(userID)),
 	)
 
-	log.Info("check permisions")
+	log.InfoContext(ctx, "check permisions")
 
 	isAdmin, err := a.users.IsAdmin(ctx, userID)
 	if err != nil {
@@ -165,7 +290,7 @@ func (a *AuthUseCase) Logout(ctx context.Context, refreshToken string) (success
 		slog.String("op", op),
 	)
 
-	log.Info("logout user by refreshToken")
+	log.InfoContext(ctx, "logout user by refreshToken")
 
 	session, err := a.sessions.SessionByRefreshToken(ctx, refreshToken)
 	if err != nil {
@@ -177,7 +302,7 @@ func (a *AuthUseCase) Logout(ctx context.Context, refreshToken string) (success
 	}
 
 	if err := a.cache.DelSession(ctx, session.ID); err != nil {
-		log.Warn("session not deleted from cache")
+		log.WarnContext(ctx, "session not deleted from cache")
 	}
 
 	ok, err := a.sessions.RevokeByRefreshToken(ctx, refreshToken)
@@ -185,6 +310,15 @@ func (a *AuthUseCase) Logout(ctx context.Context, refreshToken string) (success
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if ok {
+		a.record(ctx, domain.AuditEvent{
+			Type:      domain.AuditLogout,
+			UserID:    session.UserID,
+			AppID:     session.AppID,
+			SessionID: session.ID,
+		})
+	}
+
 	return ok, nil
 }
 
@@ -192,11 +326,13 @@ func (a *AuthUseCase) Logout(ctx context.Context, refreshToken string) (success
 func (a *AuthUseCase) RefreshToken(ctx context.Context, refreshToken string) (token tokenjwt.Token, err error) {
 	const op = "Auth.RefreshToken"
 
+	defer func() { countResult(refreshesTotal, err) }()
+
 	log := a.logger.With(
 		slog.String("op", op),
 	)
 
-	log.Info("refresh token by refreshToken")
+	log.InfoContext(ctx, "refresh token by refreshToken")
 
 	session, err := a.sessions.SessionByRefreshToken(ctx, refreshToken)
 	if err != nil {
@@ -207,28 +343,42 @@ func (a *AuthUseCase) RefreshToken(ctx context.Context, refreshToken string) (to
 		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, provider.ErrInvalidRefreshToken)
 	}
 
+	app, err := a.enabledApp(ctx, session.AppID)
+	if err != nil {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	_, _ = a.sessions.RevokeByRefreshToken(ctx, refreshToken)
 	if err := a.cache.DelSession(ctx, session.ID); err != nil {
-		log.Warn("session not deleted from cache")
+		log.WarnContext(ctx, "session not deleted from cache")
 	}
 
 	newRefreshToken, err := a.token.CreateRefreshToken()
 	if err != nil {
 		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
 	}
-	refExp := time.Now().Add(a.refreshTokenTTL)
+	accessTTL, refreshTTL := a.tokenTTLs(app)
+	refExp := time.Now().Add(refreshTTL)
 
-	sessionID, err := a.sessions.CreateSession(ctx, session.UserID, session.AppID, newRefreshToken, refExp)
+	sessionID, err := a.sessions.CreateSession(ctx, session.UserID, session.AppID, newRefreshToken, refExp, session.Scope)
 	if err != nil {
 		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	accExp := time.Now().Add(a.accessTokenTTL)
-	accessToken, err := a.token.CreateAccessToken(session.UserID, sessionID, session.AppID, accExp)
+	accExp := time.Now().Add(accessTTL)
+	accessToken, err := a.token.CreateAccessToken(session.UserID, sessionID, session.AppID, app.Name, accExp)
 	if err != nil {
 		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
 	}
 
+	a.record(ctx, domain.AuditEvent{
+		Type:      domain.AuditTokenRefreshed,
+		UserID:    session.UserID,
+		AppID:     session.AppID,
+		SessionID: sessionID,
+		Details:   map[string]string{"previous_session_id": strconv.Itoa(session.ID)},
+	})
+
 	return tokenjwt.Token{
 		AccessToken:     accessToken,
 		AccessExpireAt:  accExp,
@@ -245,21 +395,23 @@ func (a *AuthUseCase) ValidateSession(ctx context.Context, sessionID int) (activ
 		slog.String("op", op),
 	)
 
-	log.Info("validate session")
+	log.InfoContext(ctx, "validate session")
 
 	ok, session, err := a.cache.GetSession(ctx, sessionID)
 	if err != nil {
-		log.Warn("session not get from cache")
+		log.WarnContext(ctx, "session not get from cache")
 		ok = false
 	}
 
 	if !ok {
+		sessionCacheLookupsTotal.WithLabelValues(resultMiss).Inc()
+
 		session, err := a.sessions.SessionByID(ctx, sessionID)
 		if err != nil {
 			return false, fmt.Errorf("%s: %w", op, err)
 		}
 		if err = a.cache.SetSession(ctx, sessionID, session); err != nil {
-			log.Warn("session not set in cache")
+			log.WarnContext(ctx, "session not set in cache")
 		}
 		if isSessionActive(session) {
 			return true, nil
@@ -268,7 +420,9 @@ func (a *AuthUseCase) ValidateSession(ctx context.Context, sessionID int) (activ
 
 	}
 
-	log.Info("validate from cache")
+	sessionCacheLookupsTotal.WithLabelValues(resultHit).Inc()
+
+	log.InfoContext(ctx, "validate from cache")
 	if isSessionActive(session) {
 		return true, nil
 	}
@@ -276,6 +430,169 @@ func (a *AuthUseCase) ValidateSession(ctx context.Context, sessionID int) (activ
 
 }
 
+// UsersExist возвращает те из userIDs, что зарегистрированы (для других сервисов:
+// chat-service не создаёт чаты с несуществующими пользователями).
+func (a *AuthUseCase) UsersExist(ctx context.Context, userIDs []int) (existing []int, err error) {
+	const op = "Auth.UsersExist"
+
+	log := a.logger.With(
+		slog.String("op", op),
+	)
+
+	log.DebugContext(ctx, "users exist", slog.Int("count", len(userIDs)))
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	existing, err = a.users.ExistingUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return existing, nil
+}
+
+// IntrospectToken проверяет access токен целиком: подпись, exp, aud и активность сессии.
+// Невалидный токен - не ошибка, а Active: false.
+func (a *AuthUseCase) IntrospectToken(ctx context.Context, accessToken string, audience string) (info domain.TokenInfo, err error) {
+	const op = "Auth.IntrospectToken"
+
+	log := a.logger.With(
+		slog.String("op", op),
+	)
+
+	log.InfoContext(ctx, "introspect access token")
+
+	claims, err := a.token.ParseAccessToken(accessToken)
+	if err != nil {
+		return domain.TokenInfo{Active: false}, nil
+	}
+	if audience != "" && !slices.Contains(claims.Audience, audience) {
+		return domain.TokenInfo{Active: false}, nil
+	}
+
+	info = domain.TokenInfo{
+		UserID:    claims.UserID,
+		SessionID: claims.SessionID,
+		AppID:     claims.AppID,
+	}
+	if claims.ExpiresAt != nil {
+		info.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	// client_credentials: сессии и пользователя нет
+	if claims.SessionID == emptyID {
+		info.Active = true
+		return info, nil
+	}
+
+	active, err := a.ValidateSession(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return domain.TokenInfo{Active: false}, nil
+		}
+		return domain.TokenInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !active {
+		return domain.TokenInfo{Active: false}, nil
+	}
+
+	info.Roles, err = a.roles(ctx, log, claims.UserID)
+	if err != nil {
+		return domain.TokenInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+	info.Active = true
+
+	return info, nil
+}
+
+// roles берёт роли пользователя из кэша, при промахе - из БД.
+// Из кэша их убирает InvalidateRoles по событию role.changed.
+func (a *AuthUseCase) roles(ctx context.Context, log *slog.Logger, userID int) ([]string, error) {
+	ok, roles, err := a.cache.GetRoles(ctx, userID)
+	if err != nil {
+		log.WarnContext(ctx, "roles not get from cache")
+		ok = false
+	}
+	if ok {
+		rolesCacheLookupsTotal.WithLabelValues(resultHit).Inc()
+		return roles, nil
+	}
+	rolesCacheLookupsTotal.WithLabelValues(resultMiss).Inc()
+
+	isAdmin, err := a.users.IsAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles = []string{domain.RoleUser}
+	if isAdmin {
+		roles = append(roles, domain.RoleAdmin)
+	}
+	if err := a.cache.SetRoles(ctx, userID, roles); err != nil {
+		log.WarnContext(ctx, "roles not set in cache")
+	}
+
+	return roles, nil
+}
+
+// InvalidateRoles убирает роли пользователя из кэша: следующая интроспекция
+// перечитает их из БД.
+func (a *AuthUseCase) InvalidateRoles(ctx context.Context, userID int) error {
+	const op = "Auth.InvalidateRoles"
+
+	if err := a.cache.DelRoles(ctx, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// record пишет событие в журнал аудита, если он подключён.
+func (a *AuthUseCase) record(ctx context.Context, e domain.AuditEvent) {
+	if a.audit != nil {
+		a.audit.Record(ctx, e)
+	}
+}
+
+// loginFailed - неудачный вход. Email пишется как введён: по нему видно подбор
+// паролей и к несуществующим аккаунтам.
+func (a *AuthUseCase) loginFailed(ctx context.Context, userID int, appID int, email string, reason string) {
+	a.record(ctx, domain.AuditEvent{
+		Type:    domain.AuditLoginFailed,
+		UserID:  userID,
+		AppID:   appID,
+		Details: map[string]string{"email": email, "reason": reason},
+	})
+}
+
 func isSessionActive(s domain.Session) bool {
 	return s.Status == "active" && time.Now().Before(s.RefreshExpiresAt)
 }
+
+// enabledApp возвращает приложение, если оно зарегистрировано и включено.
+func (a *AuthUseCase) enabledApp(ctx context.Context, appID int) (domain.App, error) {
+	app, err := a.apps.AppByID(ctx, appID)
+	if err != nil {
+		return domain.App{}, err
+	}
+	if !app.Enabled {
+		return domain.App{}, repository.ErrAppDisabled
+	}
+
+	return app, nil
+}
+
+// tokenTTLs учитывает переопределения TTL из настроек приложения.
+func (a *AuthUseCase) tokenTTLs(app domain.App) (accessTTL time.Duration, refreshTTL time.Duration) {
+	accessTTL, refreshTTL = a.accessTokenTTL, a.refreshTokenTTL
+	if app.AccessTokenTTL > 0 {
+		accessTTL = app.AccessTokenTTL
+	}
+	if app.RefreshTokenTTL > 0 {
+		refreshTTL = app.RefreshTokenTTL
+	}
+
+	return accessTTL, refreshTTL
+}
@@ -10,6 +10,7 @@ import (
 	"auth/internal/infrastructure/tokengen"
 	"auth/internal/repository"
 	"auth/internal/usecase"
+	"auth/pkg/password"
 	"auth/provider"
 	"context"
 	"database/sql"
@@ -124,6 +125,7 @@ func (c *memoryCache) DelSession(ctx context.Context, keyID int) error {
 func newIntegrationUseCase(db *sql.DB) *usecase.AuthUseCase {
 	userRepo := sqlstore.NewUserRepository(db)
 	sessRepo := sqlstore.NewSessionRepository(db)
+	appRepo := sqlstore.NewAppRepository(db)
 	cacheRepo := newMemoryCache()
 	tokenProv := tokengen.NewTokenProvider([]byte(intCfg.JWTSecret))
 
@@ -132,8 +134,12 @@ func newIntegrationUseCase(db *sql.DB) *usecase.AuthUseCase {
 	return usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		password.NewChecker(password.Policy{}, nil),
+		testHasher,
+		nil,
 		*logger,
 		intCfg.AccessTokenTTL,
 		intCfg.RefreshTokenTTL,
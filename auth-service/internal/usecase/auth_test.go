@@ -7,12 +7,16 @@ import (
 	"auth/internal/usecase"
 	providerMocks "auth/mocks/provider"
 	repoMocks "auth/mocks/repository"
+	"auth/pkg/password"
+	tokenjwt "auth/pkg/token"
 	"auth/provider"
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -28,8 +32,23 @@ var (
 		LogLevel:        "DEBUG",
 		JWTSecret:       "123",
 	}
+	testApp = domain.App{
+		ID:      1,
+		Name:    "messenger",
+		Enabled: true,
+	}
+	// bcrypt с DefaultCost, как у хэшей в тестах, - перехэширования при входе нет
+	testHasher = mustHasher(password.HashParams{Algorithm: password.Bcrypt})
 )
 
+func mustHasher(params password.HashParams) *password.Hasher {
+	h, err := password.NewHasher(params)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
 type testUserRequest struct {
 	ctx          context.Context
 	email        string
@@ -43,6 +62,7 @@ func TestAuthUseCase_Login_Success(t *testing.T) {
 	// m := mocks.*
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -51,8 +71,12 @@ func TestAuthUseCase_Login_Success(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -66,6 +90,10 @@ func TestAuthUseCase_Login_Success(t *testing.T) {
 	}
 	user1.hashPass, _ = bcrypt.GenerateFromPassword([]byte(user1.password), bcrypt.DefaultCost)
 
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(testApp, nil)
+
 	userRepo.
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{ID: 42, Email: user1.email, PassHash: user1.hashPass}, nil)
@@ -75,11 +103,11 @@ func TestAuthUseCase_Login_Success(t *testing.T) {
 		Return("REFRESH", nil)
 
 	sessRepo.
-		On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time")).
+		On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time"), "").
 		Return(100, nil)
 
 	tokenProv.
-		On("CreateAccessToken", 42, 100, user1.appID, mock.AnythingOfType("time.Time")).
+		On("CreateAccessToken", 42, 100, user1.appID, testApp.Name, mock.AnythingOfType("time.Time")).
 		Return("ACCESS", nil)
 
 	tok, err := uc.Login(user1.ctx, user1.email, user1.password, user1.appID)
@@ -88,14 +116,101 @@ func TestAuthUseCase_Login_Success(t *testing.T) {
 	assert.Equal(t, "ACCESS", tok.AccessToken)
 	assert.Equal(t, "REFRESH", tok.RefreshToken)
 
+	appRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 	sessRepo.AssertExpectations(t)
 	tokenProv.AssertExpectations(t)
 }
 
+func TestAuthUseCase_Login_RehashesWeakHash(t *testing.T) {
+	tests := []struct {
+		name      string
+		updateErr error
+	}{
+		{"rehash saved", nil},
+		{"rehash save failed does not block login", fmt.Errorf("failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(repoMocks.UserRepository)
+			sessRepo := new(repoMocks.SessionRepository)
+			appRepo := new(repoMocks.AppRepository)
+			cacheRepo := new(repoMocks.Cache)
+			tokenProv := new(providerMocks.TokenProvider)
+
+			logger := config.NewLogger(&cfg)
+
+			// Текущая политика - argon2id, а в базе bcrypt хэш
+			hasher := mustHasher(password.HashParams{Algorithm: password.Argon2id, Argon2Memory: 1024, Argon2Time: 1})
+
+			uc := usecase.NewAuthUseCase(
+				userRepo,
+				sessRepo,
+				appRepo,
+				cacheRepo,
+				tokenProv,
+				nil,
+				hasher,
+				nil,
+				*logger,
+				cfg.AccessTokenTTL,
+				cfg.RefreshTokenTTL,
+			)
+
+			user1 := testUserRequest{
+				ctx:      context.Background(),
+				email:    "test@example.com",
+				password: "password",
+				appID:    1,
+			}
+			user1.hashPass, _ = bcrypt.GenerateFromPassword([]byte(user1.password), bcrypt.MinCost)
+
+			appRepo.
+				On("AppByID", user1.ctx, user1.appID).
+				Return(testApp, nil)
+
+			userRepo.
+				On("UserByEmail", user1.ctx, user1.email).
+				Return(domain.User{ID: 42, Email: user1.email, PassHash: user1.hashPass}, nil)
+
+			var newHash []byte
+			userRepo.
+				On("UpdatePassHash", user1.ctx, 42, mock.MatchedBy(func(passHash []byte) bool {
+					newHash = passHash
+					return true
+				})).
+				Return(tt.updateErr)
+
+			tokenProv.
+				On("CreateRefreshToken").
+				Return("REFRESH", nil)
+
+			sessRepo.
+				On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time"), "").
+				Return(100, nil)
+
+			tokenProv.
+				On("CreateAccessToken", 42, 100, user1.appID, testApp.Name, mock.AnythingOfType("time.Time")).
+				Return("ACCESS", nil)
+
+			tok, err := uc.Login(user1.ctx, user1.email, user1.password, user1.appID)
+
+			require.NoError(t, err)
+			assert.Equal(t, "ACCESS", tok.AccessToken)
+
+			require.NoError(t, hasher.Compare(newHash, user1.password))
+			assert.False(t, hasher.NeedsRehash(newHash))
+
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestAuthUseCase_Login_WrongEmail(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -104,8 +219,12 @@ func TestAuthUseCase_Login_WrongEmail(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -118,6 +237,10 @@ func TestAuthUseCase_Login_WrongEmail(t *testing.T) {
 		appID:    1,
 	}
 
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(testApp, nil)
+
 	userRepo.
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{}, repository.ErrUserNotFound)
@@ -128,12 +251,14 @@ func TestAuthUseCase_Login_WrongEmail(t *testing.T) {
 	assert.Equal(t, "", tok.AccessToken)
 	assert.Equal(t, "", tok.RefreshToken)
 
+	appRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 }
 
 func TestAuthUseCase_Login_WrongPassword(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -142,8 +267,12 @@ func TestAuthUseCase_Login_WrongPassword(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -158,6 +287,10 @@ func TestAuthUseCase_Login_WrongPassword(t *testing.T) {
 
 	realHashPass, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
 
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(testApp, nil)
+
 	userRepo.
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{ID: 42, Email: user1.email, PassHash: realHashPass}, nil)
@@ -168,6 +301,7 @@ func TestAuthUseCase_Login_WrongPassword(t *testing.T) {
 	assert.Equal(t, "", tok.AccessToken)
 	assert.Equal(t, "", tok.RefreshToken)
 
+	appRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 }
 
@@ -177,6 +311,7 @@ func TestAuthUseCase_Login_UserRepoError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -185,8 +320,12 @@ func TestAuthUseCase_Login_UserRepoError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -199,6 +338,10 @@ func TestAuthUseCase_Login_UserRepoError(t *testing.T) {
 		appID:    1,
 	}
 
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(testApp, nil)
+
 	userRepo.
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{}, errFailed)
@@ -211,6 +354,7 @@ func TestAuthUseCase_Login_UserRepoError(t *testing.T) {
 	assert.Equal(t, "", tok.AccessToken)
 	assert.Equal(t, "", tok.RefreshToken)
 
+	appRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 }
 
@@ -220,6 +364,7 @@ func TestAuthUseCase_Login_RefreshTokenProviderError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -228,8 +373,12 @@ func TestAuthUseCase_Login_RefreshTokenProviderError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -243,6 +392,10 @@ func TestAuthUseCase_Login_RefreshTokenProviderError(t *testing.T) {
 	}
 	user1.hashPass, _ = bcrypt.GenerateFromPassword([]byte(user1.password), bcrypt.DefaultCost)
 
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(testApp, nil)
+
 	userRepo.
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{ID: 42, Email: user1.email, PassHash: user1.hashPass}, nil)
@@ -259,6 +412,7 @@ func TestAuthUseCase_Login_RefreshTokenProviderError(t *testing.T) {
 	assert.Equal(t, "", tok.AccessToken)
 	assert.Equal(t, "", tok.RefreshToken)
 
+	appRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 	tokenProv.AssertExpectations(t)
 }
@@ -270,6 +424,7 @@ func TestAuthUseCase_Login_SessionRepoError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -278,8 +433,12 @@ func TestAuthUseCase_Login_SessionRepoError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -293,6 +452,10 @@ func TestAuthUseCase_Login_SessionRepoError(t *testing.T) {
 	}
 	user1.hashPass, _ = bcrypt.GenerateFromPassword([]byte(user1.password), bcrypt.DefaultCost)
 
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(testApp, nil)
+
 	userRepo.
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{ID: 42, Email: user1.email, PassHash: user1.hashPass}, nil)
@@ -302,7 +465,7 @@ func TestAuthUseCase_Login_SessionRepoError(t *testing.T) {
 		Return("REFRESH", nil)
 
 	sessRepo.
-		On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time")).
+		On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time"), "").
 		Return(emptyID, errFailed)
 
 	tok, err := uc.Login(user1.ctx, user1.email, user1.password, user1.appID)
@@ -313,6 +476,7 @@ func TestAuthUseCase_Login_SessionRepoError(t *testing.T) {
 	assert.Equal(t, "", tok.AccessToken)
 	assert.Equal(t, "", tok.RefreshToken)
 
+	appRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 	sessRepo.AssertExpectations(t)
 	tokenProv.AssertExpectations(t)
@@ -324,6 +488,7 @@ func TestAuthUseCase_Login_AccessTokenProviderError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -332,8 +497,12 @@ func TestAuthUseCase_Login_AccessTokenProviderError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -347,6 +516,10 @@ func TestAuthUseCase_Login_AccessTokenProviderError(t *testing.T) {
 	}
 	user1.hashPass, _ = bcrypt.GenerateFromPassword([]byte(user1.password), bcrypt.DefaultCost)
 
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(testApp, nil)
+
 	userRepo.
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{ID: 42, Email: user1.email, PassHash: user1.hashPass}, nil)
@@ -356,11 +529,11 @@ func TestAuthUseCase_Login_AccessTokenProviderError(t *testing.T) {
 		Return("REFRESH", nil)
 
 	sessRepo.
-		On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time")).
+		On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time"), "").
 		Return(100, nil)
 
 	tokenProv.
-		On("CreateAccessToken", 42, 100, user1.appID, mock.AnythingOfType("time.Time")).
+		On("CreateAccessToken", 42, 100, user1.appID, testApp.Name, mock.AnythingOfType("time.Time")).
 		Return("", errFailed)
 
 	tok, err := uc.Login(user1.ctx, user1.email, user1.password, user1.appID)
@@ -371,6 +544,164 @@ func TestAuthUseCase_Login_AccessTokenProviderError(t *testing.T) {
 	assert.Equal(t, "", tok.AccessToken)
 	assert.Equal(t, "", tok.RefreshToken)
 
+	appRepo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	sessRepo.AssertExpectations(t)
+	tokenProv.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Login_UnknownApp(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	user1 := testUserRequest{
+		ctx:      context.Background(),
+		email:    "test@example.com",
+		password: "password",
+		appID:    7,
+	}
+
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(domain.App{}, repository.ErrAppNotFound)
+
+	tok, err := uc.Login(user1.ctx, user1.email, user1.password, user1.appID)
+
+	require.ErrorIs(t, err, repository.ErrAppNotFound)
+	assert.Equal(t, "", tok.AccessToken)
+
+	appRepo.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "UserByEmail", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_Login_DisabledApp(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	user1 := testUserRequest{
+		ctx:      context.Background(),
+		email:    "test@example.com",
+		password: "password",
+		appID:    2,
+	}
+
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(domain.App{ID: 2, Name: "legacy", Enabled: false}, nil)
+
+	tok, err := uc.Login(user1.ctx, user1.email, user1.password, user1.appID)
+
+	require.ErrorIs(t, err, repository.ErrAppDisabled)
+	assert.Equal(t, "", tok.AccessToken)
+
+	appRepo.AssertExpectations(t)
+	userRepo.AssertNotCalled(t, "UserByEmail", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_Login_AppTTLOverride(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	user1 := testUserRequest{
+		ctx:      context.Background(),
+		email:    "test@example.com",
+		password: "password",
+		appID:    3,
+	}
+	user1.hashPass, _ = bcrypt.GenerateFromPassword([]byte(user1.password), bcrypt.DefaultCost)
+
+	app := domain.App{
+		ID:              3,
+		Name:            "mobile",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+		Enabled:         true,
+	}
+
+	appRepo.
+		On("AppByID", user1.ctx, user1.appID).
+		Return(app, nil)
+
+	userRepo.
+		On("UserByEmail", user1.ctx, user1.email).
+		Return(domain.User{ID: 42, Email: user1.email, PassHash: user1.hashPass}, nil)
+
+	tokenProv.
+		On("CreateRefreshToken").
+		Return("REFRESH", nil)
+
+	sessRepo.
+		On("CreateSession", user1.ctx, 42, user1.appID, "REFRESH", mock.AnythingOfType("time.Time"), "").
+		Return(100, nil)
+
+	tokenProv.
+		On("CreateAccessToken", 42, 100, user1.appID, app.Name, mock.AnythingOfType("time.Time")).
+		Return("ACCESS", nil)
+
+	tok, err := uc.Login(user1.ctx, user1.email, user1.password, user1.appID)
+
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(app.AccessTokenTTL), tok.AccessExpireAt, time.Second)
+	assert.WithinDuration(t, time.Now().Add(app.RefreshTokenTTL), tok.RefreshExpireAt, time.Second)
+
+	appRepo.AssertExpectations(t)
 	userRepo.AssertExpectations(t)
 	sessRepo.AssertExpectations(t)
 	tokenProv.AssertExpectations(t)
@@ -379,16 +710,22 @@ func TestAuthUseCase_Login_AccessTokenProviderError(t *testing.T) {
 func TestAuthUseCase_Register_Success(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
+	passwords := new(providerMocks.PasswordPolicy)
 
 	logger := config.NewLogger(&cfg)
 
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		passwords,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -416,6 +753,8 @@ func TestAuthUseCase_Register_Success(t *testing.T) {
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{ID: id, Email: user1.email, PassHash: savedHash}, nil)
 
+	passwords.On("Check", user1.email, user1.password).Return(nil)
+
 	userID, err := uc.Register(user1.ctx, user1.email, user1.password)
 
 	require.NoError(t, err)
@@ -424,6 +763,55 @@ func TestAuthUseCase_Register_Success(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func TestAuthUseCase_Register_WeakPassword(t *testing.T) {
+	const op = "Auth.Register"
+	emptyID := 0
+
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+	passwords := new(providerMocks.PasswordPolicy)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		passwords,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	user1 := testUserRequest{
+		ctx:      context.Background(),
+		email:    "test@example.com",
+		password: "test@example.com",
+	}
+
+	policyErr := &password.PolicyError{Violations: []password.Violation{
+		{Rule: password.RuleEqualsEmail, Message: "must not be the same as the email"},
+	}}
+	passwords.On("Check", user1.email, user1.password).Return(policyErr)
+
+	userID, err := uc.Register(user1.ctx, user1.email, user1.password)
+
+	require.ErrorIs(t, err, password.ErrWeakPassword)
+	assert.Contains(t, err.Error(), op)
+	assert.Equal(t, emptyID, userID)
+
+	// До сохранения пользователя дело не дошло
+	userRepo.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything, mock.Anything)
+	passwords.AssertExpectations(t)
+}
+
 func TestAuthUseCase_Register_SaveUserError(t *testing.T) {
 	const op = "Auth.Register"
 	errFailed := fmt.Errorf("failed")
@@ -431,16 +819,22 @@ func TestAuthUseCase_Register_SaveUserError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
+	passwords := new(providerMocks.PasswordPolicy)
 
 	logger := config.NewLogger(&cfg)
 
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		passwords,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -459,6 +853,8 @@ func TestAuthUseCase_Register_SaveUserError(t *testing.T) {
 		})).
 		Return(errFailed)
 
+	passwords.On("Check", user1.email, user1.password).Return(nil)
+
 	userID, err := uc.Register(user1.ctx, user1.email, user1.password)
 
 	require.Error(t, err)
@@ -476,16 +872,22 @@ func TestAuthUseCase_Register_UserByEmailError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
+	passwords := new(providerMocks.PasswordPolicy)
 
 	logger := config.NewLogger(&cfg)
 
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		passwords,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -507,6 +909,8 @@ func TestAuthUseCase_Register_UserByEmailError(t *testing.T) {
 		On("UserByEmail", user1.ctx, user1.email).
 		Return(domain.User{}, errFailed)
 
+	passwords.On("Check", user1.email, user1.password).Return(nil)
+
 	userID, err := uc.Register(user1.ctx, user1.email, user1.password)
 
 	require.Error(t, err)
@@ -517,10 +921,109 @@ func TestAuthUseCase_Register_UserByEmailError(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func newChangePasswordUseCase(userRepo *repoMocks.UserRepository, passwords *providerMocks.PasswordPolicy) *usecase.AuthUseCase {
+	logger := config.NewLogger(&cfg)
+
+	return usecase.NewAuthUseCase(
+		userRepo,
+		new(repoMocks.SessionRepository),
+		new(repoMocks.AppRepository),
+		new(repoMocks.Cache),
+		new(providerMocks.TokenProvider),
+		passwords,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+}
+
+func TestAuthUseCase_ChangePassword_Success(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	passwords := new(providerMocks.PasswordPolicy)
+	uc := newChangePasswordUseCase(userRepo, passwords)
+
+	ctx := context.Background()
+	currentHash, err := bcrypt.GenerateFromPassword([]byte("old-Passw0rd"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	userRepo.
+		On("UserByID", ctx, 42).
+		Return(domain.User{ID: 42, Email: "test@example.com", PassHash: currentHash}, nil)
+	passwords.On("Check", "test@example.com", "new-Passw0rd").Return(nil)
+
+	var newHash []byte
+	userRepo.
+		On("UpdatePassHash", ctx, 42, mock.MatchedBy(func(passHash []byte) bool {
+			newHash = passHash
+			return true
+		})).
+		Return(nil)
+
+	err = uc.ChangePassword(ctx, 42, "old-Passw0rd", "new-Passw0rd")
+
+	require.NoError(t, err)
+	require.NoError(t, testHasher.Compare(newHash, "new-Passw0rd"))
+
+	userRepo.AssertExpectations(t)
+	passwords.AssertExpectations(t)
+}
+
+func TestAuthUseCase_ChangePassword_WrongCurrentPassword(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	passwords := new(providerMocks.PasswordPolicy)
+	uc := newChangePasswordUseCase(userRepo, passwords)
+
+	ctx := context.Background()
+	currentHash, err := bcrypt.GenerateFromPassword([]byte("old-Passw0rd"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	userRepo.
+		On("UserByID", ctx, 42).
+		Return(domain.User{ID: 42, Email: "test@example.com", PassHash: currentHash}, nil)
+
+	err = uc.ChangePassword(ctx, 42, "guess", "new-Passw0rd")
+
+	require.ErrorIs(t, err, repository.ErrInvalidCredentials)
+	assert.Contains(t, err.Error(), "Auth.ChangePassword")
+
+	passwords.AssertNotCalled(t, "Check", mock.Anything, mock.Anything)
+	userRepo.AssertNotCalled(t, "UpdatePassHash", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_ChangePassword_WeakPassword(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	passwords := new(providerMocks.PasswordPolicy)
+	uc := newChangePasswordUseCase(userRepo, passwords)
+
+	ctx := context.Background()
+	currentHash, err := bcrypt.GenerateFromPassword([]byte("old-Passw0rd"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	userRepo.
+		On("UserByID", ctx, 42).
+		Return(domain.User{ID: 42, Email: "test@example.com", PassHash: currentHash}, nil)
+
+	policyErr := &password.PolicyError{Violations: []password.Violation{
+		{Rule: password.RuleTooShort, Message: "must be at least 12 characters"},
+	}}
+	passwords.On("Check", "test@example.com", "short").Return(policyErr)
+
+	err = uc.ChangePassword(ctx, 42, "old-Passw0rd", "short")
+
+	require.ErrorIs(t, err, password.ErrWeakPassword)
+
+	// Слабый пароль не сохраняется
+	userRepo.AssertNotCalled(t, "UpdatePassHash", mock.Anything, mock.Anything, mock.Anything)
+	passwords.AssertExpectations(t)
+}
+
 func TestAuthUseCase_IsAdmin_SeccessTrue(t *testing.T) {
 	// m := mocks.*
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -529,8 +1032,12 @@ func TestAuthUseCase_IsAdmin_SeccessTrue(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -557,6 +1064,7 @@ func TestAuthUseCase_IsAdmin_SeccessFalse(t *testing.T) {
 	// m := mocks.*
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -565,8 +1073,12 @@ func TestAuthUseCase_IsAdmin_SeccessFalse(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -595,6 +1107,7 @@ func TestAuthUseCase_IsAdmin_IsAdminError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -603,8 +1116,12 @@ func TestAuthUseCase_IsAdmin_IsAdminError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -632,6 +1149,7 @@ func TestAuthUseCase_IsAdmin_IsAdminError(t *testing.T) {
 func TestAuthUseCase_Logout_SeccessOK(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -640,8 +1158,12 @@ func TestAuthUseCase_Logout_SeccessOK(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -683,6 +1205,7 @@ func TestAuthUseCase_Logout_SeccessOK(t *testing.T) {
 func TestAuthUseCase_Logout_SeccessFail(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -691,8 +1214,12 @@ func TestAuthUseCase_Logout_SeccessFail(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -737,6 +1264,7 @@ func TestAuthUseCase_Logout_RevokeByRefreshTokenError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -745,8 +1273,12 @@ func TestAuthUseCase_Logout_RevokeByRefreshTokenError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -774,6 +1306,7 @@ func TestAuthUseCase_Logout_RevokeByRefreshTokenError(t *testing.T) {
 func TestAuthUseCase_RefreshToken_Success(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -782,8 +1315,12 @@ func TestAuthUseCase_RefreshToken_Success(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -807,6 +1344,10 @@ func TestAuthUseCase_RefreshToken_Success(t *testing.T) {
 		On("SessionByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(session, nil)
 
+	appRepo.
+		On("AppByID", user1.ctx, session.AppID).
+		Return(testApp, nil)
+
 	sessRepo.
 		On("RevokeByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(true, nil)
@@ -820,11 +1361,11 @@ func TestAuthUseCase_RefreshToken_Success(t *testing.T) {
 		Return("NEW_REFRESH", nil)
 
 	sessRepo.
-		On("CreateSession", user1.ctx, session.UserID, session.AppID, "NEW_REFRESH", mock.AnythingOfType("time.Time")).
+		On("CreateSession", user1.ctx, session.UserID, session.AppID, "NEW_REFRESH", mock.AnythingOfType("time.Time"), session.Scope).
 		Return(200, nil)
 
 	tokenProv.
-		On("CreateAccessToken", session.UserID, 200, session.AppID, mock.AnythingOfType("time.Time")).
+		On("CreateAccessToken", session.UserID, 200, session.AppID, testApp.Name, mock.AnythingOfType("time.Time")).
 		Return("NEW_ACCESS", nil)
 
 	tok, err := uc.RefreshToken(user1.ctx, user1.refreshToken)
@@ -843,6 +1384,7 @@ func TestAuthUseCase_RefreshToken_SessionByRefreshTokenError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -851,8 +1393,12 @@ func TestAuthUseCase_RefreshToken_SessionByRefreshTokenError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -883,6 +1429,7 @@ func TestAuthUseCase_RefreshToken_InvalidStatus(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -891,8 +1438,12 @@ func TestAuthUseCase_RefreshToken_InvalidStatus(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -932,6 +1483,7 @@ func TestAuthUseCase_RefreshToken_Expired(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -940,8 +1492,12 @@ func TestAuthUseCase_RefreshToken_Expired(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -976,12 +1532,71 @@ func TestAuthUseCase_RefreshToken_Expired(t *testing.T) {
 	sessRepo.AssertExpectations(t)
 }
 
+func TestAuthUseCase_RefreshToken_DisabledApp(t *testing.T) {
+	const op = "Auth.RefreshToken"
+
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	user1 := testUserRequest{
+		ctx:          context.Background(),
+		refreshToken: "REFRESH",
+		appID:        2,
+	}
+
+	session := domain.Session{
+		ID:               100,
+		UserID:           42,
+		AppID:            user1.appID,
+		RefreshExpiresAt: time.Now().Add(time.Hour),
+		Status:           "active",
+	}
+
+	sessRepo.
+		On("SessionByRefreshToken", user1.ctx, user1.refreshToken).
+		Return(session, nil)
+
+	appRepo.
+		On("AppByID", user1.ctx, session.AppID).
+		Return(domain.App{ID: 2, Name: "legacy", Enabled: false}, nil)
+
+	tok, err := uc.RefreshToken(user1.ctx, user1.refreshToken)
+
+	require.ErrorIs(t, err, repository.ErrAppDisabled)
+	assert.Contains(t, err.Error(), op)
+	assert.Equal(t, "", tok.AccessToken)
+
+	sessRepo.AssertExpectations(t)
+	appRepo.AssertExpectations(t)
+	sessRepo.AssertNotCalled(t, "RevokeByRefreshToken", mock.Anything, mock.Anything)
+}
+
 func TestAuthUseCase_RefreshToken_CreateRefreshTokenError(t *testing.T) {
 	const op = "Auth.RefreshToken"
 	errFailed := fmt.Errorf("failed")
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -990,8 +1605,12 @@ func TestAuthUseCase_RefreshToken_CreateRefreshTokenError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -1015,6 +1634,10 @@ func TestAuthUseCase_RefreshToken_CreateRefreshTokenError(t *testing.T) {
 		On("SessionByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(session, nil)
 
+	appRepo.
+		On("AppByID", user1.ctx, session.AppID).
+		Return(testApp, nil)
+
 	sessRepo.
 		On("RevokeByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(true, nil)
@@ -1046,6 +1669,7 @@ func TestAuthUseCase_RefreshToken_CreateSessionError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -1054,8 +1678,12 @@ func TestAuthUseCase_RefreshToken_CreateSessionError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -1079,6 +1707,10 @@ func TestAuthUseCase_RefreshToken_CreateSessionError(t *testing.T) {
 		On("SessionByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(session, nil)
 
+	appRepo.
+		On("AppByID", user1.ctx, session.AppID).
+		Return(testApp, nil)
+
 	sessRepo.
 		On("RevokeByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(true, nil)
@@ -1092,7 +1724,7 @@ func TestAuthUseCase_RefreshToken_CreateSessionError(t *testing.T) {
 		Return("NEW_REFRESH", nil)
 
 	sessRepo.
-		On("CreateSession", user1.ctx, session.UserID, session.AppID, "NEW_REFRESH", mock.AnythingOfType("time.Time")).
+		On("CreateSession", user1.ctx, session.UserID, session.AppID, "NEW_REFRESH", mock.AnythingOfType("time.Time"), session.Scope).
 		Return(emptyID, errFailed)
 
 	tok, err := uc.RefreshToken(user1.ctx, user1.refreshToken)
@@ -1113,6 +1745,7 @@ func TestAuthUseCase_RefreshToken_CreateAccessTokenError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -1121,8 +1754,12 @@ func TestAuthUseCase_RefreshToken_CreateAccessTokenError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -1146,6 +1783,10 @@ func TestAuthUseCase_RefreshToken_CreateAccessTokenError(t *testing.T) {
 		On("SessionByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(session, nil)
 
+	appRepo.
+		On("AppByID", user1.ctx, session.AppID).
+		Return(testApp, nil)
+
 	sessRepo.
 		On("RevokeByRefreshToken", user1.ctx, user1.refreshToken).
 		Return(true, nil)
@@ -1159,11 +1800,11 @@ func TestAuthUseCase_RefreshToken_CreateAccessTokenError(t *testing.T) {
 		Return("NEW_REFRESH", nil)
 
 	sessRepo.
-		On("CreateSession", user1.ctx, session.UserID, session.AppID, "NEW_REFRESH", mock.AnythingOfType("time.Time")).
+		On("CreateSession", user1.ctx, session.UserID, session.AppID, "NEW_REFRESH", mock.AnythingOfType("time.Time"), session.Scope).
 		Return(200, nil)
 
 	tokenProv.
-		On("CreateAccessToken", session.UserID, 200, session.AppID, mock.AnythingOfType("time.Time")).
+		On("CreateAccessToken", session.UserID, 200, session.AppID, testApp.Name, mock.AnythingOfType("time.Time")).
 		Return("", errFailed)
 
 	tok, err := uc.RefreshToken(user1.ctx, user1.refreshToken)
@@ -1177,3 +1818,293 @@ func TestAuthUseCase_RefreshToken_CreateAccessTokenError(t *testing.T) {
 	sessRepo.AssertExpectations(t)
 	tokenProv.AssertExpectations(t)
 }
+
+func TestAuthUseCase_IntrospectToken_Active(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	ctx := context.Background()
+	exp := time.Now().Add(time.Minute)
+
+	tokenProv.
+		On("ParseAccessToken", "ACCESS").
+		Return(tokenjwt.AccessClaims{
+			UserID:    42,
+			SessionID: 100,
+			AppID:     1,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Audience:  jwt.ClaimStrings{testApp.Name},
+				ExpiresAt: jwt.NewNumericDate(exp),
+			},
+		}, nil)
+
+	cacheRepo.
+		On("GetSession", ctx, 100).
+		Return(true, domain.Session{ID: 100, Status: "active", RefreshExpiresAt: exp}, nil)
+	cacheRepo.
+		On("GetRoles", ctx, 42).
+		Return(false, []string(nil), nil)
+
+	userRepo.
+		On("IsAdmin", ctx, 42).
+		Return(true, nil)
+
+	cacheRepo.
+		On("SetRoles", ctx, 42, []string{domain.RoleUser, domain.RoleAdmin}).
+		Return(nil)
+
+	info, err := uc.IntrospectToken(ctx, "ACCESS", testApp.Name)
+
+	require.NoError(t, err)
+	assert.True(t, info.Active)
+	assert.Equal(t, 42, info.UserID)
+	assert.Equal(t, 100, info.SessionID)
+	assert.Equal(t, []string{domain.RoleUser, domain.RoleAdmin}, info.Roles)
+
+	tokenProv.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	cacheRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_IntrospectToken_RolesFromCache(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	ctx := context.Background()
+	exp := time.Now().Add(time.Minute)
+
+	tokenProv.
+		On("ParseAccessToken", "ACCESS").
+		Return(tokenjwt.AccessClaims{UserID: 42, SessionID: 100}, nil)
+
+	cacheRepo.
+		On("GetSession", ctx, 100).
+		Return(true, domain.Session{ID: 100, Status: "active", RefreshExpiresAt: exp}, nil)
+	cacheRepo.
+		On("GetRoles", ctx, 42).
+		Return(true, []string{domain.RoleUser}, nil)
+
+	info, err := uc.IntrospectToken(ctx, "ACCESS", "")
+
+	require.NoError(t, err)
+	assert.True(t, info.Active)
+	assert.Equal(t, []string{domain.RoleUser}, info.Roles)
+	// Интроспекция на каждый RPC не должна ходить в БД за ролями
+	userRepo.AssertNotCalled(t, "IsAdmin", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_InvalidateRoles(t *testing.T) {
+	cacheRepo := new(repoMocks.Cache)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		new(repoMocks.UserRepository),
+		new(repoMocks.SessionRepository),
+		new(repoMocks.AppRepository),
+		cacheRepo,
+		new(providerMocks.TokenProvider),
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	ctx := context.Background()
+	cacheErr := errors.New("redis down")
+
+	cacheRepo.On("DelRoles", ctx, 42).Return(nil).Once()
+	cacheRepo.On("DelRoles", ctx, 43).Return(cacheErr).Once()
+
+	require.NoError(t, uc.InvalidateRoles(ctx, 42))
+	require.ErrorIs(t, uc.InvalidateRoles(ctx, 43), cacheErr)
+	cacheRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_IntrospectToken_WrongAudience(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	tokenProv.
+		On("ParseAccessToken", "ACCESS").
+		Return(tokenjwt.AccessClaims{
+			UserID:           42,
+			SessionID:        100,
+			RegisteredClaims: jwt.RegisteredClaims{Audience: jwt.ClaimStrings{"other"}},
+		}, nil)
+
+	info, err := uc.IntrospectToken(context.Background(), "ACCESS", testApp.Name)
+
+	require.NoError(t, err)
+	assert.False(t, info.Active)
+	cacheRepo.AssertNotCalled(t, "GetSession", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_IntrospectToken_RevokedSession(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	ctx := context.Background()
+
+	tokenProv.
+		On("ParseAccessToken", "ACCESS").
+		Return(tokenjwt.AccessClaims{UserID: 42, SessionID: 100}, nil)
+
+	cacheRepo.
+		On("GetSession", ctx, 100).
+		Return(true, domain.Session{ID: 100, Status: "revoked", RefreshExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	info, err := uc.IntrospectToken(ctx, "ACCESS", "")
+
+	require.NoError(t, err)
+	assert.False(t, info.Active)
+	userRepo.AssertNotCalled(t, "IsAdmin", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_IntrospectToken_BadSignature(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
+	cacheRepo := new(repoMocks.Cache)
+	tokenProv := new(providerMocks.TokenProvider)
+
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		sessRepo,
+		appRepo,
+		cacheRepo,
+		tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	tokenProv.
+		On("ParseAccessToken", "FORGED").
+		Return(tokenjwt.AccessClaims{}, fmt.Errorf("signature is invalid"))
+
+	info, err := uc.IntrospectToken(context.Background(), "FORGED", "")
+
+	require.NoError(t, err)
+	assert.False(t, info.Active)
+}
+
+func TestAuthUseCase_UsersExist(t *testing.T) {
+	userRepo := new(repoMocks.UserRepository)
+	logger := config.NewLogger(&cfg)
+
+	uc := usecase.NewAuthUseCase(
+		userRepo,
+		new(repoMocks.SessionRepository),
+		new(repoMocks.AppRepository),
+		new(repoMocks.Cache),
+		new(providerMocks.TokenProvider),
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	ctx := context.Background()
+	errFailed := fmt.Errorf("failed")
+	userRepo.On("ExistingUserIDs", ctx, []int{1, 2, 3}).Return([]int{1, 3}, nil).Once()
+	userRepo.On("ExistingUserIDs", ctx, []int{4}).Return(nil, errFailed).Once()
+
+	existing, err := uc.UsersExist(ctx, []int{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, existing)
+
+	_, err = uc.UsersExist(ctx, []int{4})
+	require.ErrorIs(t, err, errFailed)
+
+	// Пустой список в БД не ходит
+	existing, err = uc.UsersExist(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, existing)
+	userRepo.AssertExpectations(t)
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"auth/pkg/oidc"
+	tokenjwt "auth/pkg/token"
+	"auth/provider"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	externalLoginTTL = 10 * time.Minute
+)
+
+// ExternalLoginUseCase - вход через внешние OIDC провайдеры.
+// Аккаунт создаётся или привязывается по (provider, sub), токены выдаются как в Login.
+type ExternalLoginUseCase struct {
+	auth       *AuthUseCase
+	identities repository.IdentityRepository
+	states     repository.ExternalLoginRepository
+	providers  map[string]provider.IdentityProvider
+
+	logger slog.Logger
+}
+
+// NewExternalLoginUseCase ...
+func NewExternalLoginUseCase(
+	auth *AuthUseCase,
+	identities repository.IdentityRepository,
+	states repository.ExternalLoginRepository,
+	providers map[string]provider.IdentityProvider,
+	logger slog.Logger) *ExternalLoginUseCase {
+	return &ExternalLoginUseCase{
+		auth:       auth,
+		identities: identities,
+		states:     states,
+		providers:  providers,
+		logger:     logger,
+	}
+}
+
+// StartExternalLogin ...
+func (e *ExternalLoginUseCase) StartExternalLogin(ctx context.Context, providerName string, appID int) (authURL string, state string, err error) {
+	const op = "ExternalLogin.Start"
+
+	log := e.logger.With(
+		slog.String("op", op),
+		slog.String("provider", providerName),
+	)
+
+	log.InfoContext(ctx, "start external login")
+
+	idp, ok := e.providers[providerName]
+	if !ok {
+		return "", "", fmt.Errorf("%s: %w", op, provider.ErrUnknownIdentityProvider)
+	}
+
+	if _, err := e.auth.enabledApp(ctx, appID); err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	state, err = e.auth.token.CreateRefreshToken()
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	nonce, err := e.auth.token.CreateRefreshToken()
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+	verifier, err := e.auth.token.CreateRefreshToken()
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = e.states.SaveState(ctx, domain.ExternalLoginState{
+		StateHash:    hashCode(state),
+		Provider:     providerName,
+		AppID:        appID,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(externalLoginTTL),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	authURL, err = idp.AuthCodeURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(sum[:]))
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return authURL, state, nil
+}
+
+// CompleteExternalLogin ...
+func (e *ExternalLoginUseCase) CompleteExternalLogin(ctx context.Context, providerName string, code string, state string) (token tokenjwt.Token, err error) {
+	const op = "ExternalLogin.Complete"
+
+	log := e.logger.With(
+		slog.String("op", op),
+		slog.String("provider", providerName),
+	)
+
+	log.InfoContext(ctx, "complete external login")
+
+	idp, ok := e.providers[providerName]
+	if !ok {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, provider.ErrUnknownIdentityProvider)
+	}
+
+	st, err := e.states.ConsumeState(ctx, hashCode(state))
+	if err != nil {
+		if errors.Is(err, repository.ErrExternalStateNotFound) {
+			return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, provider.ErrExternalLoginFailed)
+		}
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if st.Provider != providerName || time.Now().After(st.ExpiresAt) {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, provider.ErrExternalLoginFailed)
+	}
+
+	identity, err := idp.Exchange(ctx, code, st.CodeVerifier, st.Nonce)
+	if err != nil {
+		log.WarnContext(ctx, "provider rejected code", slog.String("err", err.Error()))
+
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, provider.ErrExternalLoginFailed)
+	}
+
+	userID, err := e.resolveUser(ctx, providerName, identity)
+	if err != nil {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	app, err := e.auth.enabledApp(ctx, st.AppID)
+	if err != nil {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	token, err = e.auth.issueTokens(ctx, userID, app, "", map[string]string{"method": "external", "provider": providerName})
+	if err != nil {
+		return tokenjwt.Token{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// resolveUser находит пользователя по привязке, привязывает существующий аккаунт
+// с тем же подтверждённым email или создаёт новый - тоже только с подтверждённым email:
+// иначе неподтверждённый адрес занял бы email у его настоящего владельца.
+func (e *ExternalLoginUseCase) resolveUser(ctx context.Context, providerName string, identity oidc.Identity) (int, error) {
+	linked, err := e.identities.IdentityBySubject(ctx, providerName, identity.Subject)
+	if err == nil {
+		return linked.UserID, nil
+	}
+	if !errors.Is(err, repository.ErrIdentityNotFound) {
+		return emptyID, err
+	}
+
+	if identity.Email == "" {
+		return emptyID, provider.ErrExternalLoginFailed
+	}
+
+	user, err := e.auth.users.UserByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		// Без подтверждения email провайдером чужой аккаунт не привязываем
+		if !identity.EmailVerified {
+			return emptyID, repository.ErrUserAlreadyExists
+		}
+	case errors.Is(err, repository.ErrUserNotFound):
+		if !identity.EmailVerified {
+			return emptyID, provider.ErrExternalLoginFailed
+		}
+		// Пустой хеш - вход по паролю для такого аккаунта невозможен
+		if err := e.auth.users.SaveUser(ctx, identity.Email, nil); err != nil {
+			return emptyID, err
+		}
+		user, err = e.auth.users.UserByEmail(ctx, identity.Email)
+		if err != nil {
+			return emptyID, err
+		}
+		e.auth.record(ctx, domain.AuditEvent{
+			Type:    domain.AuditUserRegistered,
+			UserID:  user.ID,
+			Details: map[string]string{"provider": providerName},
+		})
+	default:
+		return emptyID, err
+	}
+
+	err = e.identities.LinkIdentity(ctx, domain.Identity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		return emptyID, err
+	}
+
+	return user.ID, nil
+}
@@ -0,0 +1,295 @@
+package usecase_test
+
+import (
+	"auth/internal/config"
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"auth/internal/usecase"
+	providerMocks "auth/mocks/provider"
+	repoMocks "auth/mocks/repository"
+	"auth/pkg/oidc"
+	"auth/provider"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type externalMocks struct {
+	userRepo   *repoMocks.UserRepository
+	sessRepo   *repoMocks.SessionRepository
+	appRepo    *repoMocks.AppRepository
+	cacheRepo  *repoMocks.Cache
+	identities *repoMocks.IdentityRepository
+	states     *repoMocks.ExternalLoginRepository
+	tokenProv  *providerMocks.TokenProvider
+	idp        *providerMocks.IdentityProvider
+	audit      *providerMocks.AuditLogger
+}
+
+func newExternalLoginUseCase() (*usecase.ExternalLoginUseCase, externalMocks) {
+	m := externalMocks{
+		userRepo:   new(repoMocks.UserRepository),
+		sessRepo:   new(repoMocks.SessionRepository),
+		appRepo:    new(repoMocks.AppRepository),
+		cacheRepo:  new(repoMocks.Cache),
+		identities: new(repoMocks.IdentityRepository),
+		states:     new(repoMocks.ExternalLoginRepository),
+		tokenProv:  new(providerMocks.TokenProvider),
+		idp:        new(providerMocks.IdentityProvider),
+		audit:      new(providerMocks.AuditLogger),
+	}
+
+	// Проверяем только нужные тесту события, остальные - как придётся
+	m.audit.On("Record", mock.Anything, mock.Anything).Return().Maybe()
+
+	logger := config.NewLogger(&cfg)
+
+	auth := usecase.NewAuthUseCase(
+		m.userRepo,
+		m.sessRepo,
+		m.appRepo,
+		m.cacheRepo,
+		m.tokenProv,
+		nil,
+		testHasher,
+		m.audit,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	providers := map[string]provider.IdentityProvider{"google": m.idp}
+
+	return usecase.NewExternalLoginUseCase(auth, m.identities, m.states, providers, *logger), m
+}
+
+// expectState - callback пришёл с валидным state от StartExternalLogin.
+func (m externalMocks) expectState(ctx context.Context) {
+	m.states.
+		On("ConsumeState", ctx, hashOf("STATE")).
+		Return(domain.ExternalLoginState{
+			Provider:     "google",
+			AppID:        testApp.ID,
+			Nonce:        "NONCE",
+			CodeVerifier: "VERIFIER",
+			ExpiresAt:    time.Now().Add(time.Minute),
+		}, nil)
+}
+
+// expectTokens - выдача токенов тем же путём, что и в Login.
+func (m externalMocks) expectTokens(ctx context.Context, userID int) {
+	m.appRepo.
+		On("AppByID", ctx, testApp.ID).
+		Return(testApp, nil)
+
+	m.tokenProv.
+		On("CreateRefreshToken").
+		Return("REFRESH", nil)
+
+	m.sessRepo.
+		On("CreateSession", ctx, userID, testApp.ID, "REFRESH", mock.AnythingOfType("time.Time"), "").
+		Return(100, nil)
+
+	m.tokenProv.
+		On("CreateAccessToken", userID, 100, testApp.ID, testApp.Name, mock.AnythingOfType("time.Time")).
+		Return("ACCESS", nil)
+}
+
+func TestExternalLogin_Start(t *testing.T) {
+	uc, m := newExternalLoginUseCase()
+	ctx := context.Background()
+
+	m.appRepo.
+		On("AppByID", ctx, testApp.ID).
+		Return(testApp, nil)
+
+	m.tokenProv.
+		On("CreateRefreshToken").
+		Return("RANDOM", nil)
+
+	m.states.
+		On("SaveState", ctx, mock.MatchedBy(func(s domain.ExternalLoginState) bool {
+			return s.StateHash == hashOf("RANDOM") && s.Provider == "google" && s.AppID == testApp.ID
+		})).
+		Return(nil)
+
+	m.idp.
+		On("AuthCodeURL", ctx, "RANDOM", "RANDOM", codeChallenge("RANDOM")).
+		Return("https://accounts.example.com/authorize?state=RANDOM", nil)
+
+	authURL, state, err := uc.StartExternalLogin(ctx, "google", testApp.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "RANDOM", state)
+	assert.Equal(t, "https://accounts.example.com/authorize?state=RANDOM", authURL)
+
+	m.states.AssertExpectations(t)
+	m.idp.AssertExpectations(t)
+}
+
+func TestExternalLogin_StartUnknownProvider(t *testing.T) {
+	uc, _ := newExternalLoginUseCase()
+
+	_, _, err := uc.StartExternalLogin(context.Background(), "github", testApp.ID)
+
+	require.ErrorIs(t, err, provider.ErrUnknownIdentityProvider)
+}
+
+func TestExternalLogin_CompleteLinkedIdentity(t *testing.T) {
+	uc, m := newExternalLoginUseCase()
+	ctx := context.Background()
+
+	m.expectState(ctx)
+
+	m.idp.
+		On("Exchange", ctx, "CODE", "VERIFIER", "NONCE").
+		Return(oidc.Identity{Subject: "sub-1", Email: "user@example.org", EmailVerified: true}, nil)
+
+	m.identities.
+		On("IdentityBySubject", ctx, "google", "sub-1").
+		Return(domain.Identity{UserID: 42, Provider: "google", Subject: "sub-1"}, nil)
+
+	m.expectTokens(ctx, 42)
+
+	token, err := uc.CompleteExternalLogin(ctx, "google", "CODE", "STATE")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ACCESS", token.AccessToken)
+	assert.Equal(t, "REFRESH", token.RefreshToken)
+
+	m.userRepo.AssertNotCalled(t, "UserByEmail", mock.Anything, mock.Anything)
+	m.sessRepo.AssertExpectations(t)
+}
+
+func TestExternalLogin_CompleteCreatesUser(t *testing.T) {
+	uc, m := newExternalLoginUseCase()
+	ctx := context.Background()
+
+	m.expectState(ctx)
+
+	m.idp.
+		On("Exchange", ctx, "CODE", "VERIFIER", "NONCE").
+		Return(oidc.Identity{Subject: "sub-1", Email: "new@example.org", EmailVerified: true}, nil)
+
+	m.identities.
+		On("IdentityBySubject", ctx, "google", "sub-1").
+		Return(domain.Identity{}, repository.ErrIdentityNotFound)
+
+	m.userRepo.
+		On("UserByEmail", ctx, "new@example.org").
+		Return(domain.User{}, repository.ErrUserNotFound).
+		Once()
+
+	m.userRepo.
+		On("SaveUser", ctx, "new@example.org", []byte(nil)).
+		Return(nil)
+
+	m.userRepo.
+		On("UserByEmail", ctx, "new@example.org").
+		Return(domain.User{ID: 7, Email: "new@example.org"}, nil).
+		Once()
+
+	m.identities.
+		On("LinkIdentity", ctx, domain.Identity{UserID: 7, Provider: "google", Subject: "sub-1", Email: "new@example.org"}).
+		Return(nil)
+
+	m.expectTokens(ctx, 7)
+
+	token, err := uc.CompleteExternalLogin(ctx, "google", "CODE", "STATE")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ACCESS", token.AccessToken)
+
+	m.userRepo.AssertExpectations(t)
+	m.identities.AssertExpectations(t)
+	m.audit.AssertCalled(t, "Record", ctx, domain.AuditEvent{
+		Type:    domain.AuditUserRegistered,
+		UserID:  7,
+		Details: map[string]string{"provider": "google"},
+	})
+}
+
+func TestExternalLogin_CompleteUnverifiedEmailNotLinked(t *testing.T) {
+	uc, m := newExternalLoginUseCase()
+	ctx := context.Background()
+
+	m.expectState(ctx)
+
+	m.idp.
+		On("Exchange", ctx, "CODE", "VERIFIER", "NONCE").
+		Return(oidc.Identity{Subject: "sub-1", Email: "user@example.org", EmailVerified: false}, nil)
+
+	m.identities.
+		On("IdentityBySubject", ctx, "google", "sub-1").
+		Return(domain.Identity{}, repository.ErrIdentityNotFound)
+
+	m.userRepo.
+		On("UserByEmail", ctx, "user@example.org").
+		Return(domain.User{ID: 42, Email: "user@example.org"}, nil)
+
+	_, err := uc.CompleteExternalLogin(ctx, "google", "CODE", "STATE")
+
+	require.ErrorIs(t, err, repository.ErrUserAlreadyExists)
+	m.identities.AssertNotCalled(t, "LinkIdentity", mock.Anything, mock.Anything)
+}
+
+func TestExternalLogin_CompleteUnverifiedEmailNotRegistered(t *testing.T) {
+	uc, m := newExternalLoginUseCase()
+	ctx := context.Background()
+
+	m.expectState(ctx)
+
+	m.idp.
+		On("Exchange", ctx, "CODE", "VERIFIER", "NONCE").
+		Return(oidc.Identity{Subject: "sub-1", Email: "new@example.org", EmailVerified: false}, nil)
+
+	m.identities.
+		On("IdentityBySubject", ctx, "google", "sub-1").
+		Return(domain.Identity{}, repository.ErrIdentityNotFound)
+
+	m.userRepo.
+		On("UserByEmail", ctx, "new@example.org").
+		Return(domain.User{}, repository.ErrUserNotFound)
+
+	_, err := uc.CompleteExternalLogin(ctx, "google", "CODE", "STATE")
+
+	// Неподтверждённый email не должен занять адрес настоящего владельца
+	require.ErrorIs(t, err, provider.ErrExternalLoginFailed)
+	m.userRepo.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything, mock.Anything)
+	m.identities.AssertNotCalled(t, "LinkIdentity", mock.Anything, mock.Anything)
+	m.sessRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExternalLogin_CompleteReusedState(t *testing.T) {
+	uc, m := newExternalLoginUseCase()
+	ctx := context.Background()
+
+	m.states.
+		On("ConsumeState", ctx, hashOf("STATE")).
+		Return(domain.ExternalLoginState{}, repository.ErrExternalStateNotFound)
+
+	_, err := uc.CompleteExternalLogin(ctx, "google", "CODE", "STATE")
+
+	require.ErrorIs(t, err, provider.ErrExternalLoginFailed)
+	m.idp.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExternalLogin_CompleteProviderRejected(t *testing.T) {
+	uc, m := newExternalLoginUseCase()
+	ctx := context.Background()
+
+	m.expectState(ctx)
+
+	m.idp.
+		On("Exchange", ctx, "CODE", "VERIFIER", "NONCE").
+		Return(oidc.Identity{}, errors.New("invalid id token"))
+
+	_, err := uc.CompleteExternalLogin(ctx, "google", "CODE", "STATE")
+
+	require.ErrorIs(t, err, provider.ErrExternalLoginFailed)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Значения label result.
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+	resultHit     = "hit"
+	resultMiss    = "miss"
+)
+
+var (
+	loginsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_logins_total",
+		Help: "Password logins by result (success, failure).",
+	}, []string{"result"})
+
+	refreshesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_token_refreshes_total",
+		Help: "Refresh token exchanges by result (success, failure).",
+	}, []string{"result"})
+
+	sessionCacheLookupsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_session_cache_lookups_total",
+		Help: "Session lookups in ValidateSession by cache result (hit, miss).",
+	}, []string{"result"})
+
+	rolesCacheLookupsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_roles_cache_lookups_total",
+		Help: "Role lookups in IntrospectToken by cache result (hit, miss).",
+	}, []string{"result"})
+)
+
+func init() {
+	// Серии с нулями сразу, чтобы rate() и отношения считались с первого скрейпа
+	for _, result := range []string{resultSuccess, resultFailure} {
+		loginsTotal.WithLabelValues(result)
+		refreshesTotal.WithLabelValues(result)
+	}
+	for _, result := range []string{resultHit, resultMiss} {
+		sessionCacheLookupsTotal.WithLabelValues(result)
+		rolesCacheLookupsTotal.WithLabelValues(result)
+	}
+}
+
+func countResult(c *prometheus.CounterVec, err error) {
+	if err != nil {
+		c.WithLabelValues(resultFailure).Inc()
+		return
+	}
+	c.WithLabelValues(resultSuccess).Inc()
+}
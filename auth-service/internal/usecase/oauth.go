@@ -0,0 +1,465 @@
+package usecase
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	tokenjwt "auth/pkg/token"
+	"auth/provider"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	authCodeTTL = time.Minute
+
+	grantAuthorizationCode = "authorization_code"
+	grantRefreshToken      = "refresh_token"
+	grantClientCredentials = "client_credentials"
+
+	pkceMethodS256 = "S256"
+	scopeOpenID    = "openid"
+
+	tokenTypeAccess  = "access_token"
+	tokenTypeRefresh = "refresh_token"
+)
+
+// OAuthUseCase реализует OAuth2 / OpenID Connect поверх AuthUseCase:
+// сессии и токены выдаются тем же путём, что и в Login.
+type OAuthUseCase struct {
+	auth     *AuthUseCase
+	codes    repository.AuthCodeRepository
+	idTokens provider.IDTokenProvider
+
+	logger slog.Logger
+}
+
+// NewOAuthUseCase ...
+func NewOAuthUseCase(
+	auth *AuthUseCase,
+	codes repository.AuthCodeRepository,
+	idTokens provider.IDTokenProvider,
+	logger slog.Logger) *OAuthUseCase {
+	return &OAuthUseCase{
+		auth:     auth,
+		codes:    codes,
+		idTokens: idTokens,
+		logger:   logger,
+	}
+}
+
+// Authorize проверяет клиента и учётные данные пользователя и выдаёт authorization code.
+func (o *OAuthUseCase) Authorize(ctx context.Context, req domain.AuthorizeRequest) (code string, err error) {
+	const op = "OAuth.Authorize"
+
+	log := o.logger.With(
+		slog.String("op", op),
+		slog.String("clientID", req.ClientID),
+	)
+
+	log.InfoContext(ctx, "authorize request")
+
+	if req.ResponseType != "code" {
+		return "", fmt.Errorf("%s: %w", op, provider.ErrUnsupportedResponseType)
+	}
+	if req.CodeChallenge == "" || req.CodeChallengeMethod != pkceMethodS256 {
+		return "", fmt.Errorf("%s: %w: PKCE with S256 is required", op, provider.ErrInvalidRequest)
+	}
+
+	app, err := o.client(ctx, req.ClientID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	if !redirectAllowed(app, req.RedirectURI) {
+		return "", fmt.Errorf("%s: %w: redirect_uri is not registered", op, provider.ErrInvalidRequest)
+	}
+
+	// Пользователь входит на странице авторизации: токены первого лица сюда не передаются
+	user, err := o.auth.authenticate(ctx, log, req.Email, req.Password, app.ID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	code, err = o.auth.token.CreateRefreshToken()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = o.codes.SaveCode(ctx, domain.AuthCode{
+		CodeHash:            hashCode(code),
+		AppID:               app.ID,
+		UserID:              user.ID,
+		RedirectURI:         req.RedirectURI,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Scope:               req.Scope,
+		Nonce:               req.Nonce,
+		ExpiresAt:           time.Now().Add(authCodeTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return code, nil
+}
+
+// Token ...
+func (o *OAuthUseCase) Token(ctx context.Context, req domain.TokenRequest) (token domain.OAuthToken, err error) {
+	const op = "OAuth.Token"
+
+	log := o.logger.With(
+		slog.String("op", op),
+		slog.String("clientID", req.ClientID),
+		slog.String("grantType", req.GrantType),
+	)
+
+	log.InfoContext(ctx, "token request")
+
+	app, err := o.client(ctx, req.ClientID)
+	if err != nil {
+		return domain.OAuthToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := authenticateClient(app, req.ClientSecret); err != nil {
+		return domain.OAuthToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch req.GrantType {
+	case grantAuthorizationCode:
+		token, err = o.exchangeCode(ctx, app, req)
+	case grantRefreshToken:
+		token, err = o.refresh(ctx, app, req)
+	case grantClientCredentials:
+		token, err = o.clientCredentials(app, req)
+	default:
+		err = provider.ErrUnsupportedGrantType
+	}
+	if err != nil {
+		return domain.OAuthToken{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return token, nil
+}
+
+// Revoke отзывает токен по запросу клиента, которому он выдан (RFC 7009 §2.1).
+// Токен другого клиента не отзывается: это ErrUnauthorizedClient.
+func (o *OAuthUseCase) Revoke(ctx context.Context, req domain.RevokeRequest) error {
+	const op = "OAuth.Revoke"
+
+	log := o.logger.With(
+		slog.String("op", op),
+		slog.String("clientID", req.ClientID),
+	)
+
+	log.InfoContext(ctx, "revoke token")
+
+	app, err := o.client(ctx, req.ClientID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := authenticateClient(app, req.ClientSecret); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.TokenTypeHint != tokenTypeRefresh {
+		if claims, err := o.auth.token.ParseAccessToken(req.Token); err == nil {
+			if claims.AppID != app.ID {
+				return fmt.Errorf("%s: %w", op, provider.ErrUnauthorizedClient)
+			}
+			if claims.SessionID == emptyID {
+				// client_credentials токены живут до exp, отзывать нечего
+				return nil
+			}
+			revoked, err := o.auth.sessions.RevokeByID(ctx, claims.SessionID)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			if err := o.auth.cache.DelSession(ctx, claims.SessionID); err != nil {
+				log.WarnContext(ctx, "session not deleted from cache")
+			}
+			if revoked {
+				o.auth.record(ctx, domain.AuditEvent{
+					Type:      domain.AuditSessionRevoked,
+					UserID:    claims.UserID,
+					AppID:     claims.AppID,
+					SessionID: claims.SessionID,
+				})
+			}
+			return nil
+		}
+	}
+
+	session, err := o.auth.sessions.SessionByRefreshToken(ctx, req.Token)
+	if err != nil {
+		// RFC 7009: неизвестный токен - не ошибка
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if session.AppID != app.ID {
+		return fmt.Errorf("%s: %w", op, provider.ErrUnauthorizedClient)
+	}
+
+	if _, err := o.auth.Logout(ctx, req.Token); err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Introspect возвращает состояние токена. Спрашивать может только конфиденциальный
+// клиент (ресурсный сервер) со своим client_secret (RFC 7662 §2.1).
+func (o *OAuthUseCase) Introspect(ctx context.Context, req domain.IntrospectRequest) (domain.Introspection, error) {
+	const op = "OAuth.Introspect"
+
+	log := o.logger.With(
+		slog.String("op", op),
+		slog.String("clientID", req.ClientID),
+	)
+
+	log.InfoContext(ctx, "introspect token")
+
+	app, err := o.client(ctx, req.ClientID)
+	if err != nil {
+		return domain.Introspection{}, fmt.Errorf("%s: %w", op, err)
+	}
+	// Публичный клиент не может доказать, что он - это он
+	if len(app.ClientSecretHash) == 0 {
+		return domain.Introspection{}, fmt.Errorf("%s: %w", op, provider.ErrInvalidClient)
+	}
+	if err := authenticateClient(app, req.ClientSecret); err != nil {
+		return domain.Introspection{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if claims, err := o.auth.token.ParseAccessToken(req.Token); err == nil {
+		info := domain.Introspection{
+			Subject:   claims.Subject,
+			TokenType: tokenTypeAccess,
+			UserID:    claims.UserID,
+			SessionID: claims.SessionID,
+			AppID:     claims.AppID,
+		}
+		if len(claims.Audience) > 0 {
+			info.ClientID = claims.Audience[0]
+		}
+		if claims.ExpiresAt != nil {
+			info.ExpiresAt = claims.ExpiresAt.Time
+		}
+		if claims.UserID != emptyID {
+			info.Subject = strconv.Itoa(claims.UserID)
+		}
+
+		if claims.SessionID == emptyID {
+			info.Active = true
+			return info, nil
+		}
+
+		active, err := o.auth.ValidateSession(ctx, claims.SessionID)
+		if err != nil && !errors.Is(err, repository.ErrSessionNotFound) {
+			return domain.Introspection{}, fmt.Errorf("%s: %w", op, err)
+		}
+		info.Active = active
+
+		return info, nil
+	}
+
+	session, err := o.auth.sessions.SessionByRefreshToken(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return domain.Introspection{Active: false}, nil
+		}
+		return domain.Introspection{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return domain.Introspection{
+		Active:    isSessionActive(session),
+		Subject:   strconv.Itoa(session.UserID),
+		Scope:     session.Scope,
+		TokenType: tokenTypeRefresh,
+		ExpiresAt: session.RefreshExpiresAt,
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		AppID:     session.AppID,
+	}, nil
+}
+
+// JWKS ...
+func (o *OAuthUseCase) JWKS() []tokenjwt.JWK {
+	return o.idTokens.JWKS()
+}
+
+func (o *OAuthUseCase) exchangeCode(ctx context.Context, app domain.App, req domain.TokenRequest) (domain.OAuthToken, error) {
+	code, err := o.codes.ConsumeCode(ctx, hashCode(req.Code))
+	if err != nil {
+		if errors.Is(err, repository.ErrAuthCodeNotFound) {
+			return domain.OAuthToken{}, provider.ErrInvalidGrant
+		}
+		return domain.OAuthToken{}, err
+	}
+
+	if code.AppID != app.ID || code.RedirectURI != req.RedirectURI || time.Now().After(code.ExpiresAt) {
+		return domain.OAuthToken{}, provider.ErrInvalidGrant
+	}
+	if !verifyPKCE(code.CodeChallenge, req.CodeVerifier) {
+		return domain.OAuthToken{}, provider.ErrInvalidGrant
+	}
+
+	token, err := o.auth.issueTokens(ctx, code.UserID, app, code.Scope, map[string]string{"method": "oauth_code"})
+	if err != nil {
+		return domain.OAuthToken{}, err
+	}
+
+	res := domain.OAuthToken{
+		AccessToken:    token.AccessToken,
+		AccessExpireAt: token.AccessExpireAt,
+		RefreshToken:   token.RefreshToken,
+		Scope:          code.Scope,
+	}
+
+	if hasScope(code.Scope, scopeOpenID) {
+		res.IDToken, err = o.idTokens.CreateIDToken(strconv.Itoa(code.UserID), app.Name, code.Nonce, token.AccessExpireAt)
+		if err != nil {
+			return domain.OAuthToken{}, err
+		}
+	}
+
+	return res, nil
+}
+
+func (o *OAuthUseCase) refresh(ctx context.Context, app domain.App, req domain.TokenRequest) (domain.OAuthToken, error) {
+	session, err := o.auth.sessions.SessionByRefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrSessionNotFound) {
+			return domain.OAuthToken{}, provider.ErrInvalidGrant
+		}
+		return domain.OAuthToken{}, err
+	}
+	if session.AppID != app.ID {
+		return domain.OAuthToken{}, provider.ErrInvalidGrant
+	}
+
+	// Сузить scope при refresh нечем: сессия ротируется с исходным scope,
+	// поэтому принимаем только его же (RFC 6749 §6 - отсутствие scope то же самое)
+	if req.Scope != "" && !scopeEqual(req.Scope, session.Scope) {
+		return domain.OAuthToken{}, provider.ErrInvalidScope
+	}
+
+	token, err := o.auth.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, provider.ErrInvalidRefreshToken) {
+			return domain.OAuthToken{}, provider.ErrInvalidGrant
+		}
+		return domain.OAuthToken{}, err
+	}
+
+	return domain.OAuthToken{
+		AccessToken:    token.AccessToken,
+		AccessExpireAt: token.AccessExpireAt,
+		RefreshToken:   token.RefreshToken,
+		Scope:          session.Scope,
+	}, nil
+}
+
+func (o *OAuthUseCase) clientCredentials(app domain.App, req domain.TokenRequest) (domain.OAuthToken, error) {
+	// Публичный клиент не может доказать, что он - это он
+	if len(app.ClientSecretHash) == 0 {
+		return domain.OAuthToken{}, provider.ErrUnauthorizedClient
+	}
+	if !scopeSubset(req.Scope, strings.Join(app.Scopes, " ")) {
+		return domain.OAuthToken{}, provider.ErrInvalidScope
+	}
+
+	accessTTL, _ := o.auth.tokenTTLs(app)
+	accExp := time.Now().Add(accessTTL)
+
+	accessToken, err := o.auth.token.CreateClientAccessToken(app.ID, app.Name, accExp)
+	if err != nil {
+		return domain.OAuthToken{}, err
+	}
+
+	return domain.OAuthToken{
+		AccessToken:    accessToken,
+		AccessExpireAt: accExp,
+		Scope:          req.Scope,
+	}, nil
+}
+
+// client находит включённое приложение по client_id.
+func (o *OAuthUseCase) client(ctx context.Context, clientID string) (domain.App, error) {
+	app, err := o.auth.apps.AppByName(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, repository.ErrAppNotFound) {
+			return domain.App{}, provider.ErrInvalidClient
+		}
+		return domain.App{}, err
+	}
+	if !app.Enabled {
+		return domain.App{}, provider.ErrInvalidClient
+	}
+
+	return app, nil
+}
+
+// authenticateClient проверяет client_secret у конфиденциальных клиентов.
+func authenticateClient(app domain.App, secret string) error {
+	if len(app.ClientSecretHash) == 0 {
+		return nil
+	}
+	if err := bcrypt.CompareHashAndPassword(app.ClientSecretHash, []byte(secret)); err != nil {
+		return provider.ErrInvalidClient
+	}
+
+	return nil
+}
+
+// redirectAllowed требует точного совпадения с зарегистрированным URI:
+// другой путь или лишние параметры на том же origin не проходят (RFC 6749 §3.1.2).
+func redirectAllowed(app domain.App, redirectURI string) bool {
+	return redirectURI != "" && slices.Contains(app.RedirectURIs, redirectURI)
+}
+
+func verifyPKCE(challenge string, verifier string) bool {
+	if verifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+func hasScope(scope string, want string) bool {
+	return slices.Contains(strings.Fields(scope), want)
+}
+
+// scopeSubset - каждое значение из scope есть в granted.
+func scopeSubset(scope string, granted string) bool {
+	have := strings.Fields(granted)
+	for _, s := range strings.Fields(scope) {
+		if !slices.Contains(have, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// scopeEqual - scope совпадают как множества, порядок значений не важен.
+func scopeEqual(a string, b string) bool {
+	return scopeSubset(a, b) && scopeSubset(b, a)
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
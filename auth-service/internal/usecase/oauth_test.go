@@ -0,0 +1,590 @@
+package usecase_test
+
+import (
+	"auth/internal/config"
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"auth/internal/usecase"
+	providerMocks "auth/mocks/provider"
+	repoMocks "auth/mocks/repository"
+	tokenjwt "auth/pkg/token"
+	"auth/provider"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type oauthMocks struct {
+	userRepo  *repoMocks.UserRepository
+	sessRepo  *repoMocks.SessionRepository
+	appRepo   *repoMocks.AppRepository
+	cacheRepo *repoMocks.Cache
+	codeRepo  *repoMocks.AuthCodeRepository
+	tokenProv *providerMocks.TokenProvider
+	idTokens  *providerMocks.IDTokenProvider
+}
+
+func newOAuthUseCase() (*usecase.OAuthUseCase, oauthMocks) {
+	m := oauthMocks{
+		userRepo:  new(repoMocks.UserRepository),
+		sessRepo:  new(repoMocks.SessionRepository),
+		appRepo:   new(repoMocks.AppRepository),
+		cacheRepo: new(repoMocks.Cache),
+		codeRepo:  new(repoMocks.AuthCodeRepository),
+		tokenProv: new(providerMocks.TokenProvider),
+		idTokens:  new(providerMocks.IDTokenProvider),
+	}
+
+	logger := config.NewLogger(&cfg)
+
+	auth := usecase.NewAuthUseCase(
+		m.userRepo,
+		m.sessRepo,
+		m.appRepo,
+		m.cacheRepo,
+		m.tokenProv,
+		nil,
+		testHasher,
+		nil,
+		*logger,
+		cfg.AccessTokenTTL,
+		cfg.RefreshTokenTTL,
+	)
+
+	return usecase.NewOAuthUseCase(auth, m.codeRepo, m.idTokens, *logger), m
+}
+
+var (
+	oauthApp = domain.App{
+		ID:           5,
+		Name:         "web",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Enabled:      true,
+	}
+	codeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func hashOf(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return fmtHex(sum[:])
+}
+
+func fmtHex(b []byte) string {
+	const digits = "0123456789abcdef"
+	out := make([]byte, len(b)*2)
+	for i, c := range b {
+		out[i*2] = digits[c>>4]
+		out[i*2+1] = digits[c&0x0f]
+	}
+	return string(out)
+}
+
+func TestOAuthUseCase_Authorize_Success(t *testing.T) {
+	uc, m := newOAuthUseCase()
+	ctx := context.Background()
+
+	req := domain.AuthorizeRequest{
+		Email:               "user@example.com",
+		Password:            "password",
+		ClientID:            oauthApp.Name,
+		RedirectURI:         "https://app.example.com/callback",
+		ResponseType:        "code",
+		Scope:               "openid",
+		CodeChallenge:       codeChallenge(codeVerifier),
+		CodeChallengeMethod: "S256",
+		Nonce:               "n-0S6_WzA2Mj",
+	}
+
+	m.appRepo.
+		On("AppByName", ctx, oauthApp.Name).
+		Return(oauthApp, nil)
+
+	hashPass, _ := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	m.userRepo.
+		On("UserByEmail", ctx, req.Email).
+		Return(domain.User{ID: 42, Email: req.Email, PassHash: hashPass}, nil)
+
+	m.tokenProv.
+		On("CreateRefreshToken").
+		Return("CODE", nil)
+
+	m.codeRepo.
+		On("SaveCode", ctx, mock.MatchedBy(func(c domain.AuthCode) bool {
+			return c.CodeHash == hashOf("CODE") &&
+				c.UserID == 42 &&
+				c.AppID == oauthApp.ID &&
+				c.Nonce == req.Nonce
+		})).
+		Return(nil)
+
+	code, err := uc.Authorize(ctx, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, "CODE", code)
+
+	m.appRepo.AssertExpectations(t)
+	m.userRepo.AssertExpectations(t)
+	m.codeRepo.AssertExpectations(t)
+	m.tokenProv.AssertExpectations(t)
+}
+
+func TestOAuthUseCase_Authorize_WrongPassword(t *testing.T) {
+	uc, m := newOAuthUseCase()
+	ctx := context.Background()
+
+	m.appRepo.
+		On("AppByName", ctx, oauthApp.Name).
+		Return(oauthApp, nil)
+
+	hashPass, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	m.userRepo.
+		On("UserByEmail", ctx, "user@example.com").
+		Return(domain.User{ID: 42, Email: "user@example.com", PassHash: hashPass}, nil)
+
+	_, err := uc.Authorize(ctx, domain.AuthorizeRequest{
+		Email:               "user@example.com",
+		Password:            "wrong",
+		ClientID:            oauthApp.Name,
+		RedirectURI:         "https://app.example.com/callback",
+		ResponseType:        "code",
+		CodeChallenge:       codeChallenge(codeVerifier),
+		CodeChallengeMethod: "S256",
+	})
+
+	require.ErrorIs(t, err, repository.ErrInvalidCredentials)
+	m.codeRepo.AssertNotCalled(t, "SaveCode", mock.Anything, mock.Anything)
+}
+
+func TestOAuthUseCase_Authorize_RedirectNotRegistered(t *testing.T) {
+	tests := []struct {
+		name        string
+		redirectURI string
+	}{
+		{name: "another origin", redirectURI: "https://evil.example.com/callback"},
+		{name: "another path", redirectURI: "https://app.example.com/other"},
+		{name: "extra query", redirectURI: "https://app.example.com/callback?next=https://evil.example.com"},
+		{name: "trailing slash", redirectURI: "https://app.example.com/callback/"},
+		{name: "empty", redirectURI: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := newOAuthUseCase()
+			ctx := context.Background()
+
+			m.appRepo.
+				On("AppByName", ctx, oauthApp.Name).
+				Return(oauthApp, nil)
+
+			_, err := uc.Authorize(ctx, domain.AuthorizeRequest{
+				Email:               "user@example.com",
+				Password:            "password",
+				ClientID:            oauthApp.Name,
+				RedirectURI:         tt.redirectURI,
+				ResponseType:        "code",
+				CodeChallenge:       codeChallenge(codeVerifier),
+				CodeChallengeMethod: "S256",
+			})
+
+			require.ErrorIs(t, err, provider.ErrInvalidRequest)
+			m.userRepo.AssertNotCalled(t, "UserByEmail", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOAuthUseCase_Authorize_PlainPKCERejected(t *testing.T) {
+	uc, _ := newOAuthUseCase()
+
+	_, err := uc.Authorize(context.Background(), domain.AuthorizeRequest{
+		ClientID:            oauthApp.Name,
+		ResponseType:        "code",
+		CodeChallenge:       codeVerifier,
+		CodeChallengeMethod: "plain",
+	})
+
+	require.ErrorIs(t, err, provider.ErrInvalidRequest)
+}
+
+func TestOAuthUseCase_Token_AuthorizationCodeSuccess(t *testing.T) {
+	uc, m := newOAuthUseCase()
+	ctx := context.Background()
+
+	redirectURI := "https://app.example.com/callback"
+
+	m.appRepo.
+		On("AppByName", ctx, oauthApp.Name).
+		Return(oauthApp, nil)
+
+	m.codeRepo.
+		On("ConsumeCode", ctx, hashOf("CODE")).
+		Return(domain.AuthCode{
+			AppID:         oauthApp.ID,
+			UserID:        42,
+			RedirectURI:   redirectURI,
+			CodeChallenge: codeChallenge(codeVerifier),
+			Scope:         "openid",
+			Nonce:         "nonce",
+			ExpiresAt:     time.Now().Add(time.Minute),
+		}, nil)
+
+	m.tokenProv.
+		On("CreateRefreshToken").
+		Return("REFRESH", nil)
+
+	m.sessRepo.
+		On("CreateSession", ctx, 42, oauthApp.ID, "REFRESH", mock.AnythingOfType("time.Time"), "openid").
+		Return(100, nil)
+
+	m.tokenProv.
+		On("CreateAccessToken", 42, 100, oauthApp.ID, oauthApp.Name, mock.AnythingOfType("time.Time")).
+		Return("ACCESS", nil)
+
+	m.idTokens.
+		On("CreateIDToken", "42", oauthApp.Name, "nonce", mock.AnythingOfType("time.Time")).
+		Return("ID_TOKEN", nil)
+
+	tok, err := uc.Token(ctx, domain.TokenRequest{
+		GrantType:    "authorization_code",
+		ClientID:     oauthApp.Name,
+		Code:         "CODE",
+		RedirectURI:  redirectURI,
+		CodeVerifier: codeVerifier,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ACCESS", tok.AccessToken)
+	assert.Equal(t, "REFRESH", tok.RefreshToken)
+	assert.Equal(t, "ID_TOKEN", tok.IDToken)
+	assert.Equal(t, "openid", tok.Scope)
+
+	m.codeRepo.AssertExpectations(t)
+	m.sessRepo.AssertExpectations(t)
+	m.tokenProv.AssertExpectations(t)
+	m.idTokens.AssertExpectations(t)
+}
+
+func TestOAuthUseCase_Token_WrongCodeVerifier(t *testing.T) {
+	uc, m := newOAuthUseCase()
+	ctx := context.Background()
+
+	redirectURI := "https://app.example.com/callback"
+
+	m.appRepo.
+		On("AppByName", ctx, oauthApp.Name).
+		Return(oauthApp, nil)
+
+	m.codeRepo.
+		On("ConsumeCode", ctx, hashOf("CODE")).
+		Return(domain.AuthCode{
+			AppID:         oauthApp.ID,
+			UserID:        42,
+			RedirectURI:   redirectURI,
+			CodeChallenge: codeChallenge(codeVerifier),
+			ExpiresAt:     time.Now().Add(time.Minute),
+		}, nil)
+
+	_, err := uc.Token(ctx, domain.TokenRequest{
+		GrantType:    "authorization_code",
+		ClientID:     oauthApp.Name,
+		Code:         "CODE",
+		RedirectURI:  redirectURI,
+		CodeVerifier: "wrong-verifier",
+	})
+
+	require.ErrorIs(t, err, provider.ErrInvalidGrant)
+	m.sessRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOAuthUseCase_Token_ClientCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		wantErr error
+	}{
+		{name: "no scope", scope: ""},
+		{name: "allowed scope", scope: "users:read"},
+		{name: "unknown scope", scope: "users:read admin", wantErr: provider.ErrInvalidScope},
+		{name: "openid", scope: "openid", wantErr: provider.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := newOAuthUseCase()
+			ctx := context.Background()
+
+			secretHash, _ := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+			app := domain.App{ID: 9, Name: "billing", Scopes: []string{"users:read"}, Enabled: true, ClientSecretHash: secretHash}
+
+			m.appRepo.
+				On("AppByName", ctx, app.Name).
+				Return(app, nil)
+
+			m.tokenProv.
+				On("CreateClientAccessToken", app.ID, app.Name, mock.AnythingOfType("time.Time")).
+				Return("CLIENT_ACCESS", nil).Maybe()
+
+			tok, err := uc.Token(ctx, domain.TokenRequest{
+				GrantType:    "client_credentials",
+				ClientID:     app.Name,
+				ClientSecret: "s3cret",
+				Scope:        tt.scope,
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				m.tokenProv.AssertNotCalled(t, "CreateClientAccessToken", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "CLIENT_ACCESS", tok.AccessToken)
+			assert.Equal(t, tt.scope, tok.Scope)
+			assert.Empty(t, tok.RefreshToken)
+
+			m.tokenProv.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOAuthUseCase_Token_ClientCredentialsWrongSecret(t *testing.T) {
+	uc, m := newOAuthUseCase()
+	ctx := context.Background()
+
+	secretHash, _ := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	app := domain.App{ID: 9, Name: "billing", Enabled: true, ClientSecretHash: secretHash}
+
+	m.appRepo.
+		On("AppByName", ctx, app.Name).
+		Return(app, nil)
+
+	_, err := uc.Token(ctx, domain.TokenRequest{
+		GrantType:    "client_credentials",
+		ClientID:     app.Name,
+		ClientSecret: "wrong",
+	})
+
+	require.ErrorIs(t, err, provider.ErrInvalidClient)
+}
+
+func TestOAuthUseCase_Token_ClientCredentialsPublicClient(t *testing.T) {
+	uc, m := newOAuthUseCase()
+	ctx := context.Background()
+
+	m.appRepo.
+		On("AppByName", ctx, oauthApp.Name).
+		Return(oauthApp, nil)
+
+	_, err := uc.Token(ctx, domain.TokenRequest{
+		GrantType: "client_credentials",
+		ClientID:  oauthApp.Name,
+	})
+
+	require.ErrorIs(t, err, provider.ErrUnauthorizedClient)
+}
+
+func TestOAuthUseCase_Token_RefreshScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		scope     string
+		wantScope string
+		wantErr   error
+	}{
+		{name: "original scope", scope: "", wantScope: "openid profile"},
+		{name: "same scope", scope: "profile openid", wantScope: "openid profile"},
+		// Сессия ротируется с исходным scope - сужение не сработало бы
+		{name: "narrowed", scope: "openid", wantErr: provider.ErrInvalidScope},
+		{name: "widened", scope: "openid admin", wantErr: provider.ErrInvalidScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := newOAuthUseCase()
+			ctx := context.Background()
+
+			session := domain.Session{
+				ID:               100,
+				UserID:           42,
+				AppID:            oauthApp.ID,
+				Status:           "active",
+				Scope:            "openid profile",
+				RefreshExpiresAt: time.Now().Add(time.Hour),
+			}
+
+			m.appRepo.
+				On("AppByName", ctx, oauthApp.Name).
+				Return(oauthApp, nil)
+			m.appRepo.
+				On("AppByID", ctx, oauthApp.ID).
+				Return(oauthApp, nil).Maybe()
+
+			m.sessRepo.
+				On("SessionByRefreshToken", ctx, "REFRESH").
+				Return(session, nil)
+			m.sessRepo.
+				On("RevokeByRefreshToken", ctx, "REFRESH").
+				Return(true, nil).Maybe()
+			m.sessRepo.
+				On("CreateSession", ctx, 42, oauthApp.ID, "NEW_REFRESH", mock.AnythingOfType("time.Time"), session.Scope).
+				Return(101, nil).Maybe()
+
+			m.cacheRepo.
+				On("DelSession", ctx, 100).
+				Return(nil).Maybe()
+
+			m.tokenProv.
+				On("CreateRefreshToken").
+				Return("NEW_REFRESH", nil).Maybe()
+			m.tokenProv.
+				On("CreateAccessToken", 42, 101, oauthApp.ID, oauthApp.Name, mock.AnythingOfType("time.Time")).
+				Return("ACCESS", nil).Maybe()
+
+			tok, err := uc.Token(ctx, domain.TokenRequest{
+				GrantType:    "refresh_token",
+				ClientID:     oauthApp.Name,
+				RefreshToken: "REFRESH",
+				Scope:        tt.scope,
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				// Отказ не должен сжигать refresh токен
+				m.sessRepo.AssertNotCalled(t, "RevokeByRefreshToken", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "ACCESS", tok.AccessToken)
+			assert.Equal(t, tt.wantScope, tok.Scope)
+			m.sessRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOAuthUseCase_Revoke(t *testing.T) {
+	secretHash, _ := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	app := domain.App{ID: 9, Name: "billing", Enabled: true, ClientSecretHash: secretHash}
+
+	tests := []struct {
+		name        string
+		secret      string
+		session     domain.Session
+		wantErr     error
+		wantRevoked bool
+	}{
+		{
+			name:        "own token",
+			secret:      "s3cret",
+			session:     domain.Session{ID: 100, UserID: 42, AppID: app.ID, Status: "active"},
+			wantRevoked: true,
+		},
+		{
+			name:    "wrong secret",
+			secret:  "wrong",
+			wantErr: provider.ErrInvalidClient,
+		},
+		{
+			name:    "token of another client",
+			secret:  "s3cret",
+			session: domain.Session{ID: 100, UserID: 42, AppID: oauthApp.ID, Status: "active"},
+			wantErr: provider.ErrUnauthorizedClient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := newOAuthUseCase()
+			ctx := context.Background()
+
+			m.appRepo.
+				On("AppByName", ctx, app.Name).
+				Return(app, nil)
+
+			m.sessRepo.
+				On("SessionByRefreshToken", ctx, "REFRESH").
+				Return(tt.session, nil).Maybe()
+			m.sessRepo.
+				On("RevokeByRefreshToken", ctx, "REFRESH").
+				Return(true, nil).Maybe()
+			m.cacheRepo.
+				On("DelSession", ctx, tt.session.ID).
+				Return(nil).Maybe()
+
+			err := uc.Revoke(ctx, domain.RevokeRequest{
+				Token:         "REFRESH",
+				TokenTypeHint: "refresh_token",
+				ClientID:      app.Name,
+				ClientSecret:  tt.secret,
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantRevoked {
+				m.sessRepo.AssertCalled(t, "RevokeByRefreshToken", ctx, "REFRESH")
+			} else {
+				m.sessRepo.AssertNotCalled(t, "RevokeByRefreshToken", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestOAuthUseCase_Introspect_ClientAuthentication(t *testing.T) {
+	secretHash, _ := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	resourceServer := domain.App{ID: 9, Name: "billing", Enabled: true, ClientSecretHash: secretHash}
+
+	tests := []struct {
+		name    string
+		app     domain.App
+		secret  string
+		wantErr error
+	}{
+		{name: "confidential client", app: resourceServer, secret: "s3cret"},
+		{name: "wrong secret", app: resourceServer, secret: "wrong", wantErr: provider.ErrInvalidClient},
+		{name: "public client", app: oauthApp, wantErr: provider.ErrInvalidClient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, m := newOAuthUseCase()
+			ctx := context.Background()
+
+			m.appRepo.
+				On("AppByName", ctx, tt.app.Name).
+				Return(tt.app, nil)
+
+			m.tokenProv.
+				On("ParseAccessToken", "CLIENT_ACCESS").
+				Return(tokenjwt.AccessClaims{AppID: 7}, nil).Maybe()
+
+			info, err := uc.Introspect(ctx, domain.IntrospectRequest{
+				Token:        "CLIENT_ACCESS",
+				ClientID:     tt.app.Name,
+				ClientSecret: tt.secret,
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				m.tokenProv.AssertNotCalled(t, "ParseAccessToken", mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, info.Active)
+			assert.Equal(t, 7, info.AppID)
+		})
+	}
+}
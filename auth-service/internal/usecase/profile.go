@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// maxBatchProfiles - сколько профилей отдаёт один BatchGetProfiles (как лимит списка чатов).
+const maxBatchProfiles = 100
+
+// Размер выдачи SearchUsers.
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+// ProfileUseCase - публичные профили пользователей: имя, @username, аватар.
+type ProfileUseCase struct {
+	profiles repository.ProfileRepository
+
+	logger slog.Logger
+}
+
+// NewProfileUseCase ...
+func NewProfileUseCase(profiles repository.ProfileRepository, logger slog.Logger) *ProfileUseCase {
+	return &ProfileUseCase{
+		profiles: profiles,
+		logger:   logger,
+	}
+}
+
+// GetProfile ...
+func (p *ProfileUseCase) GetProfile(ctx context.Context, userID int) (domain.Profile, error) {
+	const op = "Profile.GetProfile"
+
+	log := p.logger.With(
+		slog.String("op", op),
+		slog.Int("userID", userID),
+	)
+
+	log.InfoContext(ctx, "get profile")
+
+	profile, err := p.profile(ctx, userID)
+	if err != nil {
+		return domain.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profile, nil
+}
+
+// BatchGetProfiles - профили для списка чатов и сообщений за один запрос.
+// Несуществующие пользователи пропускаются, повторы в ids не дублируются.
+func (p *ProfileUseCase) BatchGetProfiles(ctx context.Context, userIDs []int) ([]domain.Profile, error) {
+	const op = "Profile.BatchGetProfiles"
+
+	log := p.logger.With(
+		slog.String("op", op),
+	)
+
+	log.InfoContext(ctx, "batch get profiles", slog.Int("count", len(userIDs)))
+
+	ids := make([]int, 0, len(userIDs))
+	seen := make(map[int]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := seen[id]; ok || id <= 0 {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > maxBatchProfiles {
+		ids = ids[:maxBatchProfiles]
+	}
+
+	profiles, err := p.profiles.ProfilesByUserIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profiles, nil
+}
+
+// GetProfileByUsername - профиль по @username, без учёта регистра и ведущего @.
+func (p *ProfileUseCase) GetProfileByUsername(ctx context.Context, username string) (domain.Profile, error) {
+	const op = "Profile.GetProfileByUsername"
+
+	log := p.logger.With(
+		slog.String("op", op),
+		slog.String("username", username),
+	)
+
+	log.InfoContext(ctx, "get profile by username")
+
+	profile, err := p.profiles.ProfileByUsername(ctx, normalizeUsername(username))
+	if err != nil {
+		return domain.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profile, nil
+}
+
+// SearchUsers ищет пользователей по email, username и имени: сначала по началу строки,
+// затем похожие (pg_trgm). Пустой запрос ничего не находит.
+func (p *ProfileUseCase) SearchUsers(ctx context.Context, query string, limit int) ([]domain.Profile, error) {
+	const op = "Profile.SearchUsers"
+
+	log := p.logger.With(
+		slog.String("op", op),
+	)
+
+	log.InfoContext(ctx, "search users", slog.String("query", query))
+
+	query = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query), "@"))
+	if query == "" {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	profiles, err := p.profiles.SearchProfiles(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profiles, nil
+}
+
+// UpdateProfile меняет заданные поля профиля пользователя и возвращает профиль целиком.
+// username хранится в нижнем регистре: @Alice и @alice - один пользователь.
+func (p *ProfileUseCase) UpdateProfile(ctx context.Context, userID int, update domain.ProfileUpdate) (domain.Profile, error) {
+	const op = "Profile.UpdateProfile"
+
+	log := p.logger.With(
+		slog.String("op", op),
+		slog.Int("userID", userID),
+	)
+
+	log.InfoContext(ctx, "update profile")
+
+	profile, err := p.profile(ctx, userID)
+	if err != nil {
+		return domain.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if update.Username != nil {
+		profile.Username = normalizeUsername(*update.Username)
+	}
+	if update.DisplayName != nil {
+		profile.DisplayName = strings.TrimSpace(*update.DisplayName)
+	}
+	if update.AvatarURL != nil {
+		profile.AvatarURL = *update.AvatarURL
+	}
+	if update.Bio != nil {
+		profile.Bio = strings.TrimSpace(*update.Bio)
+	}
+
+	if err := p.profiles.SaveProfile(ctx, profile); err != nil {
+		return domain.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// updated_at ставит БД
+	profile, err = p.profile(ctx, userID)
+	if err != nil {
+		return domain.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return profile, nil
+}
+
+func (p *ProfileUseCase) profile(ctx context.Context, userID int) (domain.Profile, error) {
+	profiles, err := p.profiles.ProfilesByUserIDs(ctx, []int{userID})
+	if err != nil {
+		return domain.Profile{}, err
+	}
+	if len(profiles) == 0 {
+		return domain.Profile{}, repository.ErrUserNotFound
+	}
+
+	return profiles[0], nil
+}
+
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimPrefix(username, "@"))
+}
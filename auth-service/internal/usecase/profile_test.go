@@ -0,0 +1,144 @@
+package usecase_test
+
+import (
+	"auth/internal/config"
+	"auth/internal/domain"
+	"auth/internal/repository"
+	"auth/internal/usecase"
+	repoMocks "auth/mocks/repository"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileUseCase_GetProfile(t *testing.T) {
+	profiles := new(repoMocks.ProfileRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewProfileUseCase(profiles, *logger)
+
+	ctx := context.Background()
+	profiles.On("ProfilesByUserIDs", ctx, []int{42}).Return([]domain.Profile{{UserID: 42, DisplayName: "Alice"}}, nil)
+	profiles.On("ProfilesByUserIDs", ctx, []int{43}).Return([]domain.Profile{}, nil)
+
+	p, err := uc.GetProfile(ctx, 42)
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", p.DisplayName)
+
+	_, err = uc.GetProfile(ctx, 43)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
+
+func TestProfileUseCase_BatchGetProfiles(t *testing.T) {
+	profiles := new(repoMocks.ProfileRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewProfileUseCase(profiles, *logger)
+
+	ctx := context.Background()
+	// Повторы и невалидные id в БД не уходят
+	profiles.
+		On("ProfilesByUserIDs", ctx, []int{7, 3}).
+		Return([]domain.Profile{{UserID: 3}, {UserID: 7}}, nil)
+
+	got, err := uc.BatchGetProfiles(ctx, []int{7, 3, 7, 0})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = uc.BatchGetProfiles(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	profiles.AssertNumberOfCalls(t, "ProfilesByUserIDs", 1)
+}
+
+func TestProfileUseCase_UpdateProfile(t *testing.T) {
+	profiles := new(repoMocks.ProfileRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewProfileUseCase(profiles, *logger)
+
+	ctx := context.Background()
+	now := time.Now()
+	username, displayName := "@Alice", "  Alice L. "
+
+	profiles.
+		On("ProfilesByUserIDs", ctx, []int{42}).
+		Return([]domain.Profile{{UserID: 42, Bio: "old bio", AvatarURL: "https://cdn.example.org/a.png"}}, nil).Once()
+	profiles.
+		On("SaveProfile", ctx, domain.Profile{
+			UserID:      42,
+			Username:    "alice",
+			DisplayName: "Alice L.",
+			AvatarURL:   "https://cdn.example.org/a.png",
+			Bio:         "old bio",
+		}).
+		Return(nil)
+	profiles.
+		On("ProfilesByUserIDs", ctx, []int{42}).
+		Return([]domain.Profile{{UserID: 42, Username: "alice", DisplayName: "Alice L.", UpdatedAt: now}}, nil).Once()
+
+	p, err := uc.UpdateProfile(ctx, 42, domain.ProfileUpdate{Username: &username, DisplayName: &displayName})
+
+	require.NoError(t, err)
+	assert.Equal(t, "alice", p.Username)
+	assert.Equal(t, now, p.UpdatedAt)
+	profiles.AssertExpectations(t)
+}
+
+func TestProfileUseCase_UpdateProfile_UsernameTaken(t *testing.T) {
+	profiles := new(repoMocks.ProfileRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewProfileUseCase(profiles, *logger)
+
+	ctx := context.Background()
+	username := "bob"
+
+	profiles.On("ProfilesByUserIDs", ctx, []int{42}).Return([]domain.Profile{{UserID: 42}}, nil)
+	profiles.
+		On("SaveProfile", ctx, mock.AnythingOfType("domain.Profile")).
+		Return(fmt.Errorf("ProfileRepository.SaveProfile: %w", repository.ErrUsernameTaken))
+
+	_, err := uc.UpdateProfile(ctx, 42, domain.ProfileUpdate{Username: &username})
+
+	assert.ErrorIs(t, err, repository.ErrUsernameTaken)
+}
+
+func TestProfileUseCase_GetProfileByUsername(t *testing.T) {
+	profiles := new(repoMocks.ProfileRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewProfileUseCase(profiles, *logger)
+
+	ctx := context.Background()
+	profiles.On("ProfileByUsername", ctx, "alice").Return(domain.Profile{UserID: 42, Username: "alice"}, nil)
+
+	p, err := uc.GetProfileByUsername(ctx, "@Alice")
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, p.UserID)
+}
+
+func TestProfileUseCase_SearchUsers(t *testing.T) {
+	profiles := new(repoMocks.ProfileRepository)
+	logger := config.NewLogger(&cfg)
+	uc := usecase.NewProfileUseCase(profiles, *logger)
+
+	ctx := context.Background()
+	profiles.On("SearchProfiles", ctx, "ali", 20).Return([]domain.Profile{{UserID: 1}}, nil).Once()
+	profiles.On("SearchProfiles", ctx, "bob", 50).Return([]domain.Profile{}, nil).Once()
+
+	got, err := uc.SearchUsers(ctx, "  @Ali ", 0)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	_, err = uc.SearchUsers(ctx, "bob", 1000)
+	require.NoError(t, err)
+
+	// Только "@" - искать нечего
+	got, err = uc.SearchUsers(ctx, "@", 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	profiles.AssertExpectations(t)
+}
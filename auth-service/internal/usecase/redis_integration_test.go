@@ -9,6 +9,7 @@ import (
 	"auth/internal/infrastructure/sqlstore"
 	"auth/internal/infrastructure/tokengen"
 	"auth/internal/usecase"
+	"auth/pkg/password"
 	"context"
 	"database/sql"
 	"strings"
@@ -41,8 +42,12 @@ func testRedisDBIntegration(t *testing.T) (*sql.DB, *usecase.AuthUseCase, func()
 	uc := usecase.NewAuthUseCase(
 		sqlstore.NewUserRepository(db),
 		sqlstore.NewSessionRepository(db),
+		sqlstore.NewAppRepository(db),
 		cache,
 		tokengen.NewTokenProvider([]byte(intCfg.JWTSecret)),
+		password.NewChecker(password.Policy{}, nil),
+		testHasher,
+		nil,
 		*logger,
 		intCfg.AccessTokenTTL,
 		intCfg.RefreshTokenTTL,
@@ -19,6 +19,7 @@ import (
 func TestAuthUseCase_ValidateSession_CacheHitActive(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -27,8 +28,12 @@ func TestAuthUseCase_ValidateSession_CacheHitActive(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -59,6 +64,7 @@ func TestAuthUseCase_ValidateSession_CacheHitActive(t *testing.T) {
 func TestAuthUseCase_ValidateSession_CacheHitInactive(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -67,8 +73,12 @@ func TestAuthUseCase_ValidateSession_CacheHitInactive(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -99,6 +109,7 @@ func TestAuthUseCase_ValidateSession_CacheHitInactive(t *testing.T) {
 func TestAuthUseCase_ValidateSession_CacheMissDBActive(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -107,8 +118,12 @@ func TestAuthUseCase_ValidateSession_CacheMissDBActive(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -150,6 +165,7 @@ func TestAuthUseCase_ValidateSession_CacheMissDBActive(t *testing.T) {
 func TestAuthUseCase_ValidateSession_CacheMissDBInactive(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -158,8 +174,12 @@ func TestAuthUseCase_ValidateSession_CacheMissDBInactive(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -201,6 +221,7 @@ func TestAuthUseCase_ValidateSession_CacheMissDBInactive(t *testing.T) {
 func TestAuthUseCase_ValidateSession_CacheErrorFallbackToDB(t *testing.T) {
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -209,8 +230,12 @@ func TestAuthUseCase_ValidateSession_CacheErrorFallbackToDB(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -257,6 +282,7 @@ func TestAuthUseCase_ValidateSession_DBError(t *testing.T) {
 
 	userRepo := new(repoMocks.UserRepository)
 	sessRepo := new(repoMocks.SessionRepository)
+	appRepo := new(repoMocks.AppRepository)
 	cacheRepo := new(repoMocks.Cache)
 	tokenProv := new(providerMocks.TokenProvider)
 
@@ -265,8 +291,12 @@ func TestAuthUseCase_ValidateSession_DBError(t *testing.T) {
 	uc := usecase.NewAuthUseCase(
 		userRepo,
 		sessRepo,
+		appRepo,
 		cacheRepo,
 		tokenProv,
+		nil,
+		testHasher,
+		nil,
 		*logger,
 		cfg.AccessTokenTTL,
 		cfg.RefreshTokenTTL,
@@ -0,0 +1,2 @@
+ALTER TABLE sessions DROP CONSTRAINT IF EXISTS fk_sessions_app;
+DROP TABLE IF EXISTS apps;
@@ -0,0 +1,18 @@
+CREATE TABLE apps (
+    id                    SERIAL      PRIMARY KEY,
+    name                  TEXT        NOT NULL UNIQUE, -- уходит в aud claim access-токена
+    redirect_origins      TEXT[]      NOT NULL DEFAULT '{}',
+    access_token_ttl_sec  INT,                         -- NULL = access_token_ttl из конфига
+    refresh_token_ttl_sec INT,                         -- NULL = refresh_token_ttl из конфига
+    enabled               BOOLEAN     NOT NULL DEFAULT TRUE,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- app_id = 1 gateway подставляет по умолчанию
+INSERT INTO apps (id, name) VALUES (1, 'messenger');
+SELECT setval('apps_id_seq', (SELECT MAX(id) FROM apps));
+
+-- NOT VALID: старые сессии могли ссылаться на произвольные app_id
+ALTER TABLE sessions
+    ADD CONSTRAINT fk_sessions_app FOREIGN KEY (app_id) REFERENCES apps(id) NOT VALID;
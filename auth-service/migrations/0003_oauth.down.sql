@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS oauth_codes;
+ALTER TABLE apps DROP COLUMN IF EXISTS client_secret_hash;
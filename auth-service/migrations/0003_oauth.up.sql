@@ -0,0 +1,18 @@
+-- NULL = публичный клиент (SPA, мобильное приложение), только PKCE
+ALTER TABLE apps ADD COLUMN client_secret_hash TEXT;
+
+CREATE TABLE oauth_codes (
+    code_hash             TEXT        PRIMARY KEY, -- sha256 от кода, сам код не храним
+    app_id                INT         NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    user_id               BIGINT      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    redirect_uri          TEXT        NOT NULL,
+    code_challenge        TEXT        NOT NULL,
+    code_challenge_method TEXT        NOT NULL,
+    scope                 TEXT        NOT NULL DEFAULT '',
+    nonce                 TEXT        NOT NULL DEFAULT '',
+    expires_at            TIMESTAMPTZ NOT NULL,
+    used_at               TIMESTAMPTZ,             -- код одноразовый
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_oauth_codes_expires_at ON oauth_codes (expires_at);
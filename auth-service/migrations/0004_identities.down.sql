@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS external_login_states;
+DROP TABLE IF EXISTS identities;
@@ -0,0 +1,26 @@
+-- Пользователи из внешних провайдеров создаются с пустым password_hash:
+-- bcrypt такой хеш не примет, вход по паролю для них невозможен.
+CREATE TABLE identities (
+    id         BIGSERIAL   PRIMARY KEY,
+    user_id    BIGINT      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider   TEXT        NOT NULL, -- имя провайдера из config.toml
+    subject    TEXT        NOT NULL, -- claim sub в ID токене провайдера
+    email      TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_identities_user_id ON identities (user_id);
+
+CREATE TABLE external_login_states (
+    state_hash    TEXT        PRIMARY KEY, -- sha256 от state, сам state не храним
+    provider      TEXT        NOT NULL,
+    app_id        INT         NOT NULL REFERENCES apps(id) ON DELETE CASCADE,
+    nonce         TEXT        NOT NULL,
+    code_verifier TEXT        NOT NULL,    -- PKCE verifier для обмена кода у провайдера
+    expires_at    TIMESTAMPTZ NOT NULL,
+    used_at       TIMESTAMPTZ,             -- state одноразовый
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_external_login_states_expires_at ON external_login_states (expires_at);
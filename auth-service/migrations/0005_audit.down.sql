@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS users_audit_role_changed ON users;
+DROP FUNCTION IF EXISTS audit_role_changed();
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
@@ -0,0 +1,48 @@
+-- Журнал событий безопасности. Только дописывается: UPDATE и DELETE запрещены триггером,
+-- у событий нет внешнего ключа на users, чтобы они пережили удаление пользователя.
+CREATE TABLE audit_events (
+    id         BIGSERIAL   PRIMARY KEY,
+    type       TEXT        NOT NULL,             -- login.succeeded, session.revoked, ...
+    user_id    BIGINT,                           -- кого касается событие, NULL - не известен
+    actor_id   BIGINT,                           -- кто сделал, если не сам user_id (админ)
+    app_id     INT,
+    session_id BIGINT,
+    ip         TEXT        NOT NULL DEFAULT '',
+    user_agent TEXT        NOT NULL DEFAULT '',
+    request_id TEXT        NOT NULL DEFAULT '',
+    details    JSONB       NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_audit_events_user_id ON audit_events (user_id, id);
+CREATE INDEX idx_audit_events_type ON audit_events (type, id);
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at);
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+-- Роль меняется только в БД (users.is_admin), поэтому смену пишет триггер:
+-- так в журнал попадает и ручной UPDATE. db_user - кто выполнил запрос.
+CREATE FUNCTION audit_role_changed() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO audit_events (type, user_id, details)
+    VALUES ('role.changed', NEW.id, jsonb_build_object(
+        'role', 'admin',
+        'granted', NEW.is_admin::text,
+        'db_user', current_user
+    ));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_audit_role_changed
+    AFTER UPDATE OF is_admin ON users
+    FOR EACH ROW WHEN (OLD.is_admin IS DISTINCT FROM NEW.is_admin)
+    EXECUTE FUNCTION audit_role_changed();
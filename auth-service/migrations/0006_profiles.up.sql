@@ -0,0 +1,13 @@
+-- Публичный профиль пользователя. Строки нет, пока пользователь его не заполнил:
+-- такой профиль отдаётся пустым.
+CREATE TABLE profiles (
+    user_id      BIGINT      PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    username     TEXT,                                -- @handle, NULL - не задан
+    display_name TEXT        NOT NULL DEFAULT '',
+    avatar_url   TEXT        NOT NULL DEFAULT '',
+    bio          TEXT        NOT NULL DEFAULT '',
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- username уникален без учёта регистра
+CREATE UNIQUE INDEX idx_profiles_username ON profiles (lower(username));
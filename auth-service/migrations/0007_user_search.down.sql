@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_profiles_display_name_trgm;
+DROP INDEX IF EXISTS idx_profiles_username_trgm;
+DROP INDEX IF EXISTS idx_users_email_trgm;
+-- pg_trgm не удаляем: расширение могли поставить до миграции
@@ -0,0 +1,8 @@
+-- Поиск пользователей: префикс (LIKE 'q%') и нечёткое совпадение (%) по email,
+-- username и display_name. GIN индексы pg_trgm обслуживают оба оператора.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_users_email_trgm ON users USING gin (lower(email) gin_trgm_ops);
+-- username хранится в нижнем регистре
+CREATE INDEX idx_profiles_username_trgm ON profiles USING gin (username gin_trgm_ops);
+CREATE INDEX idx_profiles_display_name_trgm ON profiles USING gin (lower(display_name) gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS users_audit_user_deleted ON users;
+DROP FUNCTION IF EXISTS audit_user_deleted();
@@ -0,0 +1,13 @@
+-- Удаление пользователя попадает в журнал аудита из триггера: так его видит и ручной
+-- DELETE. Другие сервисы читают эти события через AuthService.WatchUserEvents.
+CREATE FUNCTION audit_user_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO audit_events (type, user_id, details)
+    VALUES ('user.deleted', OLD.id, jsonb_build_object('db_user', current_user));
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_audit_user_deleted
+    AFTER DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION audit_user_deleted();
@@ -0,0 +1,2 @@
+-- scope, выданный при входе через OAuth: refresh может только сузить его
+ALTER TABLE sessions ADD COLUMN scope TEXT NOT NULL DEFAULT '';
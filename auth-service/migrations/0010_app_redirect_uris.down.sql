@@ -0,0 +1,3 @@
+ALTER TABLE apps DROP COLUMN IF EXISTS scopes;
+ALTER TABLE apps DROP COLUMN IF EXISTS redirect_uris;
+ALTER TABLE apps ADD COLUMN redirect_origins TEXT[] NOT NULL DEFAULT '{}';
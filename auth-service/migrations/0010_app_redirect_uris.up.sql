@@ -0,0 +1,5 @@
+-- redirect_uri сверяется целиком (RFC 6749 §3.1.2), одного origin недостаточно
+ALTER TABLE apps DROP COLUMN IF EXISTS redirect_origins;
+ALTER TABLE apps ADD COLUMN redirect_uris TEXT[] NOT NULL DEFAULT '{}';
+-- scope, которые приложение может запросить через client_credentials
+ALTER TABLE apps ADD COLUMN scopes TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "auth/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Admin is an autogenerated mock type for the Admin type
+type Admin struct {
+	mock.Mock
+}
+
+// ExportEvents provides a mock function with given fields: ctx, actorID, filter, fn
+func (_m *Admin) ExportEvents(ctx context.Context, actorID int, filter domain.AuditFilter, fn func(domain.AuditEvent) error) error {
+	ret := _m.Called(ctx, actorID, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.AuditFilter, func(domain.AuditEvent) error) error); ok {
+		r0 = rf(ctx, actorID, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListEvents provides a mock function with given fields: ctx, actorID, filter
+func (_m *Admin) ListEvents(ctx context.Context, actorID int, filter domain.AuditFilter) ([]domain.AuditEvent, int64, error) {
+	ret := _m.Called(ctx, actorID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEvents")
+	}
+
+	var r0 []domain.AuditEvent
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.AuditFilter) ([]domain.AuditEvent, int64, error)); ok {
+		return rf(ctx, actorID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.AuditFilter) []domain.AuditEvent); ok {
+		r0 = rf(ctx, actorID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, domain.AuditFilter) int64); ok {
+		r1 = rf(ctx, actorID, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, domain.AuditFilter) error); ok {
+		r2 = rf(ctx, actorID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewAdmin creates a new instance of Admin. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdmin(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Admin {
+	mock := &Admin{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
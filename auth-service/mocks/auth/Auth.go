@@ -3,10 +3,12 @@
 package mocks
 
 import (
-	tokenjwt "auth/pkg/token"
+	domain "auth/internal/domain"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	tokenjwt "auth/pkg/token"
 )
 
 // Auth is an autogenerated mock type for the Auth type
@@ -14,6 +16,52 @@ type Auth struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, userID, currentPassword, newPassword
+func (_m *Auth) ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) error {
+	ret := _m.Called(ctx, userID, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) error); ok {
+		r0 = rf(ctx, userID, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IntrospectToken provides a mock function with given fields: ctx, accessToken, audience
+func (_m *Auth) IntrospectToken(ctx context.Context, accessToken string, audience string) (domain.TokenInfo, error) {
+	ret := _m.Called(ctx, accessToken, audience)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IntrospectToken")
+	}
+
+	var r0 domain.TokenInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (domain.TokenInfo, error)); ok {
+		return rf(ctx, accessToken, audience)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.TokenInfo); ok {
+		r0 = rf(ctx, accessToken, audience)
+	} else {
+		r0 = ret.Get(0).(domain.TokenInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, accessToken, audience)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsAdmin provides a mock function with given fields: ctx, userID
 func (_m *Auth) IsAdmin(ctx context.Context, userID int) (bool, error) {
 	ret := _m.Called(ctx, userID)
@@ -154,6 +202,36 @@ func (_m *Auth) Register(ctx context.Context, email string, password string) (in
 	return r0, r1
 }
 
+// UsersExist provides a mock function with given fields: ctx, userIDs
+func (_m *Auth) UsersExist(ctx context.Context, userIDs []int) ([]int, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersExist")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]int, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateSession provides a mock function with given fields: ctx, sessionID
 func (_m *Auth) ValidateSession(ctx context.Context, sessionID int) (bool, error) {
 	ret := _m.Called(ctx, sessionID)
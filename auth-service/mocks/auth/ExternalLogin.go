@@ -0,0 +1,92 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	tokenjwt "auth/pkg/token"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExternalLogin is an autogenerated mock type for the ExternalLogin type
+type ExternalLogin struct {
+	mock.Mock
+}
+
+// CompleteExternalLogin provides a mock function with given fields: ctx, providerName, code, state
+func (_m *ExternalLogin) CompleteExternalLogin(ctx context.Context, providerName string, code string, state string) (tokenjwt.Token, error) {
+	ret := _m.Called(ctx, providerName, code, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteExternalLogin")
+	}
+
+	var r0 tokenjwt.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (tokenjwt.Token, error)); ok {
+		return rf(ctx, providerName, code, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) tokenjwt.Token); ok {
+		r0 = rf(ctx, providerName, code, state)
+	} else {
+		r0 = ret.Get(0).(tokenjwt.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, providerName, code, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartExternalLogin provides a mock function with given fields: ctx, providerName, appID
+func (_m *ExternalLogin) StartExternalLogin(ctx context.Context, providerName string, appID int) (string, string, error) {
+	ret := _m.Called(ctx, providerName, appID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExternalLogin")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (string, string, error)); ok {
+		return rf(ctx, providerName, appID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) string); ok {
+		r0 = rf(ctx, providerName, appID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) string); ok {
+		r1 = rf(ctx, providerName, appID)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int) error); ok {
+		r2 = rf(ctx, providerName, appID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewExternalLogin creates a new instance of ExternalLogin. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExternalLogin(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExternalLogin {
+	mock := &ExternalLogin{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
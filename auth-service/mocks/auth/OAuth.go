@@ -0,0 +1,153 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "auth/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	tokenjwt "auth/pkg/token"
+)
+
+// OAuth is an autogenerated mock type for the OAuth type
+type OAuth struct {
+	mock.Mock
+}
+
+// Authorize provides a mock function with given fields: ctx, req
+func (_m *OAuth) Authorize(ctx context.Context, req domain.AuthorizeRequest) (string, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authorize")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuthorizeRequest) (string, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuthorizeRequest) string); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.AuthorizeRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Introspect provides a mock function with given fields: ctx, req
+func (_m *OAuth) Introspect(ctx context.Context, req domain.IntrospectRequest) (domain.Introspection, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Introspect")
+	}
+
+	var r0 domain.Introspection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IntrospectRequest) (domain.Introspection, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.IntrospectRequest) domain.Introspection); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(domain.Introspection)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.IntrospectRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with no fields
+func (_m *OAuth) JWKS() []tokenjwt.JWK {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 []tokenjwt.JWK
+	if rf, ok := ret.Get(0).(func() []tokenjwt.JWK); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tokenjwt.JWK)
+		}
+	}
+
+	return r0
+}
+
+// Revoke provides a mock function with given fields: ctx, req
+func (_m *OAuth) Revoke(ctx context.Context, req domain.RevokeRequest) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.RevokeRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Token provides a mock function with given fields: ctx, req
+func (_m *OAuth) Token(ctx context.Context, req domain.TokenRequest) (domain.OAuthToken, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Token")
+	}
+
+	var r0 domain.OAuthToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TokenRequest) (domain.OAuthToken, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TokenRequest) domain.OAuthToken); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(domain.OAuthToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TokenRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOAuth creates a new instance of OAuth. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOAuth(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OAuth {
+	mock := &OAuth{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
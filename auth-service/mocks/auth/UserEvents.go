@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "auth/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserEvents is an autogenerated mock type for the UserEvents type
+type UserEvents struct {
+	mock.Mock
+}
+
+// WatchUserEvents provides a mock function with given fields: ctx, afterID, fn
+func (_m *UserEvents) WatchUserEvents(ctx context.Context, afterID int64, fn func(domain.AuditEvent, int64) error) error {
+	ret := _m.Called(ctx, afterID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchUserEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, func(domain.AuditEvent, int64) error) error); ok {
+		r0 = rf(ctx, afterID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewUserEvents creates a new instance of UserEvents. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserEvents(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserEvents {
+	mock := &UserEvents{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -16,6 +16,117 @@ type AuthServiceClient struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) ChangePassword(ctx context.Context, in *authv1.ChangePasswordRequest, opts ...grpc.CallOption) (*authv1.ChangePasswordResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 *authv1.ChangePasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.ChangePasswordRequest, ...grpc.CallOption) (*authv1.ChangePasswordResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.ChangePasswordRequest, ...grpc.CallOption) *authv1.ChangePasswordResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.ChangePasswordResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.ChangePasswordRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteExternalLogin provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) CompleteExternalLogin(ctx context.Context, in *authv1.CompleteExternalLoginRequest, opts ...grpc.CallOption) (*authv1.LoginResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteExternalLogin")
+	}
+
+	var r0 *authv1.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.CompleteExternalLoginRequest, ...grpc.CallOption) (*authv1.LoginResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.CompleteExternalLoginRequest, ...grpc.CallOption) *authv1.LoginResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.CompleteExternalLoginRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IntrospectToken provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) IntrospectToken(ctx context.Context, in *authv1.IntrospectTokenRequest, opts ...grpc.CallOption) (*authv1.IntrospectTokenResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IntrospectToken")
+	}
+
+	var r0 *authv1.IntrospectTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.IntrospectTokenRequest, ...grpc.CallOption) (*authv1.IntrospectTokenResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.IntrospectTokenRequest, ...grpc.CallOption) *authv1.IntrospectTokenResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.IntrospectTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.IntrospectTokenRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsAdmin provides a mock function with given fields: ctx, in, opts
 func (_m *AuthServiceClient) IsAdmin(ctx context.Context, in *authv1.IsAdminRequest, opts ...grpc.CallOption) (*authv1.IsAdminResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -201,6 +312,80 @@ func (_m *AuthServiceClient) Register(ctx context.Context, in *authv1.RegisterRe
 	return r0, r1
 }
 
+// StartExternalLogin provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) StartExternalLogin(ctx context.Context, in *authv1.StartExternalLoginRequest, opts ...grpc.CallOption) (*authv1.StartExternalLoginResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExternalLogin")
+	}
+
+	var r0 *authv1.StartExternalLoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.StartExternalLoginRequest, ...grpc.CallOption) (*authv1.StartExternalLoginResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.StartExternalLoginRequest, ...grpc.CallOption) *authv1.StartExternalLoginResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.StartExternalLoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.StartExternalLoginRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersExist provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) UsersExist(ctx context.Context, in *authv1.UsersExistRequest, opts ...grpc.CallOption) (*authv1.UsersExistResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersExist")
+	}
+
+	var r0 *authv1.UsersExistResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.UsersExistRequest, ...grpc.CallOption) (*authv1.UsersExistResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.UsersExistRequest, ...grpc.CallOption) *authv1.UsersExistResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.UsersExistResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.UsersExistRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateSession provides a mock function with given fields: ctx, in, opts
 func (_m *AuthServiceClient) ValidateSession(ctx context.Context, in *authv1.ValidateSessionRequest, opts ...grpc.CallOption) (*authv1.ValidateSessionResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -238,6 +423,43 @@ func (_m *AuthServiceClient) ValidateSession(ctx context.Context, in *authv1.Val
 	return r0, r1
 }
 
+// WatchUserEvents provides a mock function with given fields: ctx, in, opts
+func (_m *AuthServiceClient) WatchUserEvents(ctx context.Context, in *authv1.WatchUserEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[authv1.UserEvent], error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchUserEvents")
+	}
+
+	var r0 grpc.ServerStreamingClient[authv1.UserEvent]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.WatchUserEventsRequest, ...grpc.CallOption) (grpc.ServerStreamingClient[authv1.UserEvent], error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.WatchUserEventsRequest, ...grpc.CallOption) grpc.ServerStreamingClient[authv1.UserEvent]); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(grpc.ServerStreamingClient[authv1.UserEvent])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.WatchUserEventsRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewAuthServiceClient creates a new instance of AuthServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthServiceClient(t interface {
@@ -6,6 +6,8 @@ import (
 	authv1 "auth/proto/auth/v1"
 	context "context"
 
+	grpc "google.golang.org/grpc"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +16,96 @@ type AuthServiceServer struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: _a0, _a1
+func (_m *AuthServiceServer) ChangePassword(_a0 context.Context, _a1 *authv1.ChangePasswordRequest) (*authv1.ChangePasswordResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 *authv1.ChangePasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.ChangePasswordRequest) (*authv1.ChangePasswordResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.ChangePasswordRequest) *authv1.ChangePasswordResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.ChangePasswordResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.ChangePasswordRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteExternalLogin provides a mock function with given fields: _a0, _a1
+func (_m *AuthServiceServer) CompleteExternalLogin(_a0 context.Context, _a1 *authv1.CompleteExternalLoginRequest) (*authv1.LoginResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteExternalLogin")
+	}
+
+	var r0 *authv1.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.CompleteExternalLoginRequest) (*authv1.LoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.CompleteExternalLoginRequest) *authv1.LoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.LoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.CompleteExternalLoginRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IntrospectToken provides a mock function with given fields: _a0, _a1
+func (_m *AuthServiceServer) IntrospectToken(_a0 context.Context, _a1 *authv1.IntrospectTokenRequest) (*authv1.IntrospectTokenResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IntrospectToken")
+	}
+
+	var r0 *authv1.IntrospectTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.IntrospectTokenRequest) (*authv1.IntrospectTokenResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.IntrospectTokenRequest) *authv1.IntrospectTokenResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.IntrospectTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.IntrospectTokenRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsAdmin provides a mock function with given fields: _a0, _a1
 func (_m *AuthServiceServer) IsAdmin(_a0 context.Context, _a1 *authv1.IsAdminRequest) (*authv1.IsAdminResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -164,6 +256,66 @@ func (_m *AuthServiceServer) Register(_a0 context.Context, _a1 *authv1.RegisterR
 	return r0, r1
 }
 
+// StartExternalLogin provides a mock function with given fields: _a0, _a1
+func (_m *AuthServiceServer) StartExternalLogin(_a0 context.Context, _a1 *authv1.StartExternalLoginRequest) (*authv1.StartExternalLoginResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExternalLogin")
+	}
+
+	var r0 *authv1.StartExternalLoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.StartExternalLoginRequest) (*authv1.StartExternalLoginResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.StartExternalLoginRequest) *authv1.StartExternalLoginResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.StartExternalLoginResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.StartExternalLoginRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsersExist provides a mock function with given fields: _a0, _a1
+func (_m *AuthServiceServer) UsersExist(_a0 context.Context, _a1 *authv1.UsersExistRequest) (*authv1.UsersExistResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersExist")
+	}
+
+	var r0 *authv1.UsersExistResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.UsersExistRequest) (*authv1.UsersExistResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *authv1.UsersExistRequest) *authv1.UsersExistResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*authv1.UsersExistResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *authv1.UsersExistRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateSession provides a mock function with given fields: _a0, _a1
 func (_m *AuthServiceServer) ValidateSession(_a0 context.Context, _a1 *authv1.ValidateSessionRequest) (*authv1.ValidateSessionResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -194,6 +346,24 @@ func (_m *AuthServiceServer) ValidateSession(_a0 context.Context, _a1 *authv1.Va
 	return r0, r1
 }
 
+// WatchUserEvents provides a mock function with given fields: _a0, _a1
+func (_m *AuthServiceServer) WatchUserEvents(_a0 *authv1.WatchUserEventsRequest, _a1 grpc.ServerStreamingServer[authv1.UserEvent]) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchUserEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*authv1.WatchUserEventsRequest, grpc.ServerStreamingServer[authv1.UserEvent]) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // mustEmbedUnimplementedAuthServiceServer provides a mock function with no fields
 func (_m *AuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {
 	_m.Called()
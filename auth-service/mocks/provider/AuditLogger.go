@@ -0,0 +1,34 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "auth/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditLogger is an autogenerated mock type for the AuditLogger type
+type AuditLogger struct {
+	mock.Mock
+}
+
+// Record provides a mock function with given fields: ctx, event
+func (_m *AuditLogger) Record(ctx context.Context, event domain.AuditEvent) {
+	_m.Called(ctx, event)
+}
+
+// NewAuditLogger creates a new instance of AuditLogger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditLogger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditLogger {
+	mock := &AuditLogger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
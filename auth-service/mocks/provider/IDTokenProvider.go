@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	tokenjwt "auth/pkg/token"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IDTokenProvider is an autogenerated mock type for the IDTokenProvider type
+type IDTokenProvider struct {
+	mock.Mock
+}
+
+// CreateIDToken provides a mock function with given fields: subject, audience, nonce, exp
+func (_m *IDTokenProvider) CreateIDToken(subject string, audience string, nonce string, exp time.Time) (string, error) {
+	ret := _m.Called(subject, audience, nonce, exp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIDToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time) (string, error)); ok {
+		return rf(subject, audience, nonce, exp)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time) string); ok {
+		r0 = rf(subject, audience, nonce, exp)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, time.Time) error); ok {
+		r1 = rf(subject, audience, nonce, exp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with no fields
+func (_m *IDTokenProvider) JWKS() []tokenjwt.JWK {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 []tokenjwt.JWK
+	if rf, ok := ret.Get(0).(func() []tokenjwt.JWK); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tokenjwt.JWK)
+		}
+	}
+
+	return r0
+}
+
+// NewIDTokenProvider creates a new instance of IDTokenProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDTokenProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDTokenProvider {
+	mock := &IDTokenProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
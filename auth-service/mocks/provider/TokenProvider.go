@@ -3,9 +3,10 @@
 package mocks
 
 import (
-	mock "github.com/stretchr/testify/mock"
-
+	tokenjwt "auth/pkg/token"
 	time "time"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // TokenProvider is an autogenerated mock type for the TokenProvider type
@@ -13,9 +14,9 @@ type TokenProvider struct {
 	mock.Mock
 }
 
-// CreateAccessToken provides a mock function with given fields: userID, sessionID, appID, exp
-func (_m *TokenProvider) CreateAccessToken(userID int, sessionID int, appID int, exp time.Time) (string, error) {
-	ret := _m.Called(userID, sessionID, appID, exp)
+// CreateAccessToken provides a mock function with given fields: userID, sessionID, appID, audience, exp
+func (_m *TokenProvider) CreateAccessToken(userID int, sessionID int, appID int, audience string, exp time.Time) (string, error) {
+	ret := _m.Called(userID, sessionID, appID, audience, exp)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateAccessToken")
@@ -23,17 +24,45 @@ func (_m *TokenProvider) CreateAccessToken(userID int, sessionID int, appID int,
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int, int, time.Time) (string, error)); ok {
-		return rf(userID, sessionID, appID, exp)
+	if rf, ok := ret.Get(0).(func(int, int, int, string, time.Time) (string, error)); ok {
+		return rf(userID, sessionID, appID, audience, exp)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, int, string, time.Time) string); ok {
+		r0 = rf(userID, sessionID, appID, audience, exp)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, int, string, time.Time) error); ok {
+		r1 = rf(userID, sessionID, appID, audience, exp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClientAccessToken provides a mock function with given fields: appID, clientID, exp
+func (_m *TokenProvider) CreateClientAccessToken(appID int, clientID string, exp time.Time) (string, error) {
+	ret := _m.Called(appID, clientID, exp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClientAccessToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, time.Time) (string, error)); ok {
+		return rf(appID, clientID, exp)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, int, time.Time) string); ok {
-		r0 = rf(userID, sessionID, appID, exp)
+	if rf, ok := ret.Get(0).(func(int, string, time.Time) string); ok {
+		r0 = rf(appID, clientID, exp)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, int, time.Time) error); ok {
-		r1 = rf(userID, sessionID, appID, exp)
+	if rf, ok := ret.Get(1).(func(int, string, time.Time) error); ok {
+		r1 = rf(appID, clientID, exp)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,6 +98,34 @@ func (_m *TokenProvider) CreateRefreshToken() (string, error) {
 	return r0, r1
 }
 
+// ParseAccessToken provides a mock function with given fields: accToken
+func (_m *TokenProvider) ParseAccessToken(accToken string) (tokenjwt.AccessClaims, error) {
+	ret := _m.Called(accToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseAccessToken")
+	}
+
+	var r0 tokenjwt.AccessClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (tokenjwt.AccessClaims, error)); ok {
+		return rf(accToken)
+	}
+	if rf, ok := ret.Get(0).(func(string) tokenjwt.AccessClaims); ok {
+		r0 = rf(accToken)
+	} else {
+		r0 = ret.Get(0).(tokenjwt.AccessClaims)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTokenProvider creates a new instance of TokenProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenProvider(t interface {
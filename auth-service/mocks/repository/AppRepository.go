@@ -0,0 +1,85 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "auth/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AppRepository is an autogenerated mock type for the AppRepository type
+type AppRepository struct {
+	mock.Mock
+}
+
+// AppByID provides a mock function with given fields: ctx, id
+func (_m *AppRepository) AppByID(ctx context.Context, id int) (domain.App, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppByID")
+	}
+
+	var r0 domain.App
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.App, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.App); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.App)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AppByName provides a mock function with given fields: ctx, name
+func (_m *AppRepository) AppByName(ctx context.Context, name string) (domain.App, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppByName")
+	}
+
+	var r0 domain.App
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.App, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.App); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(domain.App)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAppRepository creates a new instance of AppRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAppRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AppRepository {
+	mock := &AppRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,75 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "auth/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthCodeRepository is an autogenerated mock type for the AuthCodeRepository type
+type AuthCodeRepository struct {
+	mock.Mock
+}
+
+// ConsumeCode provides a mock function with given fields: ctx, codeHash
+func (_m *AuthCodeRepository) ConsumeCode(ctx context.Context, codeHash string) (domain.AuthCode, error) {
+	ret := _m.Called(ctx, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeCode")
+	}
+
+	var r0 domain.AuthCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.AuthCode, error)); ok {
+		return rf(ctx, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.AuthCode); ok {
+		r0 = rf(ctx, codeHash)
+	} else {
+		r0 = ret.Get(0).(domain.AuthCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveCode provides a mock function with given fields: ctx, code
+func (_m *AuthCodeRepository) SaveCode(ctx context.Context, code domain.AuthCode) error {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AuthCode) error); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAuthCodeRepository creates a new instance of AuthCodeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthCodeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthCodeRepository {
+	mock := &AuthCodeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,24 @@ type Cache struct {
 	mock.Mock
 }
 
+// DelRoles provides a mock function with given fields: ctx, userID
+func (_m *Cache) DelRoles(ctx context.Context, userID int) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DelRoles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DelSession provides a mock function with given fields: ctx, keyID
 func (_m *Cache) DelSession(ctx context.Context, keyID int) error {
 	ret := _m.Called(ctx, keyID)
@@ -32,6 +50,43 @@ func (_m *Cache) DelSession(ctx context.Context, keyID int) error {
 	return r0
 }
 
+// GetRoles provides a mock function with given fields: ctx, userID
+func (_m *Cache) GetRoles(ctx context.Context, userID int) (bool, []string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 bool
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, []string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) []string); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int) error); ok {
+		r2 = rf(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSession provides a mock function with given fields: ctx, keyID
 func (_m *Cache) GetSession(ctx context.Context, keyID int) (bool, domain.Session, error) {
 	ret := _m.Called(ctx, keyID)
@@ -67,6 +122,24 @@ func (_m *Cache) GetSession(ctx context.Context, keyID int) (bool, domain.Sessio
 	return r0, r1, r2
 }
 
+// SetRoles provides a mock function with given fields: ctx, userID, roles
+func (_m *Cache) SetRoles(ctx context.Context, userID int, roles []string) error {
+	ret := _m.Called(ctx, userID, roles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRoles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, userID, roles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetSession provides a mock function with given fields: ctx, keyID, value
 func (_m *Cache) SetSession(ctx context.Context, keyID int, value domain.Session) error {
 	ret := _m.Called(ctx, keyID, value)
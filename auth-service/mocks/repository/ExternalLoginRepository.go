@@ -0,0 +1,75 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "auth/internal/domain"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExternalLoginRepository is an autogenerated mock type for the ExternalLoginRepository type
+type ExternalLoginRepository struct {
+	mock.Mock
+}
+
+// ConsumeState provides a mock function with given fields: ctx, stateHash
+func (_m *ExternalLoginRepository) ConsumeState(ctx context.Context, stateHash string) (domain.ExternalLoginState, error) {
+	ret := _m.Called(ctx, stateHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeState")
+	}
+
+	var r0 domain.ExternalLoginState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.ExternalLoginState, error)); ok {
+		return rf(ctx, stateHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.ExternalLoginState); ok {
+		r0 = rf(ctx, stateHash)
+	} else {
+		r0 = ret.Get(0).(domain.ExternalLoginState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveState provides a mock function with given fields: ctx, state
+func (_m *ExternalLoginRepository) SaveState(ctx context.Context, state domain.ExternalLoginState) error {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ExternalLoginState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewExternalLoginRepository creates a new instance of ExternalLoginRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExternalLoginRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExternalLoginRepository {
+	mock := &ExternalLoginRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -16,9 +16,9 @@ type SessionRepository struct {
 	mock.Mock
 }
 
-// CreateSession provides a mock function with given fields: ctx, userID, appID, refreshToken, refExpiresAt
-func (_m *SessionRepository) CreateSession(ctx context.Context, userID int, appID int, refreshToken string, refExpiresAt time.Time) (int, error) {
-	ret := _m.Called(ctx, userID, appID, refreshToken, refExpiresAt)
+// CreateSession provides a mock function with given fields: ctx, userID, appID, refreshToken, refExpiresAt, scope
+func (_m *SessionRepository) CreateSession(ctx context.Context, userID int, appID int, refreshToken string, refExpiresAt time.Time, scope string) (int, error) {
+	ret := _m.Called(ctx, userID, appID, refreshToken, refExpiresAt, scope)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSession")
@@ -26,17 +26,45 @@ func (_m *SessionRepository) CreateSession(ctx context.Context, userID int, appI
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, time.Time) (int, error)); ok {
-		return rf(ctx, userID, appID, refreshToken, refExpiresAt)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, time.Time, string) (int, error)); ok {
+		return rf(ctx, userID, appID, refreshToken, refExpiresAt, scope)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, time.Time) int); ok {
-		r0 = rf(ctx, userID, appID, refreshToken, refExpiresAt)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, time.Time, string) int); ok {
+		r0 = rf(ctx, userID, appID, refreshToken, refExpiresAt, scope)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, time.Time) error); ok {
-		r1 = rf(ctx, userID, appID, refreshToken, refExpiresAt)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, time.Time, string) error); ok {
+		r1 = rf(ctx, userID, appID, refreshToken, refExpiresAt, scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeByID provides a mock function with given fields: ctx, id
+func (_m *SessionRepository) RevokeByID(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
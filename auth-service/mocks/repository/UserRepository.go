@@ -14,6 +14,36 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// ExistingUserIDs provides a mock function with given fields: ctx, ids
+func (_m *UserRepository) ExistingUserIDs(ctx context.Context, ids []int) ([]int, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistingUserIDs")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) ([]int, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsAdmin provides a mock function with given fields: ctx, userID
 func (_m *UserRepository) IsAdmin(ctx context.Context, userID int) (bool, error) {
 	ret := _m.Called(ctx, userID)
@@ -60,6 +90,24 @@ func (_m *UserRepository) SaveUser(ctx context.Context, email string, passHash [
 	return r0
 }
 
+// UpdatePassHash provides a mock function with given fields: ctx, userID, passHash
+func (_m *UserRepository) UpdatePassHash(ctx context.Context, userID int, passHash []byte) error {
+	ret := _m.Called(ctx, userID, passHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []byte) error); ok {
+		r0 = rf(ctx, userID, passHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) UserByEmail(ctx context.Context, email string) (domain.User, error) {
 	ret := _m.Called(ctx, email)
@@ -88,6 +136,34 @@ func (_m *UserRepository) UserByEmail(ctx context.Context, email string) (domain
 	return r0, r1
 }
 
+// UserByID provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) UserByID(ctx context.Context, userID int) (domain.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -0,0 +1,173 @@
+// Package apierr - единая модель ошибок API для auth-service, chat-service и gateway.
+//
+// Ошибка - это gRPC статус с errdetails.ErrorInfo, где Reason - стабильный машиночитаемый
+// код из каталога ниже, и, для ошибок валидации, errdetails.BadRequest с нарушениями по полям.
+// Клиенты ветвятся по коду, а не по тексту сообщения: текст может меняться, код - нет.
+package apierr
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain - ErrorInfo.Domain у всех ошибок сервисов мессенджера.
+const Domain = "messenger"
+
+// Code - стабильный код ошибки (ErrorInfo.Reason, поле code в JSON ответе gateway).
+type Code string
+
+// Общие коды - по ним же классифицируются ошибки без ErrorInfo (см. CodeOf).
+const (
+	CodeInvalidArgument  Code = "INVALID_ARGUMENT"
+	CodeValidation       Code = "VALIDATION_FAILED"
+	CodeUnauthenticated  Code = "UNAUTHENTICATED"
+	CodePermissionDenied Code = "PERMISSION_DENIED"
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeConflict         Code = "CONFLICT"
+	CodeRateLimited      Code = "RATE_LIMITED"
+	CodeUnavailable      Code = "UNAVAILABLE"
+	CodeInternal         Code = "INTERNAL"
+)
+
+// Коды auth-service.
+const (
+	CodeUserAlreadyExists   Code = "USER_ALREADY_EXISTS"
+	CodeUserNotFound        Code = "USER_NOT_FOUND"
+	CodeInvalidCredentials  Code = "INVALID_CREDENTIALS"
+	CodeInvalidRefreshToken Code = "INVALID_REFRESH_TOKEN"
+	CodeUnknownApp          Code = "UNKNOWN_APP"
+	CodeAppDisabled         Code = "APP_DISABLED"
+	CodeUnknownProvider     Code = "UNKNOWN_IDENTITY_PROVIDER"
+	CodeExternalLoginFailed Code = "EXTERNAL_LOGIN_FAILED"
+	CodeWeakPassword        Code = "WEAK_PASSWORD"
+	CodeUsernameTaken       Code = "USERNAME_TAKEN"
+)
+
+// Коды chat-service.
+const (
+	CodeChatNotFound   Code = "CHAT_NOT_FOUND"
+	CodeNotChatMember  Code = "NOT_CHAT_MEMBER"
+	CodeUserBlocked    Code = "USER_BLOCKED"
+	CodeResyncRequired Code = "RESYNC_REQUIRED"
+)
+
+// Коды gateway - ошибки, которые не доходят до сервисов.
+const (
+	CodeCSRFMismatch     Code = "CSRF_TOKEN_MISMATCH"
+	CodeOriginNotAllowed Code = "ORIGIN_NOT_ALLOWED"
+	CodeShuttingDown     Code = "SHUTTING_DOWN"
+	CodeMethodNotAllowed Code = "METHOD_NOT_ALLOWED"
+)
+
+// FieldViolation - ошибка в конкретном поле запроса.
+// Reason - необязательный стабильный код правила, например PASSWORD_TOO_SHORT.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Error - разобранная ошибка: то, что gateway отдаёт клиенту.
+type Error struct {
+	Status     codes.Code
+	Code       Code
+	Message    string
+	Violations []FieldViolation
+}
+
+// New - gRPC ошибка со стабильным кодом.
+func New(c codes.Code, code Code, msg string) error {
+	return withDetails(c, msg, &errdetails.ErrorInfo{Reason: string(code), Domain: Domain})
+}
+
+// Invalid - InvalidArgument с кодом VALIDATION_FAILED и нарушениями по полям.
+func Invalid(msg string, violations ...FieldViolation) error {
+	return InvalidCode(CodeValidation, msg, violations...)
+}
+
+// InvalidCode - как Invalid, но со своим кодом: запрос корректен по форме,
+// но не прошёл проверку сервиса (например, WEAK_PASSWORD).
+func InvalidCode(code Code, msg string, violations ...FieldViolation) error {
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+			Reason:      v.Reason,
+		})
+	}
+	return withDetails(codes.InvalidArgument, msg, &errdetails.ErrorInfo{Reason: string(code), Domain: Domain}, br)
+}
+
+// Internal - внутренняя ошибка без подробностей: причина остаётся в логах сервиса.
+func Internal() error {
+	return status.Error(codes.Internal, "internal error")
+}
+
+// Parse разбирает ошибку gRPC вызова. Если сервис не передал ErrorInfo,
+// код выводится из gRPC кода, так что Code заполнен всегда.
+func Parse(err error) Error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return Error{Status: codes.Unknown, Code: CodeInternal, Message: err.Error()}
+	}
+
+	e := Error{Status: s.Code(), Code: CodeOf(s.Code()), Message: s.Message()}
+	for _, d := range s.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == Domain && d.GetReason() != "" {
+				e.Code = Code(d.GetReason())
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				e.Violations = append(e.Violations, FieldViolation{Field: v.GetField(), Description: v.GetDescription(), Reason: v.GetReason()})
+			}
+		}
+	}
+	return e
+}
+
+// Is сообщает, что err - ошибка API с кодом code.
+func Is(err error, code Code) bool {
+	var se interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &se) {
+		return false
+	}
+	return Parse(err).Code == code
+}
+
+// CodeOf - общий код для gRPC кода, когда сервис не указал свой.
+func CodeOf(c codes.Code) Code {
+	switch c {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return CodeInvalidArgument
+	case codes.Unauthenticated:
+		return CodeUnauthenticated
+	case codes.PermissionDenied:
+		return CodePermissionDenied
+	case codes.NotFound:
+		return CodeNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return CodeConflict
+	case codes.ResourceExhausted:
+		return CodeRateLimited
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
+
+func withDetails(c codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	s, err := status.New(c, msg).WithDetails(details...)
+	if err != nil {
+		// Детали не сериализовались - отдаём хотя бы код и сообщение
+		return status.Error(c, msg)
+	}
+	return s.Err()
+}
@@ -0,0 +1,80 @@
+package apierr_test
+
+import (
+	"auth/pkg/apierr"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParse_ErrorInfo(t *testing.T) {
+	err := apierr.New(codes.AlreadyExists, apierr.CodeUserAlreadyExists, "user with this email already exists")
+
+	got := apierr.Parse(err)
+
+	assert.Equal(t, codes.AlreadyExists, got.Status)
+	assert.Equal(t, apierr.CodeUserAlreadyExists, got.Code)
+	assert.Equal(t, "user with this email already exists", got.Message)
+	assert.Empty(t, got.Violations)
+}
+
+func TestParse_FieldViolations(t *testing.T) {
+	err := apierr.Invalid("invalid request",
+		apierr.FieldViolation{Field: "email", Description: "must be a valid email address"},
+		apierr.FieldViolation{Field: "password", Description: "the length must be between 6 and 100"},
+	)
+
+	got := apierr.Parse(err)
+
+	assert.Equal(t, codes.InvalidArgument, got.Status)
+	assert.Equal(t, apierr.CodeValidation, got.Code)
+	require.Len(t, got.Violations, 2)
+	assert.Equal(t, apierr.FieldViolation{Field: "email", Description: "must be a valid email address"}, got.Violations[0])
+}
+
+func TestParse_ViolationReason(t *testing.T) {
+	err := apierr.InvalidCode(apierr.CodeWeakPassword, "password does not meet policy",
+		apierr.FieldViolation{Field: "password", Description: "must contain a digit", Reason: "PASSWORD_NO_DIGIT"},
+	)
+
+	got := apierr.Parse(err)
+
+	assert.Equal(t, codes.InvalidArgument, got.Status)
+	assert.Equal(t, apierr.CodeWeakPassword, got.Code)
+	require.Len(t, got.Violations, 1)
+	assert.Equal(t, "PASSWORD_NO_DIGIT", got.Violations[0].Reason)
+}
+
+func TestParse_WithoutDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want apierr.Code
+	}{
+		{"internal", apierr.Internal(), apierr.CodeInternal},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "unauthenticated"), apierr.CodeUnauthenticated},
+		{"permission denied", status.Error(codes.PermissionDenied, "permission denied"), apierr.CodePermissionDenied},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), apierr.CodeUnavailable},
+		{"rate limited", status.Error(codes.ResourceExhausted, "too many requests"), apierr.CodeRateLimited},
+		{"not a status", errors.New("boom"), apierr.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, apierr.Parse(tt.err).Code)
+		})
+	}
+}
+
+func TestIs(t *testing.T) {
+	err := fmt.Errorf("wrap: %w", apierr.New(codes.InvalidArgument, apierr.CodeUnknownApp, "unknown app"))
+
+	assert.True(t, apierr.Is(err, apierr.CodeUnknownApp))
+	assert.False(t, apierr.Is(err, apierr.CodeAppDisabled))
+	assert.False(t, apierr.Is(errors.New("unknown app"), apierr.CodeUnknownApp))
+}
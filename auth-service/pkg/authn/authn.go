@@ -0,0 +1,67 @@
+// Package authn - аутентификация входящих gRPC вызовов для сервисов поверх auth-service:
+// Principal в контексте, один Verifier и интерсепторы для unary и stream методов.
+package authn
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrMissingToken ...
+	ErrMissingToken = errors.New("missing authorization header")
+	// ErrInvalidToken ...
+	ErrInvalidToken = errors.New("invalid or expired token")
+)
+
+// Principal - кто вызывает сервис.
+type Principal struct {
+	UserID    int
+	SessionID int
+	AppID     int
+	Roles     []string
+	ExpiresAt time.Time
+}
+
+// HasRole ...
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// Verifier проверяет access токен. Невалидный токен - ErrInvalidToken,
+// любая другая ошибка означает, что проверить токен сейчас нельзя.
+type Verifier interface {
+	Verify(ctx context.Context, accessToken string) (Principal, error)
+}
+
+// VerifierFunc ...
+type VerifierFunc func(ctx context.Context, accessToken string) (Principal, error)
+
+// Verify ...
+func (f VerifierFunc) Verify(ctx context.Context, accessToken string) (Principal, error) {
+	return f(ctx, accessToken)
+}
+
+type contextKey struct{}
+
+// NewContext ...
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext возвращает Principal, положенный интерсептором.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(Principal)
+	return p, ok
+}
+
+// UserID ...
+func UserID(ctx context.Context) (int, bool) {
+	p, ok := FromContext(ctx)
+	if !ok || p.UserID == 0 {
+		return 0, false
+	}
+	return p.UserID, true
+}
@@ -0,0 +1,121 @@
+package authn_test
+
+import (
+	"auth/pkg/authn"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func incoming(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestUnaryServerInterceptor_PublicMethod(t *testing.T) {
+	verifier := authn.VerifierFunc(func(context.Context, string) (authn.Principal, error) {
+		t.Fatal("public method must not be verified")
+		return authn.Principal{}, nil
+	})
+
+	interceptor := authn.UnaryServerInterceptor(verifier, authn.WithPublicMethods("/grpc.health.v1.Health/Check"))
+
+	called := false
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, func(ctx context.Context, _ any) (any, error) {
+		called = true
+		_, ok := authn.FromContext(ctx)
+		assert.False(t, ok)
+		return nil, nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestUnaryServerInterceptor_WithServices(t *testing.T) {
+	verifier := authn.VerifierFunc(func(_ context.Context, _ string) (authn.Principal, error) {
+		return authn.Principal{UserID: 7}, nil
+	})
+
+	interceptor := authn.UnaryServerInterceptor(verifier, authn.WithServices("auth.v1.AdminService"))
+	handler := func(context.Context, any) (any, error) { return nil, nil }
+
+	// Чужой сервис - без токена
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/Login"}, handler)
+	require.NoError(t, err)
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AdminService/ListAuditEvents"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = interceptor(incoming("ACCESS"), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AdminService/ListAuditEvents"}, handler)
+	require.NoError(t, err)
+}
+
+func TestUnaryServerInterceptor_WithMethods(t *testing.T) {
+	verifier := authn.VerifierFunc(func(_ context.Context, _ string) (authn.Principal, error) {
+		return authn.Principal{UserID: 7}, nil
+	})
+
+	interceptor := authn.UnaryServerInterceptor(verifier,
+		authn.WithServices("auth.v1.AdminService"),
+		authn.WithMethods("/auth.v1.AuthService/ChangePassword"),
+	)
+	handler := func(context.Context, any) (any, error) { return nil, nil }
+
+	// Остальные методы сервиса - без токена
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/Login"}, handler)
+	require.NoError(t, err)
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/ChangePassword"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = interceptor(incoming("ACCESS"), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/ChangePassword"}, handler)
+	require.NoError(t, err)
+}
+
+func TestStreamServerInterceptor_Success(t *testing.T) {
+	verifier := authn.VerifierFunc(func(_ context.Context, token string) (authn.Principal, error) {
+		require.Equal(t, "ACCESS", token)
+		return authn.Principal{UserID: 7, SessionID: 3, AppID: 1}, nil
+	})
+
+	interceptor := authn.StreamServerInterceptor(verifier)
+
+	err := interceptor(nil, &fakeStream{ctx: incoming("ACCESS")}, &grpc.StreamServerInfo{FullMethod: "/chat.v1.ChatService/Subscribe"}, func(_ any, ss grpc.ServerStream) error {
+		userID, ok := authn.UserID(ss.Context())
+		require.True(t, ok)
+		assert.Equal(t, 7, userID)
+		return nil
+	})
+
+	require.NoError(t, err)
+}
+
+func TestStreamServerInterceptor_InvalidToken(t *testing.T) {
+	verifier := authn.VerifierFunc(func(context.Context, string) (authn.Principal, error) {
+		return authn.Principal{}, authn.ErrInvalidToken
+	})
+
+	interceptor := authn.StreamServerInterceptor(verifier)
+
+	err := interceptor(nil, &fakeStream{ctx: incoming("FORGED")}, &grpc.StreamServerInfo{FullMethod: "/chat.v1.ChatService/Subscribe"}, func(any, grpc.ServerStream) error {
+		t.Fatal("handler must not be called")
+		return nil
+	})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,155 @@
+package authn
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Option ...
+type Option func(*options)
+
+type options struct {
+	public   map[string]struct{}
+	services map[string]struct{}
+	methods  map[string]struct{}
+}
+
+// WithPublicMethods пропускает методы без токена, например "/grpc.health.v1.Health/Check".
+func WithPublicMethods(fullMethods ...string) Option {
+	return func(o *options) {
+		for _, m := range fullMethods {
+			o.public[m] = struct{}{}
+		}
+	}
+}
+
+// WithServices проверяет токен только у методов этих сервисов ("auth.v1.AdminService"),
+// остальные пропускает. Для сервера, где под авторизацией лишь часть сервисов.
+func WithServices(services ...string) Option {
+	return func(o *options) {
+		for _, s := range services {
+			o.services[s] = struct{}{}
+		}
+	}
+}
+
+// WithMethods проверяет токен у этих методов, даже если их сервиса нет в WithServices:
+// для отдельных методов сервиса, который в остальном работает без токена.
+func WithMethods(fullMethods ...string) Option {
+	return func(o *options) {
+		for _, m := range fullMethods {
+			o.methods[m] = struct{}{}
+		}
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{public: make(map[string]struct{}), services: make(map[string]struct{}), methods: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o options) isPublic(fullMethod string) bool {
+	if _, ok := o.public[fullMethod]; ok {
+		return true
+	}
+	if _, ok := o.methods[fullMethod]; ok {
+		return false
+	}
+	if len(o.services) == 0 {
+		return false
+	}
+	// FullMethod - "/package.Service/Method"
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	_, ok := o.services[service]
+	return !ok
+}
+
+// UnaryServerInterceptor ...
+func UnaryServerInterceptor(v Verifier, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if o.isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor ...
+func StreamServerInterceptor(v Verifier, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if o.isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), v)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate достаёт Bearer токен из метаданных и кладёт Principal в контекст.
+func authenticate(ctx context.Context, v Verifier) (context.Context, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	p, err := v.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+		}
+		// auth-service недоступен - не повод считать токен невалидным
+		return nil, status.Error(codes.Unavailable, "auth service unavailable")
+	}
+
+	return NewContext(ctx, p), nil
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ErrMissingToken
+	}
+	vals := md.Get("authorization")
+	if len(vals) == 0 {
+		return "", ErrMissingToken
+	}
+	token := strings.TrimPrefix(vals[0], "Bearer ")
+	if token == "" {
+		return "", ErrMissingToken
+	}
+
+	return token, nil
+}
+
+// wrappedStream позволяет подменить контекст у ServerStream.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context ...
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
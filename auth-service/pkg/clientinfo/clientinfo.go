@@ -0,0 +1,68 @@
+// Package clientinfo - адрес и User-Agent конечного клиента. gateway берёт их из
+// HTTP запроса и передаёт в gRPC метаданных, интерсептор auth-service кладёт их
+// в контекст для журнала аудита. Без gateway используется адрес gRPC пира.
+package clientinfo
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// IPMetadataKey - адрес клиента в gRPC метаданных.
+	IPMetadataKey = "x-client-ip"
+	// UserAgentMetadataKey - User-Agent клиента в gRPC метаданных.
+	UserAgentMetadataKey = "x-client-user-agent"
+
+	// Ограничения на значения снаружи: они пишутся в БД как есть.
+	maxIPLen        = 64
+	maxUserAgentLen = 512
+)
+
+// Info ...
+type Info struct {
+	IP        string
+	UserAgent string
+}
+
+// FromRequest берёт адрес из RemoteAddr: X-Forwarded-For подделывается клиентом,
+// а доверенных прокси перед gateway сейчас нет.
+func FromRequest(r *http.Request) Info {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return Info{IP: ip, UserAgent: r.UserAgent()}.truncate()
+}
+
+// IsMetadataKey - ключ метаданных, который ставит только gateway: такие заголовки
+// от клиента (Grpc-Metadata-X-Client-Ip) пробрасывать нельзя.
+func IsMetadataKey(key string) bool {
+	key = strings.ToLower(key)
+	return key == IPMetadataKey || key == UserAgentMetadataKey
+}
+
+func (i Info) truncate() Info {
+	if len(i.IP) > maxIPLen {
+		i.IP = i.IP[:maxIPLen]
+	}
+	if len(i.UserAgent) > maxUserAgentLen {
+		i.UserAgent = i.UserAgent[:maxUserAgentLen]
+	}
+	return i
+}
+
+type contextKey struct{}
+
+// NewContext ...
+func NewContext(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext ...
+func FromContext(ctx context.Context) (Info, bool) {
+	info, ok := ctx.Value(contextKey{}).(Info)
+	return info, ok
+}
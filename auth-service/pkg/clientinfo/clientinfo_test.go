@@ -0,0 +1,99 @@
+package clientinfo_test
+
+import (
+	"auth/pkg/clientinfo"
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "203.0.113.7:51234"
+	r.Header.Set("User-Agent", "curl/8.5")
+	r.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+	assert.Equal(t, clientinfo.Info{IP: "203.0.113.7", UserAgent: "curl/8.5"}, clientinfo.FromRequest(r))
+
+	r.Header.Set("User-Agent", strings.Repeat("a", 1000))
+	assert.Len(t, clientinfo.FromRequest(r).UserAgent, 512)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := clientinfo.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/Login"}
+	pr := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 4000}}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want clientinfo.Info
+	}{
+		{
+			name: "from gateway",
+			md:   metadata.Pairs(clientinfo.IPMetadataKey, "203.0.113.7", clientinfo.UserAgentMetadataKey, "Firefox", "user-agent", "grpc-go"),
+			want: clientinfo.Info{IP: "203.0.113.7", UserAgent: "Firefox"},
+		},
+		{
+			name: "client-supplied value before gateway's",
+			md: metadata.MD{
+				clientinfo.IPMetadataKey:        {"1.1.1.1", "203.0.113.7"},
+				clientinfo.UserAgentMetadataKey: {"spoofed", "Firefox"},
+			},
+			want: clientinfo.Info{IP: "203.0.113.7", UserAgent: "Firefox"},
+		},
+		{
+			name: "direct call",
+			md:   metadata.Pairs("user-agent", "grpc-go"),
+			want: clientinfo.Info{IP: "10.1.2.3", UserAgent: "grpc-go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), tt.md), pr)
+
+			var got clientinfo.Info
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+				got, _ = clientinfo.FromContext(ctx)
+				return nil, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	// grpc-gateway уже положил в метаданные заголовки клиента Grpc-Metadata-X-Client-*
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs(
+		clientinfo.IPMetadataKey, "1.1.1.1",
+		clientinfo.UserAgentMetadataKey, "spoofed",
+		"x-request-id", "req-1",
+	))
+	ctx = clientinfo.NewContext(ctx, clientinfo.Info{IP: "203.0.113.7", UserAgent: "Firefox"})
+
+	var md metadata.MD
+	err := clientinfo.UnaryClientInterceptor()(ctx, "/auth.v1.AuthService/Login", nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"203.0.113.7"}, md.Get(clientinfo.IPMetadataKey))
+	assert.Equal(t, []string{"Firefox"}, md.Get(clientinfo.UserAgentMetadataKey))
+	assert.Equal(t, []string{"req-1"}, md.Get("x-request-id"))
+}
+
+func TestIsMetadataKey(t *testing.T) {
+	assert.True(t, clientinfo.IsMetadataKey("X-Client-Ip"))
+	assert.True(t, clientinfo.IsMetadataKey("x-client-user-agent"))
+	assert.False(t, clientinfo.IsMetadataKey("x-request-id"))
+}
@@ -0,0 +1,88 @@
+package clientinfo
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// UnaryServerInterceptor кладёт в контекст Info из метаданных gateway,
+// а для прямых вызовов - адрес пира и user-agent gRPC клиента.
+// Если значений несколько, берётся последнее: его дописал gateway, а первые мог
+// прислать сам клиент.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incoming(ctx), req)
+	}
+}
+
+// StreamServerInterceptor ...
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: incoming(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor передаёт Info из контекста дальше в метаданных,
+// заменяя значения этих ключей, пришедшие от клиента.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor ...
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx), desc, cc, method, opts...)
+	}
+}
+
+func incoming(ctx context.Context) context.Context {
+	var info Info
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(IPMetadataKey); len(vals) > 0 {
+		info.IP = vals[len(vals)-1]
+		if vals := md.Get(UserAgentMetadataKey); len(vals) > 0 {
+			info.UserAgent = vals[len(vals)-1]
+		}
+		return NewContext(ctx, info.truncate())
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(info.IP); err == nil {
+			info.IP = host
+		}
+	}
+	if vals := md.Get("user-agent"); len(vals) > 0 {
+		info.UserAgent = vals[0]
+	}
+	return NewContext(ctx, info.truncate())
+}
+
+func outgoing(ctx context.Context) context.Context {
+	info, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(IPMetadataKey, info.IP)
+	md.Set(UserAgentMetadataKey, info.UserAgent)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// wrappedStream позволяет подменить контекст у ServerStream.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context ...
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,122 @@
+// Package healthcheck - стандартный grpc.health.v1 для сервисов со статусом по
+// зависимостям: фоновые проверки (ping БД, Redis, соседних сервисов) переводят
+// сервис в NOT_SERVING, пока хоть одна из них не проходит.
+package healthcheck
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// checkTimeout - сколько ждать одну проверку, чтобы зависшая зависимость
+// не задерживала обновление статуса.
+const checkTimeout = 2 * time.Second
+
+// CheckFunc проверяет зависимость: nil - работает.
+type CheckFunc func(ctx context.Context) error
+
+// Checker ...
+type Checker struct {
+	logger   *slog.Logger
+	server   *health.Server
+	services []string
+	checks   map[string]CheckFunc
+	interval time.Duration
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	// failing - зависимости, которые не прошли прошлую проверку: в лог
+	// попадают только смены состояния, а не каждая проверка.
+	failing map[string]bool
+}
+
+// NewChecker ... services - имена gRPC сервисов ("auth.v1.AuthService"), статус
+// ставится им и пустому имени (весь сервер). До первой проверки все NOT_SERVING.
+func NewChecker(log *slog.Logger, interval time.Duration, checks map[string]CheckFunc, services ...string) *Checker {
+	c := &Checker{
+		logger:   log,
+		server:   health.NewServer(),
+		services: append([]string{""}, services...),
+		checks:   checks,
+		interval: interval,
+		stop:     make(chan struct{}),
+		failing:  make(map[string]bool),
+	}
+	c.set(healthv1.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Register ...
+func (c *Checker) Register(s *grpc.Server) {
+	healthv1.RegisterHealthServer(s, c.server)
+}
+
+// Run проверяет зависимости сразу и дальше раз в interval, до Stop.
+func (c *Checker) Run() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(context.Background())
+
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check выполняет все проверки параллельно и обновляет статус.
+func (c *Checker) Check(ctx context.Context) {
+	type result struct {
+		name string
+		err  error
+	}
+
+	results := make(chan result, len(c.checks))
+	for name, check := range c.checks {
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			results <- result{name: name, err: check(ctx)}
+		}()
+	}
+
+	serving := healthv1.HealthCheckResponse_SERVING
+	for range c.checks {
+		r := <-results
+		if r.err != nil {
+			serving = healthv1.HealthCheckResponse_NOT_SERVING
+			if !c.failing[r.name] {
+				c.logger.Warn("health check failed", slog.String("check", r.name), slog.String("err", r.err.Error()))
+			}
+		} else if c.failing[r.name] {
+			c.logger.Info("health check recovered", slog.String("check", r.name))
+		}
+		c.failing[r.name] = r.err != nil
+	}
+
+	c.set(serving)
+}
+
+// Stop останавливает проверки и навсегда переводит сервис в NOT_SERVING,
+// чтобы клиенты ушли с него до остановки gRPC сервера.
+func (c *Checker) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		c.server.Shutdown()
+	})
+}
+
+func (c *Checker) set(status healthv1.HealthCheckResponse_ServingStatus) {
+	for _, s := range c.services {
+		c.server.SetServingStatus(s, status)
+	}
+}
@@ -0,0 +1,105 @@
+package healthcheck_test
+
+import (
+	"auth/pkg/healthcheck"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const service = "auth.v1.AuthService"
+
+func newClient(t *testing.T, c *healthcheck.Checker) healthv1.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	c.Register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthv1.NewHealthClient(conn)
+}
+
+func status(t *testing.T, client healthv1.HealthClient, svc string) healthv1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	resp, err := client.Check(context.Background(), &healthv1.HealthCheckRequest{Service: svc})
+	require.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestChecker(t *testing.T) {
+	var redisErr error
+	checker := healthcheck.NewChecker(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Hour,
+		map[string]healthcheck.CheckFunc{
+			"postgres": func(context.Context) error { return nil },
+			"redis":    func(context.Context) error { return redisErr },
+		}, service)
+	client := newClient(t, checker)
+
+	// До первой проверки трафик не принимаем
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, client, ""))
+
+	checker.Check(context.Background())
+	assert.Equal(t, healthv1.HealthCheckResponse_SERVING, status(t, client, ""))
+	assert.Equal(t, healthv1.HealthCheckResponse_SERVING, status(t, client, service))
+
+	redisErr = errors.New("connection refused")
+	checker.Check(context.Background())
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, client, ""))
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, client, service))
+
+	redisErr = nil
+	checker.Check(context.Background())
+	assert.Equal(t, healthv1.HealthCheckResponse_SERVING, status(t, client, service))
+
+	// После Stop статус не возвращается в SERVING
+	checker.Stop()
+	checker.Check(context.Background())
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, client, service))
+}
+
+func TestChecker_Run(t *testing.T) {
+	checker := healthcheck.NewChecker(slog.New(slog.NewTextHandler(io.Discard, nil)), time.Hour,
+		map[string]healthcheck.CheckFunc{
+			"postgres": func(context.Context) error { return nil },
+		}, service)
+	client := newClient(t, checker)
+
+	done := make(chan struct{})
+	go func() {
+		checker.Run()
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		resp, err := client.Check(context.Background(), &healthv1.HealthCheckRequest{Service: service})
+		return err == nil && resp.GetStatus() == healthv1.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	checker.Stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after Stop")
+	}
+}
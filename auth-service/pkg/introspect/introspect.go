@@ -0,0 +1,67 @@
+// Package introspect - клиент IntrospectToken для других сервисов:
+// проверка access токена одним вызовом auth-service и gRPC интерсепторы поверх него.
+// Client - это authn.Verifier, Principal кладут в контекст интерсепторы authn.
+package introspect
+
+import (
+	"auth/pkg/authn"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+// Client ...
+type Client struct {
+	api      authv1.AuthServiceClient
+	audience string
+}
+
+// NewClient ... Пустой audience - aud токена не проверяется.
+func NewClient(api authv1.AuthServiceClient, audience string) *Client {
+	return &Client{
+		api:      api,
+		audience: audience,
+	}
+}
+
+// Introspect проверяет подпись, exp, aud и активность сессии токена.
+// Неактивный токен - authn.ErrInvalidToken.
+func (c *Client) Introspect(ctx context.Context, accessToken string) (authn.Principal, error) {
+	const op = "introspect.Introspect"
+
+	resp, err := c.api.IntrospectToken(ctx, &authv1.IntrospectTokenRequest{
+		AccessToken: accessToken,
+		Audience:    c.audience,
+	})
+	if err != nil {
+		return authn.Principal{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if !resp.GetActive() {
+		return authn.Principal{}, fmt.Errorf("%s: %w", op, authn.ErrInvalidToken)
+	}
+
+	return authn.Principal{
+		UserID:    int(resp.GetUserId()),
+		SessionID: int(resp.GetSessionId()),
+		AppID:     int(resp.GetAppId()),
+		Roles:     resp.GetRoles(),
+		ExpiresAt: resp.GetExpiresAt().AsTime(),
+	}, nil
+}
+
+// Verify реализует authn.Verifier.
+func (c *Client) Verify(ctx context.Context, accessToken string) (authn.Principal, error) {
+	return c.Introspect(ctx, accessToken)
+}
+
+// UnaryServerInterceptor ...
+func (c *Client) UnaryServerInterceptor(opts ...authn.Option) grpc.UnaryServerInterceptor {
+	return authn.UnaryServerInterceptor(c, opts...)
+}
+
+// StreamServerInterceptor ...
+func (c *Client) StreamServerInterceptor(opts ...authn.Option) grpc.StreamServerInterceptor {
+	return authn.StreamServerInterceptor(c, opts...)
+}
@@ -0,0 +1,97 @@
+package introspect_test
+
+import (
+	protoMocks "auth/mocks/proto/auth/v1"
+	"auth/pkg/authn"
+	"auth/pkg/introspect"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func incoming(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestUnaryServerInterceptor_Success(t *testing.T) {
+	api := new(protoMocks.AuthServiceClient)
+	ctx := incoming("ACCESS")
+	exp := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	api.
+		On("IntrospectToken", mock.Anything, &authv1.IntrospectTokenRequest{AccessToken: "ACCESS", Audience: "messenger"}).
+		Return(&authv1.IntrospectTokenResponse{
+			Active:    true,
+			UserId:    42,
+			SessionId: 100,
+			AppId:     1,
+			Roles:     []string{"user", "admin"},
+			ExpiresAt: timestamppb.New(exp),
+		}, nil)
+
+	interceptor := introspect.NewClient(api, "messenger").UnaryServerInterceptor()
+
+	var got authn.Principal
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/chat.v1.ChatService/SendMessage"}, func(ctx context.Context, _ any) (any, error) {
+		var ok bool
+		got, ok = authn.FromContext(ctx)
+		require.True(t, ok)
+
+		userID, ok := authn.UserID(ctx)
+		require.True(t, ok)
+		assert.Equal(t, 42, userID)
+		return nil, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, got.UserID)
+	assert.Equal(t, 100, got.SessionID)
+	assert.Equal(t, 1, got.AppID)
+	assert.True(t, got.HasRole("admin"))
+	assert.True(t, exp.Equal(got.ExpiresAt))
+
+	api.AssertExpectations(t)
+}
+
+func TestUnaryServerInterceptor_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		resp     *authv1.IntrospectTokenResponse
+		err      error
+		wantCode codes.Code
+	}{
+		{"no metadata", context.Background(), nil, nil, codes.Unauthenticated},
+		{"inactive token", incoming("EXPIRED"), &authv1.IntrospectTokenResponse{Active: false}, nil, codes.Unauthenticated},
+		{"auth service down", incoming("ACCESS"), nil, errors.New("connection refused"), codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := new(protoMocks.AuthServiceClient)
+			api.
+				On("IntrospectToken", mock.Anything, mock.Anything).
+				Return(tt.resp, tt.err)
+
+			interceptor := introspect.NewClient(api, "").UnaryServerInterceptor()
+
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+				t.Fatal("handler must not be called")
+				return nil, nil
+			})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
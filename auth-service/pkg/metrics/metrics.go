@@ -0,0 +1,136 @@
+// Package metrics - Prometheus метрики, общие для сервисов: gRPC интерцепторы
+// и HTTP сервер /metrics. Всё регистрируется в prometheus.DefaultRegisterer;
+// доменные метрики объявляют сами пакеты рядом с кодом, который их считает.
+//
+// Имена метрик gRPC совпадают с go-grpc-prometheus, чтобы подошли готовые дашборды.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+
+	grpcHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency (seconds) of RPCs handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
+
+// UnaryServerInterceptor считает вызовы по методам и кодам ответа и их длительность.
+// Ставится первым в цепочке, чтобы учитывать и отказы аутентификации и валидации.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe("unary", info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor - то же для стримов: длительность - время жизни стрима.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(streamType(info), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observe(grpcType string, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	code := status.Code(err)
+	if code == codes.Unknown {
+		// Как сервер gRPC: ошибки контекста - Canceled/DeadlineExceeded, а не Unknown
+		code = status.FromContextError(err).Code()
+	}
+	grpcHandled.WithLabelValues(grpcType, service, method, code.String()).Inc()
+	grpcHandlingSeconds.WithLabelValues(grpcType, service, method).Observe(time.Since(start).Seconds())
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
+
+// splitMethod: "/chat.v1.ChatService/Subscribe" -> "chat.v1.ChatService", "Subscribe".
+func splitMethod(fullMethod string) (service string, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
+
+// Handler отдаёт все зарегистрированные метрики в формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Server - отдельный HTTP сервер с /metrics для gRPC сервисов.
+type Server struct {
+	logger *slog.Logger
+	srv    *http.Server
+}
+
+// NewServer ...
+func NewServer(log *slog.Logger, addr string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", Handler())
+
+	return &Server{
+		logger: log,
+		srv: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// Run ...
+func (s *Server) Run() error {
+	const op = "metrics.Run"
+
+	s.logger.Info("metrics server started", slog.String("op", op), slog.String("addr", s.srv.Addr))
+
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Stop ...
+func (s *Server) Stop(ctx context.Context) error {
+	const op = "metrics.Stop"
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package metrics_test
+
+import (
+	"auth/pkg/apierr"
+	"auth/pkg/metrics"
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(rec.Result().Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := metrics.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/Register"}
+
+	_, err := interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+
+	_, err = interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, apierr.Invalid("invalid request")
+	})
+	require.Error(t, err)
+
+	body := scrape(t)
+	assert.Contains(t, body, `grpc_server_handled_total{grpc_code="OK",grpc_method="Register",grpc_service="auth.v1.AuthService",grpc_type="unary"} 1`)
+	assert.Contains(t, body, `grpc_server_handled_total{grpc_code="InvalidArgument",grpc_method="Register",grpc_service="auth.v1.AuthService",grpc_type="unary"} 1`)
+	assert.Contains(t, body, `grpc_server_handling_seconds_count{grpc_method="Register",grpc_service="auth.v1.AuthService",grpc_type="unary"} 2`)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := metrics.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/chat.v1.ChatService/Subscribe", IsServerStream: true}
+
+	err := interceptor(nil, nil, info, func(any, grpc.ServerStream) error {
+		return context.Canceled
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Contains(t, scrape(t), `grpc_server_handled_total{grpc_code="Canceled",grpc_method="Subscribe",grpc_service="chat.v1.ChatService",grpc_type="server_stream"} 1`)
+}
@@ -0,0 +1,317 @@
+// Package oidc ...
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval - не чаще этого JWKS перечитывается из-за неизвестного kid.
+const jwksRefreshInterval = time.Minute
+
+var (
+	// ErrInvalidIDToken ...
+	ErrInvalidIDToken = errors.New("invalid id token")
+)
+
+// Config ...
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Identity - пользователь по данным ID токена провайдера.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type idClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	jwt.RegisteredClaims
+}
+
+// Client - OIDC relying party для одного провайдера: discovery, authorization code + PKCE,
+// проверка ID токена по JWKS провайдера.
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	mu            sync.Mutex
+	meta          *metadata
+	keys          map[string]*rsa.PublicKey
+	keysFetchedAt time.Time
+
+	// refreshMu - JWKS перечитывает один запрос, остальные ждут его результата
+	refreshMu sync.Mutex
+}
+
+// NewClient ...
+func NewClient(cfg Config, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email"}
+	}
+
+	return &Client{
+		cfg:  cfg,
+		http: httpClient,
+	}
+}
+
+// AuthCodeURL строит адрес страницы входа провайдера.
+func (c *Client) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	const op = "oidc.AuthCodeURL"
+
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	u, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", c.cfg.ClientID)
+	q.Set("redirect_uri", c.cfg.RedirectURL)
+	q.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange меняет code на токены провайдера и возвращает проверенного пользователя из ID токена.
+func (c *Client) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (Identity, error) {
+	const op = "oidc.Exchange"
+
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"client_id":     {c.cfg.ClientID},
+		"client_secret": {c.cfg.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tok struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := c.doJSON(req, &tok); err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if tok.IDToken == "" {
+		return Identity{}, fmt.Errorf("%s: %w: no id_token in response", op, ErrInvalidIDToken)
+	}
+
+	claims, err := c.verify(ctx, meta, tok.IDToken)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if claims.Nonce != nonce {
+		return Identity{}, fmt.Errorf("%s: %w: nonce mismatch", op, ErrInvalidIDToken)
+	}
+
+	return Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
+
+func (c *Client) verify(ctx context.Context, meta *metadata, idToken string) (idClaims, error) {
+	var claims idClaims
+
+	_, err := jwt.ParseWithClaims(idToken, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, meta, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(c.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return idClaims{}, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+	if claims.Subject == "" {
+		return idClaims{}, fmt.Errorf("%w: empty sub", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// key ищет ключ по kid; неизвестный kid - повод перечитать JWKS (ротация ключей у провайдера),
+// но не чаще jwksRefreshInterval: иначе поток токенов с выдуманным kid превращается
+// в поток запросов к провайдеру.
+func (c *Client) key(ctx context.Context, meta *metadata, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	// Пока ждали, JWKS мог перечитать другой запрос
+	c.mu.Lock()
+	key, ok = c.keys[kid]
+	fresh := !c.keysFetchedAt.IsZero() && time.Since(c.keysFetchedAt) < jwksRefreshInterval
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if fresh {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keys, err := c.fetchKeys(ctx, meta.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.keysFetchedAt = time.Now()
+	c.mu.Unlock()
+
+	key, ok = keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (c *Client) fetchKeys(ctx context.Context, jwksURI string) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := c.doJSON(req, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
+
+// discover читает /.well-known/openid-configuration один раз на время жизни клиента.
+func (c *Client) discover(ctx context.Context) (*metadata, error) {
+	c.mu.Lock()
+	meta := c.meta
+	c.mu.Unlock()
+	if meta != nil {
+		return meta, nil
+	}
+
+	wellKnown := strings.TrimRight(c.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	meta = &metadata{}
+	if err := c.doJSON(req, meta); err != nil {
+		return nil, err
+	}
+	if meta.Issuer != strings.TrimRight(c.cfg.Issuer, "/") && meta.Issuer != c.cfg.Issuer {
+		return nil, fmt.Errorf("issuer mismatch: %q", meta.Issuer)
+	}
+
+	c.mu.Lock()
+	c.meta = meta
+	c.mu.Unlock()
+
+	return meta, nil
+}
+
+func (c *Client) doJSON(req *http.Request, v any) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: unexpected status %d", req.Method, req.URL.Redacted(), resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,182 @@
+package oidc_test
+
+import (
+	"auth/pkg/oidc"
+	tokenjwt "auth/pkg/token"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider - локальная замена внешнего OIDC провайдера.
+type fakeProvider struct {
+	srv    *httptest.Server
+	signer tokenjwt.IDTokenSigner
+	issuer string
+
+	// rogue - подписывает ID токен ключом, которого нет в JWKS
+	rogue     *tokenjwt.IDTokenSigner
+	jwksCalls atomic.Int32
+
+	// что вернёт token endpoint
+	nonce    string
+	audience string
+	email    string
+
+	gotForm url.Values
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+
+	key, err := tokenjwt.LoadRSAKey("")
+	require.NoError(t, err)
+
+	p := &fakeProvider{audience: "client-1", email: "user@example.org"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.issuer,
+			"authorization_endpoint": p.issuer + "/authorize",
+			"token_endpoint":         p.issuer + "/token",
+			"jwks_uri":               p.issuer + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, _ *http.Request) {
+		p.jwksCalls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": p.signer.JWKS()})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		p.gotForm = r.PostForm
+
+		signer := p.signer
+		if p.rogue != nil {
+			signer = *p.rogue
+		}
+		idToken, err := signer.CreateIDToken("ext-sub-1", p.audience, p.nonce, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "provider-access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	p.srv = httptest.NewServer(mux)
+	t.Cleanup(p.srv.Close)
+
+	p.issuer = p.srv.URL
+	p.signer = tokenjwt.NewIDTokenSigner(key, p.issuer)
+
+	return p
+}
+
+func (p *fakeProvider) client() *oidc.Client {
+	return oidc.NewClient(oidc.Config{
+		Issuer:       p.issuer,
+		ClientID:     "client-1",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:8080/auth/external/fake/callback",
+	}, p.srv.Client())
+}
+
+func TestClient_AuthCodeURL(t *testing.T) {
+	p := newFakeProvider(t)
+
+	authURL, err := p.client().AuthCodeURL(context.Background(), "STATE", "NONCE", "CHALLENGE")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+
+	q := u.Query()
+	assert.Equal(t, p.issuer+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "client-1", q.Get("client_id"))
+	assert.Equal(t, "STATE", q.Get("state"))
+	assert.Equal(t, "NONCE", q.Get("nonce"))
+	assert.Equal(t, "CHALLENGE", q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, "openid email", q.Get("scope"))
+}
+
+func TestClient_ExchangeSuccess(t *testing.T) {
+	p := newFakeProvider(t)
+	p.nonce = "NONCE"
+
+	identity, err := p.client().Exchange(context.Background(), "CODE", "VERIFIER", "NONCE")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ext-sub-1", identity.Subject)
+	assert.Equal(t, "CODE", p.gotForm.Get("code"))
+	assert.Equal(t, "VERIFIER", p.gotForm.Get("code_verifier"))
+	assert.Equal(t, "authorization_code", p.gotForm.Get("grant_type"))
+}
+
+func TestClient_ExchangeNonceMismatch(t *testing.T) {
+	p := newFakeProvider(t)
+	p.nonce = "OTHER"
+
+	_, err := p.client().Exchange(context.Background(), "CODE", "VERIFIER", "NONCE")
+	require.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
+
+func TestClient_ExchangeWrongAudience(t *testing.T) {
+	p := newFakeProvider(t)
+	p.nonce = "NONCE"
+	p.audience = "someone-else"
+
+	_, err := p.client().Exchange(context.Background(), "CODE", "VERIFIER", "NONCE")
+	require.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	require.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+}
+
+func TestClient_ExchangeUnknownKidRefreshLimit(t *testing.T) {
+	p := newFakeProvider(t)
+	p.nonce = "NONCE"
+	c := p.client()
+
+	_, err := c.Exchange(context.Background(), "CODE", "VERIFIER", "NONCE")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), p.jwksCalls.Load())
+
+	key, err := tokenjwt.LoadRSAKey("")
+	require.NoError(t, err)
+	rogue := tokenjwt.NewIDTokenSigner(key, p.issuer)
+	p.rogue = &rogue
+
+	// Неизвестный kid сразу после чтения JWKS не заставляет перечитывать его снова
+	for range 3 {
+		_, err = c.Exchange(context.Background(), "CODE", "VERIFIER", "NONCE")
+		require.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	}
+	assert.Equal(t, int32(1), p.jwksCalls.Load())
+}
+
+func TestClient_ExchangeUnknownKidFetchesOnce(t *testing.T) {
+	p := newFakeProvider(t)
+	p.nonce = "NONCE"
+	key, err := tokenjwt.LoadRSAKey("")
+	require.NoError(t, err)
+	rogue := tokenjwt.NewIDTokenSigner(key, p.issuer)
+	p.rogue = &rogue
+	c := p.client()
+
+	for range 3 {
+		_, err = c.Exchange(context.Background(), "CODE", "VERIFIER", "NONCE")
+		require.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+	}
+	assert.Equal(t, int32(1), p.jwksCalls.Load())
+}
@@ -0,0 +1,232 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// PrefixLen - длина hex префикса SHA-1, по которому идёт поиск (как в range API Pwned Passwords).
+const PrefixLen = 5
+
+// ErrCorruptCorpus - строка базы не похожа на SHA-1.
+var ErrCorruptCorpus = errors.New("corrupt breached passwords corpus")
+
+// Corpus - локальная база SHA-1 хэшей утёкших паролей.
+//
+// База не загружается в память: это отсортированный по хэшу файл (выгрузка Pwned
+// Passwords "ordered by hash"), в котором строка ищется бинарным поиском по смещениям.
+// Один поиск - несколько десятков коротких чтений, память не зависит от размера базы.
+//
+// Поиск устроен как k-anonymity запрос: по первым PrefixLen символам хэша
+// выбирается диапазон суффиксов (Range), а совпадение проверяется уже на стороне
+// вызывающего. Сам пароль и полный хэш никуда не передаются, так что источник
+// диапазонов можно вынести из процесса, не меняя проверку.
+type Corpus struct {
+	r      io.ReaderAt
+	size   int64
+	closer io.Closer
+}
+
+// OpenCorpus открывает базу из файла (см. NewCorpus). Файл остаётся открытым до Close.
+func OpenCorpus(path string) (*Corpus, error) {
+	const op = "password.OpenCorpus"
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c, err := NewCorpus(f, st.Size())
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	c.closer = f
+
+	return c, nil
+}
+
+// NewCorpus - база из r размером size байт: по SHA-1 (40 hex символов) на строку,
+// строки отсортированы по хэшу, регистр во всём файле один и тот же.
+// Допускается формат выгрузки Pwned Passwords "HASH:COUNT" - счётчик игнорируется,
+// и окончания строк \r\n. Пустые строки и комментарии с # в начале файла пропускаются.
+// Порядок строк не проверяется - это потребовало бы прочитать файл целиком.
+func NewCorpus(r io.ReaderAt, size int64) (*Corpus, error) {
+	c := &Corpus{r: r, size: size}
+
+	// Первая запись должна читаться, иначе это не та база
+	rr, err := c.recordsFrom(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rr.next(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Size - размер базы в байтах.
+func (c *Corpus) Size() int64 {
+	return c.size
+}
+
+// Close закрывает файл базы, открытый OpenCorpus.
+func (c *Corpus) Close() error {
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer.Close()
+}
+
+// Range - суффиксы всех хэшей с данным префиксом (верхний регистр), по возрастанию, без дублей.
+func (c *Corpus) Range(prefix string) ([]string, error) {
+	const op = "password.Corpus.Range"
+
+	prefix = strings.ToUpper(prefix)
+
+	off, err := c.search(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rr, err := c.recordsFrom(off)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var suffixes []string
+	for {
+		hash, err := rr.next()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if !strings.HasPrefix(hash, prefix) {
+			return suffixes, nil
+		}
+		suffix := hash[len(prefix):]
+		if len(suffixes) == 0 || suffixes[len(suffixes)-1] != suffix {
+			suffixes = append(suffixes, suffix)
+		}
+	}
+}
+
+// Contains - пароль есть в базе. Ищется сразу полный хэш, без чтения всего диапазона.
+func (c *Corpus) Contains(password string) (bool, error) {
+	const op = "password.Corpus.Contains"
+
+	sum := sha1.Sum([]byte(password))
+	h := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	hash, err := c.firstNotLess(h)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return hash == h, nil
+}
+
+// firstNotLess - первая запись не меньше key или "", если таких нет.
+func (c *Corpus) firstNotLess(key string) (string, error) {
+	off, err := c.search(key)
+	if err != nil {
+		return "", err
+	}
+	rr, err := c.recordsFrom(off)
+	if err != nil {
+		return "", err
+	}
+	return rr.next()
+}
+
+// search - наименьшее смещение, с которого следующая запись не меньше key.
+// Записи отсортированы, поэтому "запись после смещения меньше key" монотонно
+// меняется с true на false - ищем границу бинарным поиском по байтам.
+func (c *Corpus) search(key string) (int64, error) {
+	lo, hi := int64(0), c.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		rr, err := c.recordsFrom(mid)
+		if err != nil {
+			return 0, err
+		}
+		hash, err := rr.next()
+		if err != nil {
+			return 0, err
+		}
+
+		if hash != "" && hash < key {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// recordReader читает записи базы подряд.
+type recordReader struct {
+	br  *bufio.Reader
+	pos int64
+}
+
+// recordsFrom - записи, начиная с первой строки, которая начинается не раньше off.
+// Для off > 0 пропускается всё до ближайшего перевода строки, чтобы не принять
+// хвост строки за запись; с off-1, чтобы не пропустить строку, начинающуюся ровно с off.
+func (c *Corpus) recordsFrom(off int64) (*recordReader, error) {
+	if off > 0 {
+		off--
+	}
+	rr := &recordReader{
+		br:  bufio.NewReader(io.NewSectionReader(c.r, off, c.size-off)),
+		pos: off,
+	}
+	if off > 0 {
+		skipped, err := rr.br.ReadString('\n')
+		rr.pos += int64(len(skipped))
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	}
+	return rr, nil
+}
+
+// next - хэш следующей записи в верхнем регистре; "" - записей больше нет.
+func (rr *recordReader) next() (string, error) {
+	for {
+		line, err := rr.br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		start := rr.pos
+		rr.pos += int64(len(line))
+
+		s := strings.TrimSpace(line)
+		if s != "" && !strings.HasPrefix(s, "#") {
+			s, _, _ = strings.Cut(s, ":")
+			s = strings.ToUpper(s)
+			if len(s) != sha1.Size*2 {
+				return "", fmt.Errorf("%w: offset %d: expected %d hex characters, got %d", ErrCorruptCorpus, start, sha1.Size*2, len(s))
+			}
+			if _, decodeErr := hex.DecodeString(s); decodeErr != nil {
+				return "", fmt.Errorf("%w: offset %d: %w", ErrCorruptCorpus, start, decodeErr)
+			}
+			return s, nil
+		}
+
+		if err != nil {
+			return "", nil
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package password_test
+
+import (
+	"auth/pkg/password"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCorpus - база из строк как есть (порядок - забота вызывающего).
+func newCorpus(t *testing.T, lines ...string) *password.Corpus {
+	t.Helper()
+
+	data := strings.Join(lines, "\n") + "\n"
+	corpus, err := password.NewCorpus(strings.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	return corpus
+}
+
+func TestCorpus_Contains(t *testing.T) {
+	// Достаточно строк, чтобы бинарный поиск попадал в середину строк и на их границы
+	var hashes []string
+	for i := range 1000 {
+		hashes = append(hashes, sha1Hex("password"+strconv.Itoa(i)))
+	}
+	slices.Sort(hashes)
+
+	lines := make([]string, 0, len(hashes))
+	for i, h := range hashes {
+		lines = append(lines, h+":"+strconv.Itoa(i*7))
+	}
+	corpus := newCorpus(t, lines...)
+
+	for i := range 1000 {
+		found, err := corpus.Contains("password" + strconv.Itoa(i))
+		require.NoError(t, err)
+		require.True(t, found, "password%d", i)
+	}
+
+	for _, pw := range []string{"password1000", "Password1", "", "correct horse battery staple"} {
+		found, err := corpus.Contains(pw)
+		require.NoError(t, err)
+		assert.False(t, found, pw)
+	}
+}
+
+func TestCorpus_Range(t *testing.T) {
+	h := sha1Hex("qwerty")
+	records := []string{strings.ToLower(sha1Hex("123456")), strings.ToLower(h) + ":10", strings.ToLower(h) + ":10"}
+	slices.Sort(records)
+	corpus := newCorpus(t, append([]string{"# top passwords", ""}, records...)...)
+
+	// Диапазон по префиксу содержит только суффиксы, без дублей; регистр не важен
+	suffixes, err := corpus.Range(strings.ToLower(h[:password.PrefixLen]))
+	require.NoError(t, err)
+	assert.Equal(t, []string{h[password.PrefixLen:]}, suffixes)
+
+	found, err := corpus.Contains("qwerty")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = corpus.Contains("Qwerty")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	suffixes, err = corpus.Range("00000")
+	require.NoError(t, err)
+	assert.Empty(t, suffixes)
+}
+
+func TestOpenCorpus(t *testing.T) {
+	hashes := []string{sha1Hex("123456"), sha1Hex("qwerty"), sha1Hex("letmein")}
+	slices.Sort(hashes)
+
+	// Формат выгрузки Pwned Passwords: HASH:COUNT и \r\n, без перевода строки в конце
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(hashes, ":1\r\n")+":1"), 0o600))
+
+	corpus, err := password.OpenCorpus(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, corpus.Close()) }()
+
+	for _, pw := range []string{"123456", "qwerty", "letmein"} {
+		found, err := corpus.Contains(pw)
+		require.NoError(t, err)
+		assert.True(t, found, pw)
+	}
+
+	found, err := corpus.Contains("hunter2")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestNewCorpus_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"short hash", "ABCDEF"},
+		{"not hex", strings.Repeat("Z", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := password.NewCorpus(strings.NewReader(tt.in), int64(len(tt.in)))
+			require.ErrorIs(t, err, password.ErrCorruptCorpus)
+			assert.Contains(t, err.Error(), "offset 0")
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package password
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithm - схема хэширования паролей.
+type Algorithm string
+
+// Поддерживаемые схемы.
+const (
+	Argon2id Algorithm = "argon2id"
+	Bcrypt   Algorithm = "bcrypt"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+	argon2SaltLen  = 16
+	argon2KeyLen   = 32
+)
+
+var (
+	// ErrMismatchedPassword - пароль не подходит к хэшу (или хэш пустой/неизвестного формата).
+	ErrMismatchedPassword = errors.New("password does not match hash")
+	// ErrUnknownAlgorithm ...
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+)
+
+// HashParams - текущая политика хэширования. Нули заменяются значениями по умолчанию:
+// argon2id с параметрами из рекомендаций OWASP (19 MiB, 2 прохода, 1 поток), bcrypt cost 10.
+type HashParams struct {
+	Algorithm     Algorithm
+	BcryptCost    int
+	Argon2Time    uint32 // Число проходов.
+	Argon2Memory  uint32 // В KiB.
+	Argon2Threads uint8
+}
+
+// Hasher хэширует пароли по текущей политике и проверяет хэши любой
+// поддерживаемой схемы. Параметры записываются в сам хэш (PHC формат для
+// argon2id, $2a$cost$ для bcrypt), поэтому политику можно ужесточать:
+// старые хэши продолжают проверяться, а NeedsRehash подсказывает, какие пора пересчитать.
+type Hasher struct {
+	params HashParams
+}
+
+// NewHasher ...
+func NewHasher(params HashParams) (*Hasher, error) {
+	const op = "password.NewHasher"
+
+	if params.Algorithm == "" {
+		params.Algorithm = Argon2id
+	}
+	if params.Algorithm != Argon2id && params.Algorithm != Bcrypt {
+		return nil, fmt.Errorf("%s: %w: %q", op, ErrUnknownAlgorithm, params.Algorithm)
+	}
+	if params.BcryptCost == 0 {
+		params.BcryptCost = bcrypt.DefaultCost
+	}
+	if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("%s: bcrypt cost must be between %d and %d", op, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	if params.Argon2Time == 0 {
+		params.Argon2Time = 2
+	}
+	if params.Argon2Memory == 0 {
+		params.Argon2Memory = 19 * 1024
+	}
+	if params.Argon2Threads == 0 {
+		params.Argon2Threads = 1
+	}
+
+	return &Hasher{params: params}, nil
+}
+
+// Hash хэширует пароль текущей схемой.
+func (h *Hasher) Hash(password string) ([]byte, error) {
+	if h.params.Algorithm == Bcrypt {
+		return bcrypt.GenerateFromPassword([]byte(password), h.params.BcryptCost)
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	p := argon2Hash{
+		time:    h.params.Argon2Time,
+		memory:  h.params.Argon2Memory,
+		threads: h.params.Argon2Threads,
+		salt:    salt,
+	}
+	p.key = argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, argon2KeyLen)
+	return p.encode(), nil
+}
+
+// Compare проверяет пароль по хэшу любой поддерживаемой схемы.
+// Несовпадение и хэш неизвестного формата - ErrMismatchedPassword.
+func (h *Hasher) Compare(hash []byte, password string) error {
+	if bytes.HasPrefix(hash, []byte(argon2idPrefix)) {
+		p, err := decodeArgon2(hash)
+		if err != nil {
+			return ErrMismatchedPassword
+		}
+		key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key))) // #nosec G115 -- длина ключа из нашего же хэша
+		if subtle.ConstantTimeCompare(key, p.key) != 1 {
+			return ErrMismatchedPassword
+		}
+		return nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		return ErrMismatchedPassword
+	}
+	return nil
+}
+
+// NeedsRehash - хэш сделан не текущей схемой или с параметрами слабее текущих.
+// Неразбираемый хэш пересчитывать нечем: пароль к нему всё равно не подойдёт.
+func (h *Hasher) NeedsRehash(hash []byte) bool {
+	if bytes.HasPrefix(hash, []byte(argon2idPrefix)) {
+		if h.params.Algorithm != Argon2id {
+			return true
+		}
+		p, err := decodeArgon2(hash)
+		if err != nil {
+			return false
+		}
+		return p.time < h.params.Argon2Time ||
+			p.memory < h.params.Argon2Memory ||
+			p.threads < h.params.Argon2Threads ||
+			len(p.key) < argon2KeyLen
+	}
+
+	cost, err := bcrypt.Cost(hash)
+	if err != nil {
+		return false
+	}
+	return h.params.Algorithm != Bcrypt || cost < h.params.BcryptCost
+}
+
+// argon2Hash - разобранный хэш в PHC формате:
+// $argon2id$v=19$m=19456,t=2,p=1$<соль base64>$<ключ base64>.
+type argon2Hash struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func (p argon2Hash) encode() []byte {
+	return fmt.Appendf(nil, "%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(p.salt),
+		base64.RawStdEncoding.EncodeToString(p.key),
+	)
+}
+
+func decodeArgon2(hash []byte) (argon2Hash, error) {
+	parts := strings.Split(string(hash), "$")
+	if len(parts) != 6 {
+		return argon2Hash{}, errors.New("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return argon2Hash{}, err
+	}
+	if version != argon2.Version {
+		return argon2Hash{}, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var p argon2Hash
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return argon2Hash{}, err
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return argon2Hash{}, err
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return argon2Hash{}, err
+	}
+	if len(p.key) == 0 || p.time == 0 || p.threads == 0 {
+		return argon2Hash{}, errors.New("invalid argon2id parameters")
+	}
+	return p, nil
+}
@@ -0,0 +1,124 @@
+package password_test
+
+import (
+	"auth/pkg/password"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Маленькие параметры argon2id, чтобы тесты не тормозили
+var fastArgon2 = password.HashParams{Algorithm: password.Argon2id, Argon2Memory: 1024, Argon2Time: 1, Argon2Threads: 1}
+
+func newHasher(t *testing.T, params password.HashParams) *password.Hasher {
+	t.Helper()
+
+	h, err := password.NewHasher(params)
+	require.NoError(t, err)
+	return h
+}
+
+func TestHasher_HashAndCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		params password.HashParams
+		prefix string
+	}{
+		{"argon2id", fastArgon2, "$argon2id$v=19$m=1024,t=1,p=1$"},
+		{"bcrypt", password.HashParams{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}, "$2a$04$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHasher(t, tt.params)
+
+			hash, err := h.Hash("Correct-horse-1")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(hash), tt.prefix), string(hash))
+
+			assert.NoError(t, h.Compare(hash, "Correct-horse-1"))
+			assert.ErrorIs(t, h.Compare(hash, "correct-horse-1"), password.ErrMismatchedPassword)
+			assert.False(t, h.NeedsRehash(hash))
+
+			// Соль случайная - хэши одного пароля различаются
+			again, err := h.Hash("Correct-horse-1")
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, again)
+		})
+	}
+}
+
+func TestHasher_CompareAnyScheme(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	argonHash, err := newHasher(t, fastArgon2).Hash("password")
+	require.NoError(t, err)
+
+	// Текущая схема не важна: проверяется схема из самого хэша
+	for _, h := range []*password.Hasher{
+		newHasher(t, fastArgon2),
+		newHasher(t, password.HashParams{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}),
+	} {
+		assert.NoError(t, h.Compare(bcryptHash, "password"))
+		assert.NoError(t, h.Compare(argonHash, "password"))
+	}
+}
+
+func TestHasher_CompareInvalidHash(t *testing.T) {
+	h := newHasher(t, fastArgon2)
+
+	for _, hash := range []string{
+		"",
+		"plain",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA",
+		"$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5",
+	} {
+		assert.ErrorIs(t, h.Compare([]byte(hash), "password"), password.ErrMismatchedPassword, hash)
+		assert.False(t, h.NeedsRehash([]byte(hash)), hash)
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	bcrypt4, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	bcrypt5, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost+1)
+	require.NoError(t, err)
+	argonWeak, err := newHasher(t, fastArgon2).Hash("password")
+	require.NoError(t, err)
+
+	argonStrong := fastArgon2
+	argonStrong.Argon2Memory = 2048
+
+	tests := []struct {
+		name   string
+		params password.HashParams
+		hash   []byte
+		want   bool
+	}{
+		{"bcrypt cost raised", password.HashParams{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost + 1}, bcrypt4, true},
+		{"bcrypt cost same", password.HashParams{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost + 1}, bcrypt5, false},
+		{"bcrypt cost higher than policy", password.HashParams{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}, bcrypt5, false},
+		{"bcrypt to argon2id", fastArgon2, bcrypt5, true},
+		{"argon2id to bcrypt", password.HashParams{Algorithm: password.Bcrypt, BcryptCost: bcrypt.MinCost}, argonWeak, true},
+		{"argon2id memory raised", argonStrong, argonWeak, true},
+		{"argon2id same", fastArgon2, argonWeak, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newHasher(t, tt.params).NeedsRehash(tt.hash))
+		})
+	}
+}
+
+func TestNewHasher_Invalid(t *testing.T) {
+	_, err := password.NewHasher(password.HashParams{Algorithm: "md5"})
+	assert.ErrorIs(t, err, password.ErrUnknownAlgorithm)
+
+	_, err = password.NewHasher(password.HashParams{Algorithm: password.Bcrypt, BcryptCost: 100})
+	assert.Error(t, err)
+}
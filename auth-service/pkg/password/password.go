@@ -0,0 +1,159 @@
+// Package password - политика паролей: длина, классы символов, запрет пароля,
+// совпадающего с email, и офлайн проверка по базе утёкших паролей (см. Corpus).
+// Одна и та же проверка нужна везде, где пользователь задаёт пароль.
+// Хэширование паролей (argon2id, bcrypt) - Hasher.
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BcryptMaxBytes - bcrypt молча игнорирует (или, в новых версиях x/crypto, отвергает)
+// всё, что длиннее 72 байт, поэтому длиннее пароль быть не может.
+const BcryptMaxBytes = 72
+
+// Rule - стабильный идентификатор правила политики (Reason в нарушении поля).
+type Rule string
+
+// Правила политики.
+const (
+	RuleTooShort    Rule = "PASSWORD_TOO_SHORT"
+	RuleTooLong     Rule = "PASSWORD_TOO_LONG"
+	RuleNoLowercase Rule = "PASSWORD_NO_LOWERCASE"
+	RuleNoUppercase Rule = "PASSWORD_NO_UPPERCASE"
+	RuleNoDigit     Rule = "PASSWORD_NO_DIGIT"
+	RuleNoSymbol    Rule = "PASSWORD_NO_SYMBOL"
+	RuleEqualsEmail Rule = "PASSWORD_EQUALS_EMAIL"
+	RuleBreached    Rule = "PASSWORD_BREACHED"
+)
+
+// ErrWeakPassword ...
+var ErrWeakPassword = errors.New("password does not meet policy")
+
+// Violation - нарушенное правило и понятное пользователю описание.
+type Violation struct {
+	Rule    Rule
+	Message string
+}
+
+// PolicyError - пароль не прошёл политику. Содержит все нарушения сразу,
+// чтобы клиент мог показать их одним списком. errors.Is(err, ErrWeakPassword) == true.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	rules := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		rules = append(rules, string(v.Rule))
+	}
+	return fmt.Sprintf("%s: %s", ErrWeakPassword, strings.Join(rules, ", "))
+}
+
+// Is ...
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
+
+// Policy - настройки политики. Нулевое значение проверяет только MaxBytes.
+type Policy struct {
+	MinLength     int // В символах, не в байтах.
+	MaxBytes      int // 0 или больше BcryptMaxBytes - BcryptMaxBytes.
+	RequireLower  bool
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// Checker проверяет пароль по политике и, если задан corpus, по базе утёкших паролей.
+type Checker struct {
+	policy Policy
+	corpus *Corpus
+}
+
+// NewChecker ... corpus может быть nil - тогда проверка на утечку выключена.
+func NewChecker(policy Policy, corpus *Corpus) *Checker {
+	if policy.MaxBytes <= 0 || policy.MaxBytes > BcryptMaxBytes {
+		policy.MaxBytes = BcryptMaxBytes
+	}
+	return &Checker{policy: policy, corpus: corpus}
+}
+
+// Check возвращает *PolicyError со всеми нарушенными правилами или nil.
+// Другая ошибка - базу утечек не удалось прочитать.
+func (c *Checker) Check(email string, password string) error {
+	const op = "password.Checker.Check"
+
+	var violations []Violation
+	add := func(rule Rule, format string, args ...any) {
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if utf8.RuneCountInString(password) < c.policy.MinLength {
+		add(RuleTooShort, "must be at least %d characters long", c.policy.MinLength)
+	}
+	if len(password) > c.policy.MaxBytes {
+		add(RuleTooLong, "must be at most %d bytes long", c.policy.MaxBytes)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	if c.policy.RequireLower && !lower {
+		add(RuleNoLowercase, "must contain a lowercase letter")
+	}
+	if c.policy.RequireUpper && !upper {
+		add(RuleNoUppercase, "must contain an uppercase letter")
+	}
+	if c.policy.RequireDigit && !digit {
+		add(RuleNoDigit, "must contain a digit")
+	}
+	if c.policy.RequireSymbol && !symbol {
+		add(RuleNoSymbol, "must contain a symbol")
+	}
+
+	if equalsEmail(email, password) {
+		add(RuleEqualsEmail, "must not be the same as the email")
+	}
+
+	if c.corpus != nil {
+		breached, err := c.corpus.Contains(password)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if breached {
+			add(RuleBreached, "has appeared in a data breach, choose another one")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// equalsEmail - пароль совпадает с email или его локальной частью без учёта регистра.
+func equalsEmail(email string, password string) bool {
+	if email == "" || password == "" {
+		return false
+	}
+	if strings.EqualFold(password, email) {
+		return true
+	}
+	local, _, ok := strings.Cut(email, "@")
+	return ok && strings.EqualFold(password, local)
+}
@@ -0,0 +1,116 @@
+package password_test
+
+import (
+	"auth/pkg/password"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func rules(t *testing.T, err error) []password.Rule {
+	t.Helper()
+
+	var policyErr *password.PolicyError
+	require.True(t, errors.As(err, &policyErr), "expected *PolicyError, got %v", err)
+
+	got := make([]password.Rule, 0, len(policyErr.Violations))
+	for _, v := range policyErr.Violations {
+		assert.NotEmpty(t, v.Message)
+		got = append(got, v.Rule)
+	}
+	return got
+}
+
+func TestChecker_Check(t *testing.T) {
+	policy := password.Policy{
+		MinLength:     8,
+		RequireLower:  true,
+		RequireUpper:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		want     []password.Rule
+	}{
+		{"valid", "user@example.org", "Correct-horse-1", nil},
+		{"too short", "user@example.org", "Ab-1", []password.Rule{password.RuleTooShort}},
+		{"too long for bcrypt", "user@example.org", "Aa-1" + strings.Repeat("x", 69), []password.Rule{password.RuleTooLong}},
+		{"no lowercase", "user@example.org", "CORRECT-HORSE-1", []password.Rule{password.RuleNoLowercase}},
+		{"no uppercase", "user@example.org", "correct-horse-1", []password.Rule{password.RuleNoUppercase}},
+		{"no digit", "user@example.org", "Correct-horse", []password.Rule{password.RuleNoDigit}},
+		{"no symbol", "user@example.org", "CorrectHorse1", []password.Rule{password.RuleNoSymbol}},
+		{"equals email", "Long.User-1@example.org", "long.user-1@EXAMPLE.org", []password.Rule{password.RuleEqualsEmail}},
+		{"equals email local part", "Long.User-1@example.org", "LONG.user-1", []password.Rule{password.RuleEqualsEmail}},
+		{
+			"all at once", "user@example.org", "abc",
+			[]password.Rule{password.RuleTooShort, password.RuleNoUppercase, password.RuleNoDigit, password.RuleNoSymbol},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := password.NewChecker(policy, nil).Check(tt.email, tt.password)
+
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, password.ErrWeakPassword)
+			assert.Equal(t, tt.want, rules(t, err))
+		})
+	}
+}
+
+func TestChecker_MaxBytesCappedByBcrypt(t *testing.T) {
+	checker := password.NewChecker(password.Policy{MaxBytes: 1000}, nil)
+
+	require.NoError(t, checker.Check("", strings.Repeat("x", password.BcryptMaxBytes)))
+
+	err := checker.Check("", strings.Repeat("x", password.BcryptMaxBytes+1))
+	assert.Equal(t, []password.Rule{password.RuleTooLong}, rules(t, err))
+}
+
+func TestChecker_MinLengthCountsRunes(t *testing.T) {
+	checker := password.NewChecker(password.Policy{MinLength: 8}, nil)
+
+	// 8 символов, но 16 байт
+	assert.NoError(t, checker.Check("", "пароль12"))
+}
+
+func TestChecker_Breached(t *testing.T) {
+	corpus := newCorpus(t, sha1Hex("Password1!")+":3861493")
+
+	checker := password.NewChecker(password.Policy{MinLength: 8}, corpus)
+
+	err := checker.Check("user@example.org", "Password1!")
+	assert.Equal(t, []password.Rule{password.RuleBreached}, rules(t, err))
+
+	assert.NoError(t, checker.Check("user@example.org", "Correct-horse-1"))
+}
+
+func TestChecker_CorruptCorpus(t *testing.T) {
+	data := sha1Hex("a") + "\nnot a hash\n"
+	corpus, err := password.NewCorpus(strings.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	checker := password.NewChecker(password.Policy{MinLength: 8}, corpus)
+
+	// Ошибка чтения базы - не нарушение политики, а отказ проверки
+	err = checker.Check("user@example.org", "Correct-horse-1")
+	require.ErrorIs(t, err, password.ErrCorruptCorpus)
+	assert.False(t, errors.Is(err, password.ErrWeakPassword))
+}
@@ -0,0 +1,58 @@
+// Package requestid - сквозной идентификатор запроса: gateway получает или создаёт
+// X-Request-ID, передаёт его в gRPC метаданных, интерсепторы сервисов кладут его
+// в контекст, а Handler дописывает его в каждую запись лога с этим контекстом.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const (
+	// Header - HTTP заголовок запроса и ответа gateway.
+	Header = "X-Request-ID"
+	// MetadataKey - ключ в gRPC метаданных.
+	MetadataKey = "x-request-id"
+	// LogKey - атрибут в логах.
+	LogKey = "request_id"
+
+	maxLen = 128
+)
+
+// New генерирует случайный ID: 16 байт в hex.
+func New() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // crypto/rand.Read не возвращает ошибку
+	return hex.EncodeToString(b)
+}
+
+// Valid проверяет ID, пришедший снаружи: он попадёт в логи и заголовки,
+// поэтому только короткая строка из букв, цифр и "-_.:".
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for _, c := range []byte(id) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type contextKey struct{}
+
+// NewContext ...
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext ...
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}
@@ -0,0 +1,89 @@
+package requestid_test
+
+import (
+	"auth/pkg/requestid"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestValid(t *testing.T) {
+	assert.True(t, requestid.Valid(requestid.New()))
+	assert.True(t, requestid.Valid("5f0c7c1e-3b1a-4a7e-9d51-2f7c0d1e8b2a"))
+	assert.False(t, requestid.Valid(""))
+	assert.False(t, requestid.Valid("id with spaces"))
+	assert.False(t, requestid.Valid("id\nforged=log"))
+	assert.False(t, requestid.Valid(strings.Repeat("a", 129)))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := requestid.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/ValidateSession"}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string
+	}{
+		{name: "from metadata", md: metadata.Pairs(requestid.MetadataKey, "req-1"), want: "req-1"},
+		{name: "missing", md: metadata.MD{}},
+		{name: "invalid", md: metadata.Pairs(requestid.MetadataKey, "bad id")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var got string
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+				got, _ = requestid.FromContext(ctx)
+				return nil, nil
+			})
+			require.NoError(t, err)
+
+			if tt.want != "" {
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.True(t, requestid.Valid(got))
+				assert.NotEqual(t, "bad id", got)
+			}
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := requestid.UnaryClientInterceptor()
+	ctx := requestid.NewContext(context.Background(), "req-1")
+
+	err := interceptor(ctx, "/auth.v1.AuthService/ValidateSession", nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Equal(t, []string{"req-1"}, md.Get(requestid.MetadataKey))
+			return nil
+		})
+	require.NoError(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(requestid.NewHandler(slog.NewJSONHandler(&buf, nil)))
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	logger.With(slog.String("op", "AuthUseCase.Login")).InfoContext(ctx, "attempting to login user")
+
+	var rec map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	assert.Equal(t, "req-1", rec[requestid.LogKey])
+	assert.Equal(t, "AuthUseCase.Login", rec["op"])
+
+	buf.Reset()
+	logger.Info("no context")
+	assert.NotContains(t, buf.String(), requestid.LogKey)
+}
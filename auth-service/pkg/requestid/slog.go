@@ -0,0 +1,41 @@
+package requestid
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Handler дописывает request_id из контекста к каждой записи. Логгеры из
+// logger.With(...) наследуют его, так что достаточно писать через InfoContext(ctx, ...)
+// и остальные *Context методы.
+type Handler struct {
+	next slog.Handler
+}
+
+// NewHandler ...
+func NewHandler(next slog.Handler) *Handler {
+	return &Handler{next: next}
+}
+
+// Enabled ...
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle ...
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := FromContext(ctx); ok {
+		r.AddAttrs(slog.String(LogKey, id))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs ...
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup ...
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name)}
+}
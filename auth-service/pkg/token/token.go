@@ -51,7 +51,7 @@ type UserAccessDate struct {
 }
 
 // CreateAccessToken ...
-func (p TokenProvider) CreateAccessToken(userID int, sessionID int, appID int, accExp time.Time) (accToken string, err error) {
+func (p TokenProvider) CreateAccessToken(userID int, sessionID int, appID int, audience string, accExp time.Time) (accToken string, err error) {
 	const op = "TokenProvider.CreateAccessToken"
 
 	claims := AccessClaims{
@@ -62,6 +62,9 @@ func (p TokenProvider) CreateAccessToken(userID int, sessionID int, appID int, a
 			ExpiresAt: jwt.NewNumericDate(accExp),
 		},
 	}
+	if audience != "" {
+		claims.Audience = jwt.ClaimStrings{audience}
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -73,6 +76,44 @@ func (p TokenProvider) CreateAccessToken(userID int, sessionID int, appID int, a
 	return accessTokenStr, nil
 }
 
+// CreateClientAccessToken выдаёт токен для client_credentials: без пользователя и сессии.
+func (p TokenProvider) CreateClientAccessToken(appID int, clientID string, accExp time.Time) (accToken string, err error) {
+	const op = "TokenProvider.CreateClientAccessToken"
+
+	claims := AccessClaims{
+		AppID: appID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   clientID,
+			Audience:  jwt.ClaimStrings{clientID},
+			ExpiresAt: jwt.NewNumericDate(accExp),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	accessTokenStr, err := token.SignedString([]byte(p.jwtSecret))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return accessTokenStr, nil
+}
+
+// ParseAccessToken проверяет подпись и exp access токена.
+func (p TokenProvider) ParseAccessToken(accToken string) (AccessClaims, error) {
+	const op = "TokenProvider.ParseAccessToken"
+
+	var claims AccessClaims
+	_, err := jwt.ParseWithClaims(accToken, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(p.jwtSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return AccessClaims{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return claims, nil
+}
+
 // CreateRefreshToken ...
 func (p TokenProvider) CreateRefreshToken() (refToken string, err error) {
 	bytes := make([]byte, refreshTokenBytes)
@@ -3,6 +3,9 @@ package tokenjwt_test
 
 import (
 	tokenjwt "auth/pkg/token"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
 	"testing"
 	"time"
 
@@ -18,6 +21,7 @@ var (
 		userID:    42,
 		sessionID: 10,
 		appID:     1,
+		audience:  "messenger",
 		accExp:    time.Now().Add(time.Minute * 15),
 	}
 )
@@ -26,11 +30,12 @@ type testUser struct {
 	userID    int
 	sessionID int
 	appID     int
+	audience  string
 	accExp    time.Time
 }
 
 func TestCreateAccessToken_Success(t *testing.T) {
-	accToken, err := provider.CreateAccessToken(user.userID, user.sessionID, user.appID, user.accExp)
+	accToken, err := provider.CreateAccessToken(user.userID, user.sessionID, user.appID, user.audience, user.accExp)
 
 	require.NoError(t, err)
 	require.NotEmpty(t, accToken)
@@ -54,10 +59,63 @@ func TestCreateAccessToken_Success(t *testing.T) {
 	require.True(t, ok)
 	appid, ok := claims["app_id"].(float64)
 	require.True(t, ok)
+	aud, err := claims.GetAudience()
+	require.NoError(t, err)
 
 	require.Greater(t, int64(exp), time.Now().Unix())
 	assert.Equal(t, float64(user.userID), userid)
 	assert.Equal(t, float64(user.sessionID), sessionid)
 	assert.Equal(t, float64(user.appID), appid)
+	assert.Equal(t, jwt.ClaimStrings{user.audience}, aud)
+
+}
+
+func TestCreateIDToken_VerifiesWithJWKS(t *testing.T) {
+	key, err := tokenjwt.LoadRSAKey("")
+	require.NoError(t, err)
+
+	signer := tokenjwt.NewIDTokenSigner(key, "http://localhost:8080")
+
+	idToken, err := signer.CreateIDToken("42", "web", "nonce", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	jwks := signer.JWKS()
+	require.Len(t, jwks, 1)
+
+	n, err := base64.RawURLEncoding.DecodeString(jwks[0].N)
+	require.NoError(t, err)
+	e, err := base64.RawURLEncoding.DecodeString(jwks[0].E)
+	require.NoError(t, err)
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
 
+	var claims tokenjwt.IDClaims
+	token, err := jwt.ParseWithClaims(idToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		require.Equal(t, jwks[0].Kid, token.Header["kid"])
+		return pub, nil
+	}, jwt.WithValidMethods([]string{"RS256"}), jwt.WithIssuer("http://localhost:8080"), jwt.WithAudience("web"))
+
+	require.NoError(t, err)
+	require.True(t, token.Valid)
+	assert.Equal(t, "42", claims.Subject)
+	assert.Equal(t, "nonce", claims.Nonce)
+}
+
+func TestParseAccessToken_RejectsForeignSecret(t *testing.T) {
+	accToken, err := tokenjwt.NewTokenProvider("other").CreateAccessToken(user.userID, user.sessionID, user.appID, user.audience, user.accExp)
+	require.NoError(t, err)
+
+	_, err = provider.ParseAccessToken(accToken)
+	require.Error(t, err)
+
+	claims, err := provider.ParseAccessToken(mustAccessToken(t))
+	require.NoError(t, err)
+	assert.Equal(t, user.userID, claims.UserID)
+	assert.Equal(t, user.sessionID, claims.SessionID)
+}
+
+func mustAccessToken(t *testing.T) string {
+	t.Helper()
+	accToken, err := provider.CreateAccessToken(user.userID, user.sessionID, user.appID, user.audience, user.accExp)
+	require.NoError(t, err)
+	return accToken
 }
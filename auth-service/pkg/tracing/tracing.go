@@ -0,0 +1,99 @@
+// Package tracing - OpenTelemetry трейсинг, общий для сервисов: провайдер с экспортером
+// из конфига и W3C Trace Context для gRPC. Всё ставится в глобальный otel, поэтому
+// инструментация SQL и Redis подхватывает провайдер без явной передачи.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"google.golang.org/grpc"
+)
+
+// Экспортеры.
+const (
+	ExporterNone   = ""
+	ExporterOTLP   = "otlp"   // OTLP/gRPC: коллектор, Jaeger, Tempo
+	ExporterStdout = "stdout" // JSON в stdout для локального запуска
+)
+
+// Config - куда отправлять спаны.
+type Config struct {
+	Exporter string
+	Endpoint string // host:port коллектора для otlp.
+	Insecure bool   // otlp без TLS.
+	// SampleRatio - доля новых трейсов, которые записываются (0..1). Если трейс начат
+	// выше по цепочке, решение берётся из родителя, чтобы трейс не рвался между сервисами.
+	SampleRatio float64
+}
+
+// Setup ставит глобальные propagator и TracerProvider. Возвращённый shutdown
+// досылает накопленные спаны - его нужно вызвать при остановке сервиса.
+// С пустым Exporter спаны не пишутся, но traceparent всё равно передаётся дальше.
+func Setup(ctx context.Context, serviceName string, cfg Config) (func(context.Context) error, error) {
+	const op = "tracing.Setup"
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("%s: unknown exporter %q", op, cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// OTEL_RESOURCE_ATTRIBUTES и OTEL_SERVICE_NAME перекрывают имя из кода
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// ServerOption - спан на каждый входящий вызов, продолжает трейс из метаданных клиента.
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
+
+// DialOption - спан на каждый исходящий вызов и traceparent в метаданных запроса.
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
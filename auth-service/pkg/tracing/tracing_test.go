@@ -0,0 +1,75 @@
+package tracing_test
+
+import (
+	"auth/pkg/tracing"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), "test", tracing.Config{Exporter: "zipkin"})
+	require.Error(t, err)
+}
+
+func TestPropagation(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), "test", tracing.Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = shutdown(context.Background()) })
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(tracing.ServerOption())
+	healthv1.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		tracing.DialOption(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, root := tp.Tracer("test").Start(context.Background(), "POST /chat/send")
+	_, err = healthv1.NewHealthClient(conn).Check(ctx, &healthv1.HealthCheckRequest{})
+	require.NoError(t, err)
+	root.End()
+	require.NoError(t, tp.ForceFlush(context.Background()))
+
+	var client, server sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		switch s.SpanKind() {
+		case trace.SpanKindClient:
+			client = s
+		case trace.SpanKindServer:
+			server = s
+		}
+	}
+	require.NotNil(t, client)
+	require.NotNil(t, server)
+
+	// Один трейс: корень -> клиентский вызов -> серверный спан по traceparent из метаданных
+	traceID := root.SpanContext().TraceID()
+	assert.Equal(t, traceID, client.SpanContext().TraceID())
+	assert.Equal(t, traceID, server.SpanContext().TraceID())
+	assert.Equal(t, root.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.True(t, server.Parent().IsRemote())
+}
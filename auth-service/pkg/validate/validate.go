@@ -0,0 +1,74 @@
+// Package validate - gRPC интерцепторы, которые проверяют запросы по правилам
+// buf.validate из .proto до вызова хендлера. Нарушения уходят клиенту как
+// InvalidArgument с кодом VALIDATION_FAILED и списком полей (см. apierr).
+package validate
+
+import (
+	"auth/pkg/apierr"
+	"context"
+	"errors"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor ...
+func UnaryServerInterceptor(v protovalidate.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := check(v, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor проверяет каждое сообщение, которое хендлер читает из стрима.
+func StreamServerInterceptor(v protovalidate.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatedStream{ServerStream: ss, validator: v})
+	}
+}
+
+type validatedStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+}
+
+func (s *validatedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return check(s.validator, m)
+}
+
+func check(v protovalidate.Validator, req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	err := v.Validate(msg)
+	if err == nil {
+		return nil
+	}
+	return Error(err)
+}
+
+// Error переводит ошибку protovalidate в gRPC статус.
+// Ошибка в самих правилах (не скомпилировались) - это баг сервиса, а не клиента.
+func Error(err error) error {
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return apierr.Internal()
+	}
+
+	violations := make([]apierr.FieldViolation, 0, len(verr.Violations))
+	for _, v := range verr.Violations {
+		violations = append(violations, apierr.FieldViolation{
+			Field:       protovalidate.FieldPathString(v.Proto.GetField()),
+			Description: v.Proto.GetMessage(),
+		})
+	}
+	return apierr.Invalid("invalid request", violations...)
+}
@@ -0,0 +1,84 @@
+package validate_test
+
+import (
+	"auth/pkg/apierr"
+	"auth/pkg/validate"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"testing"
+
+	"buf.build/go/protovalidate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeStream struct {
+	grpc.ServerStream
+	req proto.Message
+}
+
+func (s *fakeStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestUnaryServerInterceptor_Invalid(t *testing.T) {
+	interceptor := validate.UnaryServerInterceptor(protovalidate.GlobalValidator)
+
+	req := &authv1.RegisterRequest{Email: "not-an-email", Password: ""}
+	_, err := interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/auth.v1.AuthService/Register"}, func(context.Context, any) (any, error) {
+		t.Fatal("handler must not be called")
+		return nil, nil
+	})
+
+	apiErr := apierr.Parse(err)
+	assert.Equal(t, codes.InvalidArgument, apiErr.Status)
+	assert.Equal(t, apierr.CodeValidation, apiErr.Code)
+	require.Len(t, apiErr.Violations, 2)
+	assert.Equal(t, "email", apiErr.Violations[0].Field)
+	assert.Equal(t, "password", apiErr.Violations[1].Field)
+	assert.NotEmpty(t, apiErr.Violations[0].Description)
+}
+
+func TestUnaryServerInterceptor_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  proto.Message
+	}{
+		{"valid register", &authv1.RegisterRequest{Email: "user@example.org", Password: "password"}},
+		// У OAuthService нет правил: ошибки там по RFC 6749, их отдаёт сам сервис
+		{"message without rules", &authv1.TokenRequest{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := validate.UnaryServerInterceptor(protovalidate.GlobalValidator)
+
+			called := false
+			_, err := interceptor(context.Background(), tt.req, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+				called = true
+				return nil, nil
+			})
+
+			require.NoError(t, err)
+			assert.True(t, called)
+		})
+	}
+}
+
+func TestStreamServerInterceptor_Invalid(t *testing.T) {
+	interceptor := validate.StreamServerInterceptor(protovalidate.GlobalValidator)
+
+	stream := &fakeStream{req: &authv1.IsAdminRequest{UserId: -1}}
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{}, func(_ any, ss grpc.ServerStream) error {
+		return ss.RecvMsg(&authv1.IsAdminRequest{})
+	})
+
+	apiErr := apierr.Parse(err)
+	assert.Equal(t, codes.InvalidArgument, apiErr.Status)
+	require.Len(t, apiErr.Violations, 1)
+	assert.Equal(t, "user_id", apiErr.Violations[0].Field)
+}
@@ -0,0 +1,12 @@
+package provider
+
+import (
+	"auth/internal/domain"
+	"context"
+)
+
+// AuditLogger пишет события безопасности в журнал аудита. Ошибка записи не должна
+// ломать вход или выход пользователя, поэтому Record её не возвращает.
+type AuditLogger interface {
+	Record(ctx context.Context, event domain.AuditEvent)
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"auth/pkg/oidc"
+	"context"
+	"errors"
+)
+
+var (
+	// ErrUnknownIdentityProvider ...
+	ErrUnknownIdentityProvider = errors.New("unknown identity provider")
+	// ErrExternalLoginFailed ...
+	ErrExternalLoginFailed = errors.New("external login failed")
+)
+
+// IdentityProvider - внешний OIDC провайдер для входа без пароля.
+type IdentityProvider interface {
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (authURL string, err error)
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (identity oidc.Identity, err error)
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	tokenjwt "auth/pkg/token"
+	"errors"
+	"time"
+)
+
+// Ошибки OAuth2 (RFC 6749, раздел 5.2). Текст ошибки совпадает с кодом из RFC.
+var (
+	// ErrInvalidRequest ...
+	ErrInvalidRequest = errors.New("invalid_request")
+	// ErrInvalidClient ...
+	ErrInvalidClient = errors.New("invalid_client")
+	// ErrInvalidGrant ...
+	ErrInvalidGrant = errors.New("invalid_grant")
+	// ErrUnauthorizedClient ...
+	ErrUnauthorizedClient = errors.New("unauthorized_client")
+	// ErrUnsupportedGrantType ...
+	ErrUnsupportedGrantType = errors.New("unsupported_grant_type")
+	// ErrUnsupportedResponseType ...
+	ErrUnsupportedResponseType = errors.New("unsupported_response_type")
+	// ErrInvalidScope ...
+	ErrInvalidScope = errors.New("invalid_scope")
+)
+
+// IDTokenProvider ...
+type IDTokenProvider interface {
+	CreateIDToken(subject string, audience string, nonce string, exp time.Time) (idToken string, err error)
+	JWKS() []tokenjwt.JWK
+}
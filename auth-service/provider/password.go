@@ -0,0 +1,15 @@
+package provider
+
+// PasswordPolicy проверяет новый пароль пользователя (см. auth/pkg/password).
+// Ошибка - *password.PolicyError со списком нарушенных правил.
+type PasswordPolicy interface {
+	Check(email string, password string) error
+}
+
+// PasswordHasher хэширует пароли по текущей политике и проверяет хэши
+// любой поддерживаемой схемы (см. password.Hasher).
+type PasswordHasher interface {
+	Hash(password string) (hash []byte, err error)
+	Compare(hash []byte, password string) error
+	NeedsRehash(hash []byte) bool
+}
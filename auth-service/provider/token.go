@@ -2,6 +2,7 @@
 package provider
 
 import (
+	tokenjwt "auth/pkg/token"
 	"errors"
 	"time"
 )
@@ -13,6 +14,8 @@ var (
 
 // TokenProvider ...
 type TokenProvider interface {
-	CreateAccessToken(userID int, sessionID int, appID int, exp time.Time) (accToken string, err error)
+	CreateAccessToken(userID int, sessionID int, appID int, audience string, exp time.Time) (accToken string, err error)
+	CreateClientAccessToken(appID int, clientID string, exp time.Time) (accToken string, err error)
 	CreateRefreshToken() (refToken string, err error)
+	ParseAccessToken(accToken string) (claims tokenjwt.AccessClaims, err error)
 }
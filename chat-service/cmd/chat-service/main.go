@@ -2,6 +2,9 @@
 package main
 
 import (
+	"auth/pkg/healthcheck"
+	"auth/pkg/metrics"
+	"auth/pkg/tracing"
 	"chat/internal/app"
 	authclient "chat/internal/client/auth"
 	"chat/internal/config"
@@ -9,6 +12,8 @@ import (
 	"chat/internal/repository"
 	"chat/internal/repository/sqlstore"
 	"chat/internal/service"
+	"chat/internal/userevents"
+	chatv1 "chat/proto/chat/v1"
 	"context"
 	"flag"
 	"log"
@@ -16,11 +21,10 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	_ "github.com/lib/pq"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
@@ -41,6 +45,16 @@ func main() {
 	}
 	logger := config.NewLogger(cfg)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "chat-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	db, err := sqlstore.New(cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal(err)
@@ -56,23 +70,61 @@ func main() {
 
 	hub := hub.New()
 
-	chatAPI := service.NewService(chatRepo, messageRepo, hub)
-
-	authConn, _ := grpc.NewClient(cfg.AuthServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	authConn, err := authclient.Dial(cfg.AuthServiceAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if err := authConn.Close(); err != nil {
+			logger.Error("auth conn close with error", slog.String("err", err.Error()))
+		}
+	}()
 	authClient := authclient.New(authConn)
 
-	app := app.New(logger, chatAPI, cfg, authClient, hub)
+	users := authclient.NewUserCache(authClient, cfg.UserCacheTTL)
+
+	blockRepo := repository.NewBlockRepository(db.DB)
+
+	chatAPI := service.NewService(chatRepo, messageRepo, blockRepo, hub, authClient, users, logger)
+
+	// NOT_SERVING, пока недоступны Postgres или auth-service
+	health := healthcheck.NewChecker(logger, cfg.HealthInterval, map[string]healthcheck.CheckFunc{
+		"postgres":     db.DB.PingContext,
+		"auth-service": authClient.Ping,
+	}, chatv1.ChatService_ServiceDesc.ServiceName)
+	go health.Run()
+
+	app := app.New(logger, chatAPI, cfg, authClient, hub, health)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Чаты удалённых в auth-service пользователей
+	go userevents.New(authClient, repository.NewUserEventRepository(db.DB), chatAPI, logger).Run(ctx)
+
 	go func() {
 		if err := app.GRPCServer.Run(); err != nil {
 			logger.Error("grpc server stopped with error", slog.String("err", err.Error()))
 		}
 	}()
 
+	metricsServer := metrics.NewServer(logger, cfg.MetricsAddr)
+	go func() {
+		if err := metricsServer.Run(); err != nil {
+			logger.Error("metrics server stopped with error", slog.String("err", err.Error()))
+		}
+	}()
+
 	<-ctx.Done()
+	health.Stop()
 	app.GRPCServer.Stop()
 
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := metricsServer.Stop(shutdownCtx); err != nil {
+		logger.Error("metrics server stopped with error", slog.String("err", err.Error()))
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("tracing shutdown with error", slog.String("err", err.Error()))
+	}
 }
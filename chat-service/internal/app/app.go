@@ -2,6 +2,7 @@
 package app
 
 import (
+	"auth/pkg/healthcheck"
 	grpcapp "chat/internal/app/grpc"
 	authclient "chat/internal/client/auth"
 	"chat/internal/config"
@@ -16,8 +17,8 @@ type App struct {
 }
 
 // New ...
-func New(log *slog.Logger, auth chat.Chat, cfg *config.Config, authClient *authclient.Client, hub *hub.Hub) *App {
-	gRPCApp := grpcapp.New(log, cfg.BindAddr, auth, cfg, authClient, hub)
+func New(log *slog.Logger, auth chat.Chat, cfg *config.Config, authClient *authclient.Client, hub *hub.Hub, health *healthcheck.Checker) *App {
+	gRPCApp := grpcapp.New(log, cfg.BindAddr, auth, cfg, authClient, hub, health)
 	return &App{
 		GRPCServer: gRPCApp,
 	}
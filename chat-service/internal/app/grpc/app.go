@@ -2,16 +2,24 @@
 package grpcapp
 
 import (
+	"auth/pkg/authn"
+	"auth/pkg/healthcheck"
+	"auth/pkg/introspect"
+	"auth/pkg/metrics"
+	"auth/pkg/requestid"
+	"auth/pkg/tracing"
+	"auth/pkg/validate"
 	authclient "chat/internal/client/auth"
 	"chat/internal/config"
 	"chat/internal/grpc/chat"
 	"chat/internal/grpc/hub"
-	"chat/internal/interceptor"
 	"fmt"
 	"log/slog"
 	"net"
 
+	"buf.build/go/protovalidate"
 	"google.golang.org/grpc"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // App ...
@@ -22,16 +30,34 @@ type App struct {
 }
 
 // New ...
-func New(log *slog.Logger, port string, auth chat.Chat, cfg *config.Config, authClient *authclient.Client, hub *hub.Hub) *App {
+func New(log *slog.Logger, port string, auth chat.Chat, cfg *config.Config, authClient *authclient.Client, hub *hub.Hub, health *healthcheck.Checker) *App {
+	verifier := introspect.NewClient(authClient.API, cfg.JWTAudience)
+	// Пробы оркестратора и gateway приходят без токена
+	public := authn.WithPublicMethods(
+		healthv1.Health_Check_FullMethodName,
+		healthv1.Health_Watch_FullMethodName,
+	)
+
+	// Сначала request_id, чтобы он был в логах всех интерсепторов; метрики снаружи,
+	// чтобы учесть и отказы; дальше аутентификация, потом проверка запроса
+	// по правилам buf.validate из chat.proto
 	gRPCServer := grpc.NewServer(
-		grpc.UnaryInterceptor(
-			interceptor.AuthInterceptor(cfg.JWTSecret, authClient),
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(
+			requestid.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+			authn.UnaryServerInterceptor(verifier, public),
+			validate.UnaryServerInterceptor(protovalidate.GlobalValidator),
 		),
-		grpc.StreamInterceptor(
-			interceptor.AuthStreamInterceptor(cfg.JWTSecret, authClient),
+		grpc.ChainStreamInterceptor(
+			requestid.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(),
+			authn.StreamServerInterceptor(verifier, public),
+			validate.StreamServerInterceptor(protovalidate.GlobalValidator),
 		),
 	)
 	chat.Register(gRPCServer, auth, hub, log)
+	health.Register(gRPCServer)
 
 	return &App{
 		logger:     log,
@@ -2,17 +2,112 @@
 package authclient
 
 import (
+	"auth/pkg/requestid"
+	"auth/pkg/tracing"
 	authv1 "auth/proto/auth/v1"
+	chaterror "chat/internal/error"
+	"chat/internal/model"
+	"context"
+	"fmt"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // Client ...
 type Client struct {
-	API authv1.AuthServiceClient
+	API      authv1.AuthServiceClient
+	Profiles authv1.ProfileServiceClient
+	health   healthv1.HealthClient
 }
 
 // New ...
 func New(conn *grpc.ClientConn) *Client {
-	return &Client{API: authv1.NewAuthServiceClient(conn)}
+	return &Client{
+		API:      authv1.NewAuthServiceClient(conn),
+		Profiles: authv1.NewProfileServiceClient(conn),
+		health:   healthv1.NewHealthClient(conn),
+	}
+}
+
+// Ping проверяет, что auth-service доступен и сам готов обслуживать AuthService:
+// без него чат не может проверить ни один токен.
+func (c *Client) Ping(ctx context.Context) error {
+	const op = "authclient.Ping"
+
+	resp, err := c.health.Check(ctx, &healthv1.HealthCheckRequest{
+		Service: authv1.AuthService_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if resp.GetStatus() != healthv1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s: auth-service is %s", op, resp.GetStatus())
+	}
+	return nil
+}
+
+// BatchGetProfiles возвращает профили пользователей по id одним запросом.
+// Неизвестных пользователей в ответе нет.
+func (c *Client) BatchGetProfiles(ctx context.Context, userIDs []int) (map[int]model.Profile, error) {
+	const op = "authclient.BatchGetProfiles"
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = int64(id)
+	}
+
+	resp, err := c.Profiles.BatchGetProfiles(ctx, &authv1.BatchGetProfilesRequest{UserIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	profiles := make(map[int]model.Profile, len(resp.GetProfiles()))
+	for _, p := range resp.GetProfiles() {
+		profiles[int(p.GetUserId())] = model.Profile{
+			UserID:      int(p.GetUserId()),
+			Username:    p.GetUsername(),
+			DisplayName: p.GetDisplayName(),
+			AvatarURL:   p.GetAvatarUrl(),
+		}
+	}
+	return profiles, nil
+}
+
+// ProfileByUsername ищет пользователя по @username, chaterror.ErrUserNotFound - нет такого.
+func (c *Client) ProfileByUsername(ctx context.Context, username string) (model.Profile, error) {
+	const op = "authclient.ProfileByUsername"
+
+	p, err := c.Profiles.GetProfileByUsername(ctx, &authv1.GetProfileByUsernameRequest{Username: username})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return model.Profile{}, fmt.Errorf("%s: %w", op, chaterror.ErrUserNotFound)
+		}
+		return model.Profile{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return model.Profile{
+		UserID:      int(p.GetUserId()),
+		Username:    p.GetUsername(),
+		DisplayName: p.GetDisplayName(),
+		AvatarURL:   p.GetAvatarUrl(),
+	}, nil
+}
+
+// Dial ...
+// Вызовы несут traceparent и x-request-id: ValidateSession попадает в трейс
+// и логи запроса к чату.
+func Dial(addr string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		tracing.DialOption(),
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor()),
+	)
 }
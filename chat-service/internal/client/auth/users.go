@@ -0,0 +1,129 @@
+package authclient
+
+import (
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// UsersExist возвращает те из userIDs, что зарегистрированы в auth-service.
+func (c *Client) UsersExist(ctx context.Context, userIDs []int) ([]int, error) {
+	const op = "authclient.UsersExist"
+
+	ids := make([]int64, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = int64(id)
+	}
+
+	resp, err := c.API.UsersExist(ctx, &authv1.UsersExistRequest{UserIds: ids})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	existing := make([]int, len(resp.GetExistingUserIds()))
+	for i, id := range resp.GetExistingUserIds() {
+		existing[i] = int(id)
+	}
+	return existing, nil
+}
+
+// WatchUserDeletions читает удаления пользователей после afterID, пока поток не оборвётся
+// или ctx не отменён. fn получает курсор, с которого продолжать после обрыва, и id
+// удалённого пользователя. События приходят не по порядку id и после обрыва повторяются.
+func (c *Client) WatchUserDeletions(ctx context.Context, afterID int64, fn func(cursor int64, userID int) error) error {
+	const op = "authclient.WatchUserDeletions"
+
+	stream, err := c.API.WatchUserEvents(ctx, &authv1.WatchUserEventsRequest{AfterId: afterID})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if e.GetType() != authv1.UserEventType_USER_EVENT_TYPE_DELETED {
+			continue
+		}
+		if err := fn(e.GetCursor(), int(e.GetUserId())); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+}
+
+// UserCache запоминает существующих пользователей на ttl, чтобы не спрашивать
+// auth-service при каждом GetOrCreateChat. Отсутствие не кэшируется: пользователь
+// мог только что зарегистрироваться. Удалённых убирает Forget.
+type UserCache struct {
+	client *Client
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	exists map[int]time.Time // userID -> до какого момента верим
+}
+
+// NewUserCache ...
+func NewUserCache(client *Client, ttl time.Duration) *UserCache {
+	return &UserCache{
+		client: client,
+		ttl:    ttl,
+		exists: make(map[int]time.Time),
+	}
+}
+
+// UsersExist ...
+func (u *UserCache) UsersExist(ctx context.Context, userIDs []int) (map[int]bool, error) {
+	result := make(map[int]bool, len(userIDs))
+	var unknown []int
+
+	now := time.Now()
+	u.mu.Lock()
+	for _, id := range userIDs {
+		if until, ok := u.exists[id]; ok && now.Before(until) {
+			result[id] = true
+			continue
+		}
+		delete(u.exists, id)
+		unknown = append(unknown, id)
+	}
+	u.mu.Unlock()
+
+	if len(unknown) == 0 {
+		return result, nil
+	}
+
+	existing, err := u.client.UsersExist(ctx, unknown)
+	if err != nil {
+		return nil, err
+	}
+
+	until := time.Now().Add(u.ttl)
+	u.mu.Lock()
+	for _, id := range existing {
+		u.exists[id] = until
+		result[id] = true
+	}
+	u.mu.Unlock()
+
+	for _, id := range unknown {
+		if !result[id] {
+			result[id] = false
+		}
+	}
+	return result, nil
+}
+
+// Forget ...
+func (u *UserCache) Forget(userID int) {
+	u.mu.Lock()
+	delete(u.exists, userID)
+	u.mu.Unlock()
+}
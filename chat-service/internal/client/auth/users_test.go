@@ -0,0 +1,137 @@
+package authclient
+
+import (
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeAuth отвечает на UsersExist из known и считает вызовы; WatchUserEvents отдаёт events.
+type fakeAuth struct {
+	authv1.AuthServiceClient
+	known  map[int64]bool
+	asked  [][]int64
+	events []*authv1.UserEvent
+	req    *authv1.WatchUserEventsRequest
+}
+
+func (f *fakeAuth) UsersExist(_ context.Context, req *authv1.UsersExistRequest, _ ...grpc.CallOption) (*authv1.UsersExistResponse, error) {
+	f.asked = append(f.asked, req.GetUserIds())
+	resp := &authv1.UsersExistResponse{}
+	for _, id := range req.GetUserIds() {
+		if f.known[id] {
+			resp.ExistingUserIds = append(resp.ExistingUserIds, id)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeAuth) WatchUserEvents(_ context.Context, req *authv1.WatchUserEventsRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[authv1.UserEvent], error) {
+	f.req = req
+	return &fakeUserEventStream{events: f.events}, nil
+}
+
+type fakeUserEventStream struct {
+	grpc.ServerStreamingClient[authv1.UserEvent]
+	events []*authv1.UserEvent
+}
+
+func (s *fakeUserEventStream) Recv() (*authv1.UserEvent, error) {
+	if len(s.events) == 0 {
+		return nil, io.EOF
+	}
+	e := s.events[0]
+	s.events = s.events[1:]
+	return e, nil
+}
+
+func TestUserCache_UsersExist(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeAuth{known: map[int64]bool{1: true}}
+	cache := NewUserCache(&Client{API: api}, time.Minute)
+	ctx := context.Background()
+
+	got, err := cache.UsersExist(ctx, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true, 2: false}, got)
+
+	// Существующий берётся из кэша, отсутствующий спрашивается снова
+	got, err = cache.UsersExist(ctx, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true, 2: false}, got)
+	assert.Equal(t, [][]int64{{1, 2}, {2}}, api.asked)
+
+	// Только что зарегистрированный виден сразу
+	api.known[2] = true
+	got, err = cache.UsersExist(ctx, []int{2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{2: true}, got)
+}
+
+func TestUserCache_Forget(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeAuth{known: map[int64]bool{1: true}}
+	cache := NewUserCache(&Client{API: api}, time.Minute)
+	ctx := context.Background()
+
+	_, err := cache.UsersExist(ctx, []int{1})
+	require.NoError(t, err)
+
+	delete(api.known, 1)
+	cache.Forget(1)
+
+	got, err := cache.UsersExist(ctx, []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: false}, got)
+	assert.Len(t, api.asked, 2)
+}
+
+func TestUserCache_TTL(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeAuth{known: map[int64]bool{1: true}}
+	cache := NewUserCache(&Client{API: api}, time.Nanosecond)
+	ctx := context.Background()
+
+	_, err := cache.UsersExist(ctx, []int{1})
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = cache.UsersExist(ctx, []int{1})
+	require.NoError(t, err)
+
+	assert.Len(t, api.asked, 2)
+}
+
+func TestClient_WatchUserDeletions(t *testing.T) {
+	t.Parallel()
+
+	api := &fakeAuth{events: []*authv1.UserEvent{
+		{Id: 13, Type: authv1.UserEventType_USER_EVENT_TYPE_DELETED, UserId: 8, Cursor: 10},
+		{Id: 14, Type: authv1.UserEventType_USER_EVENT_TYPE_UNSPECIFIED, UserId: 9, Cursor: 10},
+		{Id: 12, Type: authv1.UserEventType_USER_EVENT_TYPE_DELETED, UserId: 7, Cursor: 11},
+	}}
+	client := &Client{API: api}
+
+	type call struct {
+		cursor int64
+		userID int
+	}
+	var got []call
+	err := client.WatchUserDeletions(context.Background(), 10, func(cursor int64, userID int) error {
+		got = append(got, call{cursor, userID})
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), api.req.GetAfterId())
+	// fn получает курсор, а не id события: по id 13 возобновлять нельзя, 12 ещё впереди
+	assert.Equal(t, []call{{10, 8}, {11, 7}}, got)
+}
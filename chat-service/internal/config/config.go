@@ -1,22 +1,44 @@
 // Package config ...
 package config
 
+import "time"
+
 // Config ...
 type Config struct {
-	DatabaseURL     string `toml:"database_url"`
-	TestDatabaseURL string `toml:"test_database_url"`
-	RedisAddr       string `toml:"redis_addr"`
-	TestRedisAddr   string `toml:"test_redis_addr"`
-	BindAddr        string `toml:"bind_addr"`
-	LogLevel        string `toml:"log_level"`
-	JWTSecret       string `toml:"jwt_secret"`
-	AuthServiceAddr string `toml:"auth_service_addr"`
+	DatabaseURL     string        `toml:"database_url"`
+	TestDatabaseURL string        `toml:"test_database_url"`
+	RedisAddr       string        `toml:"redis_addr"`
+	TestRedisAddr   string        `toml:"test_redis_addr"`
+	BindAddr        string        `toml:"bind_addr"`
+	MetricsAddr     string        `toml:"metrics_addr"`
+	HealthInterval  time.Duration `toml:"health_check_interval"`
+	LogLevel        string        `toml:"log_level"`
+	JWTAudience     string        `toml:"jwt_audience"`
+	AuthServiceAddr string        `toml:"auth_service_addr"`
+	UserCacheTTL    time.Duration `toml:"user_cache_ttl"`
+	Tracing         Tracing       `toml:"tracing"`
+}
+
+// Tracing - экспорт спанов OpenTelemetry. Пустой exporter - спаны не пишутся.
+type Tracing struct {
+	Exporter    string  `toml:"exporter"` // otlp или stdout.
+	Endpoint    string  `toml:"endpoint"` // host:port OTLP/gRPC коллектора.
+	Insecure    bool    `toml:"insecure"`
+	SampleRatio float64 `toml:"sample_ratio"`
 }
 
 // NewConfig ...
 func NewConfig() *Config {
 	return &Config{
-		BindAddr: ":8080",
-		LogLevel: "info",
+		BindAddr:       ":8080",
+		MetricsAddr:    ":9092",
+		HealthInterval: 5 * time.Second,
+		UserCacheTTL:   5 * time.Minute,
+		LogLevel:       "info",
+		Tracing: Tracing{
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
 	}
 }
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"auth/pkg/requestid"
 	"log/slog"
 	"os"
 	"time"
@@ -25,5 +26,6 @@ func NewLogger(config *Config) *slog.Logger {
 		},
 	)
 
-	return slog.New(handler)
+	// request_id из контекста в каждой записи, см. requestid.Handler
+	return slog.New(requestid.NewHandler(handler))
 }
@@ -10,4 +10,14 @@ var (
 	ErrPermissionDenied = errors.New("permission denied")
 	// ErrChatNotFound ...
 	ErrChatNotFound = errors.New("chat not found")
+	// ErrUserNotFound ...
+	ErrUserNotFound = errors.New("user not found")
+	// ErrSelfChat ...
+	ErrSelfChat = errors.New("cannot start a chat with yourself")
+	// ErrSelfBlock ...
+	ErrSelfBlock = errors.New("cannot block yourself")
+	// ErrBlocked - один из пары заблокировал другого.
+	ErrBlocked = errors.New("messaging between these users is blocked")
+	// ErrResyncRequired - пропущено больше, чем отдаём при возобновлении подписки.
+	ErrResyncRequired = errors.New("too many missed messages, resync required")
 )
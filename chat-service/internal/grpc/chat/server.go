@@ -2,12 +2,16 @@
 package chat
 
 import (
+	"auth/pkg/apierr"
+	"auth/pkg/authn"
+	chaterror "chat/internal/error"
 	"chat/internal/grpc/hub"
-	"chat/internal/interceptor"
 	"chat/internal/model"
 	chatv1 "chat/proto/chat/v1"
 	"context"
+	"errors"
 	"log/slog"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -19,9 +23,15 @@ import (
 // Chat ...
 type Chat interface {
 	GetOrCreateChat(ctx context.Context, initiatorID int, recipientID int) (chatID int, created bool, createdAt time.Time, err error)
+	GetOrCreateChatByUsername(ctx context.Context, initiatorID int, username string) (chatID int, created bool, createdAt time.Time, err error)
 	GetMessages(ctx context.Context, chatID int, limit int, cursor string) (massages []model.MassageDTO, nextCursor string, err error)
 	GetUserChats(ctx context.Context, userID int, limit int, offset int) (chats []model.ChatPreviewDTO, err error)
 	SendMessage(ctx context.Context, chatID int, senderID int, text string) (massageID int, createdAt time.Time, err error)
+	MissedMessages(ctx context.Context, afterID int) ([]model.MassageDTO, error)
+	BlockUser(ctx context.Context, blockerID int, blockedID int) error
+	UnblockUser(ctx context.Context, blockerID int, blockedID int) error
+	ListBlocked(ctx context.Context, blockerID int, limit int, offset int) ([]model.BlockedUser, error)
+	MuteChat(ctx context.Context, chatID int, userID int, until *time.Time) (mutedUntil *time.Time, err error)
 }
 
 type serverAPI struct {
@@ -44,9 +54,9 @@ func (s *serverAPI) GetOrCreateChat(ctx context.Context, req *chatv1.GetOrCreate
 	log := s.logger.With(
 		slog.String("op", op),
 	)
-	log.Info("GetOrCreateChat")
+	log.InfoContext(ctx, "GetOrCreateChat")
 
-	userID, ok := ctx.Value(interceptor.UserIDKey).(int)
+	userID, ok := authn.UserID(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
 	}
@@ -55,9 +65,19 @@ func (s *serverAPI) GetOrCreateChat(ctx context.Context, req *chatv1.GetOrCreate
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
 
-	chatID, created, createdAt, err := s.chat.GetOrCreateChat(ctx, int(req.GetInitiatorId()), int(req.GetRecipientId()))
+	var (
+		chatID    int
+		created   bool
+		createdAt time.Time
+		err       error
+	)
+	if username := req.GetRecipientUsername(); username != "" {
+		chatID, created, createdAt, err = s.chat.GetOrCreateChatByUsername(ctx, userID, username)
+	} else {
+		chatID, created, createdAt, err = s.chat.GetOrCreateChat(ctx, userID, int(req.GetRecipientId()))
+	}
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, s.serviceError(ctx, log, err)
 	}
 	return &chatv1.GetOrCreateChatResponse{
 		ChatId:    int64(chatID),
@@ -72,11 +92,11 @@ func (s *serverAPI) GetMessages(ctx context.Context, req *chatv1.GetMessagesRequ
 	log := s.logger.With(
 		slog.String("op", op),
 	)
-	log.Info("GetMassages")
+	log.InfoContext(ctx, "GetMassages")
 
 	messages, nextCursor, err := s.chat.GetMessages(ctx, int(req.GetChatId()), int(req.GetLimit()), req.GetCursor())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, s.serviceError(ctx, log, err)
 	}
 	messagesDTO := make([]*chatv1.MessageDTO, len(messages))
 	for i := range messages {
@@ -100,9 +120,9 @@ func (s *serverAPI) GetUserChats(ctx context.Context, req *chatv1.GetUserChatsRe
 	log := s.logger.With(
 		slog.String("op", op),
 	)
-	log.Info("GetUserChats")
+	log.InfoContext(ctx, "GetUserChats")
 
-	userID, ok := ctx.Value(interceptor.UserIDKey).(int)
+	userID, ok := authn.UserID(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
 	}
@@ -113,7 +133,7 @@ func (s *serverAPI) GetUserChats(ctx context.Context, req *chatv1.GetUserChatsRe
 
 	chatPreview, err := s.chat.GetUserChats(ctx, int(req.GetUserId()), int(req.GetLimit()), int(req.GetOffset()))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, s.serviceError(ctx, log, err)
 	}
 	chatPreviewDTO := make([]*chatv1.ChatPreviewDTO, len(chatPreview))
 	for i := range chatPreview {
@@ -121,6 +141,10 @@ func (s *serverAPI) GetUserChats(ctx context.Context, req *chatv1.GetUserChatsRe
 		if chatPreview[i].LastMessageAt != nil {
 			lastMessageAt = timestamppb.New(*chatPreview[i].LastMessageAt)
 		}
+		var mutedUntil *timestamppb.Timestamp
+		if chatPreview[i].MutedUntil != nil {
+			mutedUntil = timestamppb.New(*chatPreview[i].MutedUntil)
+		}
 
 		chatPreviewDTO[i] = &chatv1.ChatPreviewDTO{
 			ChatId:        int64(chatPreview[i].ChatID),
@@ -128,6 +152,13 @@ func (s *serverAPI) GetUserChats(ctx context.Context, req *chatv1.GetUserChatsRe
 			LastMessage:   chatPreview[i].LastMessage,
 			UnreadCount:   int64(chatPreview[i].UnreadCount),
 			LastMessageAt: lastMessageAt,
+
+			CompanionUsername:    chatPreview[i].Companion.Username,
+			CompanionDisplayName: chatPreview[i].Companion.DisplayName,
+			CompanionAvatarUrl:   chatPreview[i].Companion.AvatarURL,
+
+			MutedUntil:       mutedUntil,
+			CompanionBlocked: chatPreview[i].CompanionBlocked,
 		}
 	}
 	return &chatv1.GetUserChatsResponse{
@@ -141,9 +172,9 @@ func (s *serverAPI) SendMessage(ctx context.Context, req *chatv1.SendMessageRequ
 	log := s.logger.With(
 		slog.String("op", op),
 	)
-	log.Info("SendMessage")
+	log.InfoContext(ctx, "SendMessage")
 
-	userID, ok := ctx.Value(interceptor.UserIDKey).(int)
+	userID, ok := authn.UserID(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
 	}
@@ -154,7 +185,7 @@ func (s *serverAPI) SendMessage(ctx context.Context, req *chatv1.SendMessageRequ
 
 	massageID, createdAt, err := s.chat.SendMessage(ctx, int(req.GetChatId()), int(req.GetSenderId()), req.GetText())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, s.serviceError(ctx, log, err)
 	}
 
 	return &chatv1.SendMessageResponse{
@@ -164,16 +195,227 @@ func (s *serverAPI) SendMessage(ctx context.Context, req *chatv1.SendMessageRequ
 }
 
 // Subscribe ...
-func (s *serverAPI) Subscribe(_ *chatv1.SubscribeRequest, stream chatv1.ChatService_SubscribeServer) error {
-	userID, ok := stream.Context().Value(interceptor.UserIDKey).(int)
+func (s *serverAPI) Subscribe(req *chatv1.SubscribeRequest, stream chatv1.ChatService_SubscribeServer) error {
+	const op = "serverAPI.Subscribe"
+	log := s.logger.With(
+		slog.String("op", op),
+	)
+
+	userID, ok := authn.UserID(stream.Context())
 	if !ok {
 		return status.Error(codes.Unauthenticated, "unauthenticated")
 	}
 
-	s.hub.Subscribe(userID, stream)
-	defer s.hub.Unsubscribe(userID, stream)
+	if req.GetAfterMessageId() <= 0 {
+		s.hub.Subscribe(userID, stream)
+		defer s.hub.Unsubscribe(userID, stream)
+
+		// Держим стрим открытым пока клиент не отключится
+		<-stream.Context().Done()
+		return nil
+	}
+
+	// Возобновление: подписываемся до чтения истории, чтобы не потерять сообщения
+	// между запросом в БД и подпиской; новые копятся в буфере до конца догрузки.
+	resume := &resumeStream{ChatService_SubscribeServer: stream, replaying: true}
+	s.hub.Subscribe(userID, resume)
+	defer s.hub.Unsubscribe(userID, resume)
+
+	missed, err := s.chat.MissedMessages(stream.Context(), int(req.GetAfterMessageId()))
+	if errors.Is(err, chaterror.ErrResyncRequired) {
+		// Клиент перечитывает чаты и подписывается заново без after_message_id
+		return apierr.New(codes.OutOfRange, apierr.CodeResyncRequired, "too many missed messages, resync required")
+	}
+	if err != nil {
+		log.ErrorContext(stream.Context(), "missed messages", slog.String("err", err.Error()))
+		return apierr.Internal()
+	}
+
+	// Буфер сверяем с историей по id, а не по последнему id: сообщение с меньшим id,
+	// закоммиченное после запроса истории, в неё не попало и придёт только из хаба.
+	replayed := make(map[int64]struct{}, len(missed))
+	for i := range missed {
+		msg := &chatv1.MessageDTO{
+			Id:        int64(missed[i].ID),
+			ChatId:    int64(missed[i].ChatID),
+			SenderId:  int64(missed[i].SenderID),
+			Text:      missed[i].Text,
+			CreatedAt: timestamppb.New(*missed[i].CreatedAt),
+			Muted:     missed[i].Muted,
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+		replayed[msg.GetId()] = struct{}{}
+	}
+
+	if err := resume.flush(replayed); err != nil {
+		return err
+	}
 
-	// Держим стрим открытым пока клиент не отключится
 	<-stream.Context().Done()
 	return nil
 }
+
+// resumeStream буферизует сообщения из хаба, пока идёт догрузка истории,
+// и потом отправляет только те, что не попали в историю.
+type resumeStream struct {
+	chatv1.ChatService_SubscribeServer
+
+	mu        sync.Mutex
+	replaying bool
+	buffered  []*chatv1.MessageDTO
+}
+
+// Send ...
+func (r *resumeStream) Send(msg *chatv1.MessageDTO) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.replaying {
+		r.buffered = append(r.buffered, msg)
+		return nil
+	}
+	return r.ChatService_SubscribeServer.Send(msg)
+}
+
+// flush отправляет накопленное, кроме уже отданного в истории, и дальше пропускает
+// сообщения из хаба сразу.
+func (r *resumeStream) flush(replayed map[int64]struct{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.replaying = false
+	for _, msg := range r.buffered {
+		if _, ok := replayed[msg.GetId()]; ok {
+			continue
+		}
+		if err := r.ChatService_SubscribeServer.Send(msg); err != nil {
+			return err
+		}
+	}
+	r.buffered = nil
+	return nil
+}
+
+// BlockUser ...
+func (s *serverAPI) BlockUser(ctx context.Context, req *chatv1.BlockUserRequest) (*chatv1.BlockUserResponse, error) {
+	const op = "serverAPI.BlockUser"
+	log := s.logger.With(
+		slog.String("op", op),
+	)
+	log.InfoContext(ctx, "BlockUser")
+
+	userID, ok := authn.UserID(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	if err := s.chat.BlockUser(ctx, userID, int(req.GetUserId())); err != nil {
+		return nil, s.serviceError(ctx, log, err)
+	}
+	return &chatv1.BlockUserResponse{}, nil
+}
+
+// UnblockUser ...
+func (s *serverAPI) UnblockUser(ctx context.Context, req *chatv1.UnblockUserRequest) (*chatv1.UnblockUserResponse, error) {
+	const op = "serverAPI.UnblockUser"
+	log := s.logger.With(
+		slog.String("op", op),
+	)
+	log.InfoContext(ctx, "UnblockUser")
+
+	userID, ok := authn.UserID(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	if err := s.chat.UnblockUser(ctx, userID, int(req.GetUserId())); err != nil {
+		return nil, s.serviceError(ctx, log, err)
+	}
+	return &chatv1.UnblockUserResponse{}, nil
+}
+
+// ListBlocked ...
+func (s *serverAPI) ListBlocked(ctx context.Context, req *chatv1.ListBlockedRequest) (*chatv1.ListBlockedResponse, error) {
+	const op = "serverAPI.ListBlocked"
+	log := s.logger.With(
+		slog.String("op", op),
+	)
+	log.InfoContext(ctx, "ListBlocked")
+
+	userID, ok := authn.UserID(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	blocked, err := s.chat.ListBlocked(ctx, userID, int(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		return nil, s.serviceError(ctx, log, err)
+	}
+	users := make([]*chatv1.BlockedUser, len(blocked))
+	for i := range blocked {
+		users[i] = &chatv1.BlockedUser{
+			UserId:    int64(blocked[i].UserID),
+			BlockedAt: timestamppb.New(blocked[i].BlockedAt),
+		}
+	}
+	return &chatv1.ListBlockedResponse{
+		Users: users,
+	}, nil
+}
+
+// MuteChat ...
+func (s *serverAPI) MuteChat(ctx context.Context, req *chatv1.MuteChatRequest) (*chatv1.MuteChatResponse, error) {
+	const op = "serverAPI.MuteChat"
+	log := s.logger.With(
+		slog.String("op", op),
+	)
+	log.InfoContext(ctx, "MuteChat")
+
+	userID, ok := authn.UserID(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	var until *time.Time
+	if req.GetUntil() != nil {
+		t := req.GetUntil().AsTime()
+		until = &t
+	}
+
+	mutedUntil, err := s.chat.MuteChat(ctx, int(req.GetChatId()), userID, until)
+	if err != nil {
+		return nil, s.serviceError(ctx, log, err)
+	}
+
+	resp := &chatv1.MuteChatResponse{}
+	if mutedUntil != nil {
+		resp.MutedUntil = timestamppb.New(*mutedUntil)
+	}
+	return resp, nil
+}
+
+// serviceError переводит ошибки сервиса в gRPC статус со стабильным кодом.
+// Неизвестные ошибки логируются и уходят клиенту как internal error без подробностей.
+func (s *serverAPI) serviceError(ctx context.Context, log *slog.Logger, err error) error {
+	switch {
+	case errors.Is(err, chaterror.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	case errors.Is(err, chaterror.ErrPermissionDenied):
+		return apierr.New(codes.PermissionDenied, apierr.CodeNotChatMember, "not a member of this chat")
+	case errors.Is(err, chaterror.ErrChatNotFound):
+		return apierr.New(codes.NotFound, apierr.CodeChatNotFound, "chat not found")
+	case errors.Is(err, chaterror.ErrUserNotFound):
+		return apierr.New(codes.NotFound, apierr.CodeUserNotFound, "user not found")
+	case errors.Is(err, chaterror.ErrSelfChat):
+		return apierr.New(codes.InvalidArgument, apierr.CodeInvalidArgument, "cannot start a chat with yourself")
+	case errors.Is(err, chaterror.ErrSelfBlock):
+		return apierr.New(codes.InvalidArgument, apierr.CodeInvalidArgument, "cannot block yourself")
+	case errors.Is(err, chaterror.ErrBlocked):
+		return apierr.New(codes.PermissionDenied, apierr.CodeUserBlocked, "messaging between these users is blocked")
+	}
+
+	log.ErrorContext(ctx, "internal error", slog.String("err", err.Error()))
+	return apierr.Internal()
+}
@@ -0,0 +1,112 @@
+package chat
+
+import (
+	"auth/pkg/authn"
+	"chat/internal/grpc/hub"
+	"chat/internal/model"
+	chatv1 "chat/proto/chat/v1"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChat отдаёт missed из MissedMessages; onMissed вызывается до ответа -
+// так тест вклинивается между подпиской на хаб и чтением истории.
+type fakeChat struct {
+	Chat
+	missed   []model.MassageDTO
+	onMissed func()
+}
+
+func (f *fakeChat) MissedMessages(_ context.Context, _ int) ([]model.MassageDTO, error) {
+	if f.onMissed != nil {
+		f.onMissed()
+	}
+	return f.missed, nil
+}
+
+// fakeSubscribeStream запоминает отправленные клиенту сообщения.
+type fakeSubscribeStream struct {
+	chatv1.ChatService_SubscribeServer
+	ctx  context.Context
+	sent []*chatv1.MessageDTO
+}
+
+func (f *fakeSubscribeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeSubscribeStream) Send(msg *chatv1.MessageDTO) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+// closedStream - стрим пользователя userID, клиент которого уже отключился:
+// Subscribe отдаёт догрузку и сразу возвращается.
+func closedStream(userID int) *fakeSubscribeStream {
+	ctx, cancel := context.WithCancel(authn.NewContext(context.Background(), authn.Principal{UserID: userID}))
+	cancel()
+	return &fakeSubscribeStream{ctx: ctx}
+}
+
+func newTestServer(chat Chat, h *hub.Hub) *serverAPI {
+	return &serverAPI{chat: chat, hub: h, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+func sentIDs(msgs []*chatv1.MessageDTO) []int64 {
+	ids := make([]int64, 0, len(msgs))
+	for _, msg := range msgs {
+		ids = append(ids, msg.GetId())
+	}
+	return ids
+}
+
+func TestServerAPI_SubscribeResumeMuted(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	chat := &fakeChat{missed: []model.MassageDTO{
+		{ID: 11, ChatID: 1, SenderID: 8, Text: "hi", CreatedAt: &now},
+		{ID: 12, ChatID: 2, SenderID: 9, Text: "muted", CreatedAt: &now, Muted: true},
+	}}
+	s := newTestServer(chat, hub.New())
+	stream := closedStream(7)
+
+	err := s.Subscribe(&chatv1.SubscribeRequest{AfterMessageId: 10}, stream)
+
+	require.NoError(t, err)
+	require.Equal(t, []int64{11, 12}, sentIDs(stream.sent))
+	// Догруженное из заглушённого чата тоже приходит без уведомления
+	assert.False(t, stream.sent[0].GetMuted())
+	assert.True(t, stream.sent[1].GetMuted())
+}
+
+func TestServerAPI_SubscribeResumeLateCommit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	h := hub.New()
+	chat := &fakeChat{missed: []model.MassageDTO{
+		{ID: 11, ChatID: 1, SenderID: 8, Text: "first", CreatedAt: &now},
+		{ID: 13, ChatID: 1, SenderID: 8, Text: "third", CreatedAt: &now},
+	}}
+	// Пока читается история, хаб доставляет 13 (она же есть в истории) и 12:
+	// её id выдан раньше, но транзакция закоммитилась уже после запроса истории
+	chat.onMissed = func() {
+		h.Push(7, &chatv1.MessageDTO{Id: 13, ChatId: 1, SenderId: 8, Text: "third"})
+		h.Push(7, &chatv1.MessageDTO{Id: 12, ChatId: 1, SenderId: 9, Text: "second"})
+	}
+	s := newTestServer(chat, h)
+	stream := closedStream(7)
+
+	err := s.Subscribe(&chatv1.SubscribeRequest{AfterMessageId: 10}, stream)
+
+	require.NoError(t, err)
+	// 12 не теряется, 13 не дублируется
+	assert.Equal(t, []int64{11, 13, 12}, sentIDs(stream.sent))
+}
@@ -26,6 +26,8 @@ func (h *Hub) Subscribe(userID int, stream chatv1.ChatService_SubscribeServer) {
 	h.mu.Lock()
 	h.streams[userID] = append(h.streams[userID], stream)
 	h.mu.Unlock()
+
+	subscribers.Inc()
 }
 
 // Unsubscribe удаляет стрим пользователя.
@@ -37,6 +39,7 @@ func (h *Hub) Unsubscribe(userID int, stream chatv1.ChatService_SubscribeServer)
 	for i, s := range streams {
 		if s == stream {
 			h.streams[userID] = append(streams[:i], streams[i+1:]...)
+			subscribers.Dec()
 			break
 		}
 	}
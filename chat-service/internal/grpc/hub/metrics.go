@@ -0,0 +1,11 @@
+package hub
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var subscribers = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "chat_hub_subscribers",
+	Help: "Active Subscribe streams registered in the hub.",
+})
@@ -10,6 +10,8 @@ type MassageDTO struct {
 	SenderID  int
 	Text      string
 	CreatedAt *time.Time
+	// Muted - получатель заглушил чат; заполняется только при догрузке пропущенного.
+	Muted bool
 }
 
 // ChatPreviewDTO ...
@@ -19,4 +21,23 @@ type ChatPreviewDTO struct {
 	LastMessage   string
 	UnreadCount   int
 	LastMessageAt *time.Time
+	Companion     Profile
+	// MutedUntil - до какого момента чат заглушен, nil - не заглушен.
+	MutedUntil *time.Time
+	// CompanionBlocked - собеседник заблокирован пользователем.
+	CompanionBlocked bool
+}
+
+// BlockedUser ...
+type BlockedUser struct {
+	UserID    int
+	BlockedAt time.Time
+}
+
+// Profile - профиль пользователя из auth-service.
+type Profile struct {
+	UserID      int
+	Username    string
+	DisplayName string
+	AvatarURL   string
 }
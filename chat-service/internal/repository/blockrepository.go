@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"chat/internal/model"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// BlockRepository ...
+type BlockRepository struct {
+	db *sql.DB
+}
+
+// NewBlockRepository ...
+func NewBlockRepository(db *sql.DB) *BlockRepository {
+	return &BlockRepository{db: db}
+}
+
+// Block ...
+// Повторная блокировка ничего не меняет.
+func (r *BlockRepository) Block(ctx context.Context, blockerID int, blockedID int) error {
+	const op = "BlockRepository.Block"
+
+	const query = `
+        INSERT INTO blocks (blocker_id, blocked_id)
+        VALUES ($1, $2)
+        ON CONFLICT DO NOTHING
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, blockerID, blockedID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Unblock ...
+func (r *BlockRepository) Unblock(ctx context.Context, blockerID int, blockedID int) error {
+	const op = "BlockRepository.Unblock"
+
+	const query = `
+        DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, blockerID, blockedID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListBlocked ...
+func (r *BlockRepository) ListBlocked(ctx context.Context, blockerID int, limit int, offset int) ([]model.BlockedUser, error) {
+	const op = "BlockRepository.ListBlocked"
+
+	const query = `
+        SELECT blocked_id, created_at
+        FROM blocks
+        WHERE blocker_id = $1
+        ORDER BY created_at DESC, blocked_id
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, blockerID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var blocked []model.BlockedUser
+	for rows.Next() {
+		var b model.BlockedUser
+		if err := rows.Scan(&b.UserID, &b.BlockedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		blocked = append(blocked, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return blocked, nil
+}
+
+// IsBlocked - заблокировал ли кто-то из пары другого.
+func (r *BlockRepository) IsBlocked(ctx context.Context, user1ID int, user2ID int) (bool, error) {
+	const op = "BlockRepository.IsBlocked"
+
+	const query = `
+        SELECT EXISTS (
+            SELECT 1 FROM blocks
+            WHERE (blocker_id = $1 AND blocked_id = $2)
+               OR (blocker_id = $2 AND blocked_id = $1)
+        )
+    `
+
+	var blocked bool
+	if err := r.db.QueryRowContext(ctx, query, user1ID, user2ID).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return blocked, nil
+}
+
+// DeleteUserBlocks удаляет блокировки, где участвует userID (пользователь удалён).
+func (r *BlockRepository) DeleteUserBlocks(ctx context.Context, userID int) error {
+	const op = "BlockRepository.DeleteUserBlocks"
+
+	const query = `
+        DELETE FROM blocks WHERE blocker_id = $1 OR blocked_id = $1
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -95,7 +95,15 @@ func (r *ChatRepository) GetUserChats(ctx context.Context, userID int, limit int
                  ELSE c.user1_id END                       AS companion_id,
             COALESCE(m.text, '')                           AS last_message,
             COALESCE(cm.unread_count, 0)                   AS unread_count,
-            m.created_at                                   AS last_message_at
+            m.created_at                                   AS last_message_at,
+            CASE WHEN cm.muted_until > now()
+                 THEN cm.muted_until END                   AS muted_until,
+            EXISTS (
+                SELECT 1 FROM blocks b
+                WHERE b.blocker_id = $1
+                  AND b.blocked_id = CASE WHEN c.user1_id = $1 THEN c.user2_id
+                                          ELSE c.user1_id END
+            )                                              AS companion_blocked
         FROM chats c
         JOIN chat_members cm ON cm.chat_id = c.id AND cm.user_id = $1
         LEFT JOIN LATERAL (
@@ -128,6 +136,8 @@ func (r *ChatRepository) GetUserChats(ctx context.Context, userID int, limit int
 			&chat.LastMessage,
 			&chat.UnreadCount,
 			&chat.LastMessageAt,
+			&chat.MutedUntil,
+			&chat.CompanionBlocked,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
@@ -176,6 +186,51 @@ func (r *ChatRepository) ResetUnread(ctx context.Context, chatID int, userID int
 	return nil
 }
 
+// MuteChat заглушает чат для участника до until, nil - снимает.
+// false - userID не участник чата.
+func (r *ChatRepository) MuteChat(ctx context.Context, chatID int, userID int, until *time.Time) (bool, error) {
+	const op = "ChatRepository.MuteChat"
+
+	const query = `
+        UPDATE chat_members
+        SET muted_until = $3
+        WHERE chat_id = $1 AND user_id = $2
+    `
+
+	res, err := r.db.ExecContext(ctx, query, chatID, userID, until)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n > 0, nil
+}
+
+// DeleteUserChats удаляет все чаты пользователя вместе с сообщениями и участниками (ON DELETE CASCADE).
+func (r *ChatRepository) DeleteUserChats(ctx context.Context, userID int) (int, error) {
+	const op = "ChatRepository.DeleteUserChats"
+
+	const query = `
+        DELETE FROM chats WHERE user1_id = $1 OR user2_id = $1
+    `
+
+	res, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(n), nil
+}
+
 // GetParticipants ...
 func (r *ChatRepository) GetParticipants(ctx context.Context, chatID int) (user1ID int, user2ID int, err error) {
 	const op = "ChatRepository.GetParticipants"
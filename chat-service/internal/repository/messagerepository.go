@@ -2,9 +2,11 @@
 package repository
 
 import (
+	chaterror "chat/internal/error"
 	"chat/internal/model"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -79,31 +81,91 @@ func (r *MessageRepository) GetMessages(ctx context.Context, chatID int, limit i
 	return messages, nil
 }
 
-// SendMessage ...
-func (r *MessageRepository) SendMessage(ctx context.Context, chatID int, senderID int, text string) (int, time.Time, error) {
+// GetMessagesAfter возвращает сообщения из всех чатов пользователя с id больше afterID
+// в порядке отправки - для догрузки пропущенного при переподключении.
+func (r *MessageRepository) GetMessagesAfter(ctx context.Context, userID int, afterID int, limit int) ([]model.MassageDTO, error) {
+	const op = "MessageRepository.GetMessagesAfter"
+
+	const query = `
+		SELECT m.id, m.chat_id, m.sender_id, m.text, m.created_at,
+		       COALESCE(cm.muted_until > now(), false)
+		FROM messages m
+		JOIN chat_members cm ON cm.chat_id = m.chat_id
+		WHERE cm.user_id = $1 AND m.id > $2
+		ORDER BY m.id ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messages []model.MassageDTO
+	for rows.Next() {
+		var msg model.MassageDTO
+		if err := rows.Scan(
+			&msg.ID,
+			&msg.ChatID,
+			&msg.SenderID,
+			&msg.Text,
+			&msg.CreatedAt,
+			&msg.Muted,
+		); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return messages, nil
+}
+
+// SendMessage сохраняет сообщение, если собеседники не заблокировали друг друга, иначе
+// chaterror.ErrBlocked. Блокировка проверяется в том же запросе, что и вставка: между
+// проверкой и вставкой заблокировать уже не успеют. recipientMuted - собеседник заглушил чат.
+func (r *MessageRepository) SendMessage(ctx context.Context, chatID int, senderID int, text string) (messageID int, createdAt time.Time, recipientMuted bool, err error) {
 	const op = "MessageRepository.SendMessage"
 
+	// Заглушившим чат участникам непрочитанные не увеличиваем
 	const query = `
-		WITH inserted AS (
+		WITH recipient AS (
+			SELECT user_id, muted_until IS NOT NULL AND muted_until > now() AS muted
+			FROM chat_members
+			WHERE chat_id = $1 AND user_id != $2
+		), allowed AS (
+			SELECT 1 FROM recipient
+			WHERE NOT EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (blocker_id = $2 AND blocked_id = recipient.user_id)
+				   OR (blocker_id = recipient.user_id AND blocked_id = $2)
+			)
+		), inserted AS (
 			INSERT INTO messages (chat_id, sender_id, text)
-			VALUES ($1, $2, $3)
+			SELECT $1, $2, $3 FROM allowed
 			RETURNING id, created_at
+		), bumped AS (
+			UPDATE chat_members
+			SET unread_count = unread_count + 1
+			WHERE chat_id = $1 AND user_id != $2
+			  AND (muted_until IS NULL OR muted_until <= now())
+			  AND EXISTS (SELECT 1 FROM inserted)
 		)
-		UPDATE chat_members
-		SET unread_count = unread_count + 1
-		WHERE chat_id = $1 AND user_id != $2
-		RETURNING (SELECT id FROM inserted), (SELECT created_at FROM inserted)
+		SELECT inserted.id, inserted.created_at, recipient.muted
+		FROM inserted, recipient
 	`
 
-	var (
-		messageID int
-		createdAt time.Time
-	)
-
-	err := r.db.QueryRowContext(ctx, query, chatID, senderID, text).Scan(&messageID, &createdAt)
+	err = r.db.QueryRowContext(ctx, query, chatID, senderID, text).Scan(&messageID, &createdAt, &recipientMuted)
 	if err != nil {
-		return 0, time.Time{}, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, time.Time{}, false, fmt.Errorf("%s: %w", op, chaterror.ErrBlocked)
+		}
+		return 0, time.Time{}, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return messageID, createdAt, nil
+	return messageID, createdAt, recipientMuted, nil
 }
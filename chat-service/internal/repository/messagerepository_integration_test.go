@@ -0,0 +1,195 @@
+//go:build integration
+// +build integration
+
+package repository_test
+
+import (
+	"chat/internal/config"
+	chaterror "chat/internal/error"
+	"chat/internal/repository"
+	"context"
+	"database/sql"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	intCfg config.Config
+)
+
+// initIntegrationConfig загружает config-chat.toml относительно расположения этого файла.
+func init() {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		panic("cannot get caller info for messagerepository_integration_test.go")
+	}
+
+	configPath := filepath.Join(
+		filepath.Dir(filename),
+		"..", "..", "..",
+		"config-chat.toml",
+	)
+
+	c := config.NewConfig()
+	_, err := toml.DecodeFile(configPath, c)
+	if err != nil {
+		panic(err)
+	}
+
+	intCfg = *c
+}
+
+func skipIfNoIntegrationDSN(t *testing.T) {
+	t.Helper()
+
+	if strings.TrimSpace(intCfg.TestDatabaseURL) == "" {
+		t.Skip("test_database_url is empty in config-chat.toml; skipping integration tests")
+	}
+}
+
+// testDBIntegration открывает подключение к БД и возвращает функцию очистки.
+func testDBIntegration(t *testing.T, databaseURL string) (*sql.DB, func(...string)) {
+	t.Helper()
+
+	db, err := sql.Open("postgres", databaseURL)
+	require.NoError(t, err)
+
+	err = db.Ping()
+	require.NoError(t, err)
+
+	cleanup := func(tables ...string) {
+		if len(tables) > 0 {
+			query := "TRUNCATE " + strings.Join(tables, ", ") + " CASCADE"
+			_, err := db.Exec(query)
+			require.NoError(t, err)
+		}
+
+		_ = db.Close()
+	}
+
+	return db, cleanup
+}
+
+func unreadCount(t *testing.T, db *sql.DB, chatID int, userID int) int {
+	t.Helper()
+
+	var n int
+	err := db.QueryRow(`SELECT unread_count FROM chat_members WHERE chat_id = $1 AND user_id = $2`, chatID, userID).Scan(&n)
+	require.NoError(t, err)
+	return n
+}
+
+func messageCount(t *testing.T, db *sql.DB, chatID int) int {
+	t.Helper()
+
+	var n int
+	err := db.QueryRow(`SELECT count(*) FROM messages WHERE chat_id = $1`, chatID).Scan(&n)
+	require.NoError(t, err)
+	return n
+}
+
+func TestMessageRepository_SendMessage_Integration(t *testing.T) {
+	skipIfNoIntegrationDSN(t)
+
+	db, cleanup := testDBIntegration(t, intCfg.TestDatabaseURL)
+	defer cleanup("messages", "chat_members", "chats", "blocks")
+
+	ctx := context.Background()
+	chats := repository.NewChatRepository(db)
+	blocks := repository.NewBlockRepository(db)
+	messages := repository.NewMessageRepository(db)
+
+	chatID, _, _, err := chats.GetOrCreateChat(ctx, 7, 8)
+	require.NoError(t, err)
+
+	t.Run("delivered", func(t *testing.T) {
+		id, createdAt, recipientMuted, err := messages.SendMessage(ctx, chatID, 7, "hi")
+		require.NoError(t, err)
+		assert.NotZero(t, id)
+		assert.False(t, createdAt.IsZero())
+		assert.False(t, recipientMuted)
+		assert.Equal(t, 1, unreadCount(t, db, chatID, 8))
+		assert.Equal(t, 0, unreadCount(t, db, chatID, 7))
+	})
+
+	t.Run("recipient muted", func(t *testing.T) {
+		until := time.Now().Add(time.Hour)
+		_, err := chats.MuteChat(ctx, chatID, 8, &until)
+		require.NoError(t, err)
+		defer func() {
+			_, err := chats.MuteChat(ctx, chatID, 8, nil)
+			require.NoError(t, err)
+		}()
+
+		before := unreadCount(t, db, chatID, 8)
+		_, _, recipientMuted, err := messages.SendMessage(ctx, chatID, 7, "muted")
+		require.NoError(t, err)
+		assert.True(t, recipientMuted)
+		assert.Equal(t, before, unreadCount(t, db, chatID, 8))
+
+		// Заглушка одного участника не касается другого
+		_, _, recipientMuted, err = messages.SendMessage(ctx, chatID, 8, "reply")
+		require.NoError(t, err)
+		assert.False(t, recipientMuted)
+	})
+
+	t.Run("blocked", func(t *testing.T) {
+		// Блокировка действует в обе стороны
+		require.NoError(t, blocks.Block(ctx, 8, 7))
+		defer func() { require.NoError(t, blocks.Unblock(ctx, 8, 7)) }()
+
+		before := messageCount(t, db, chatID)
+		unread := unreadCount(t, db, chatID, 8)
+
+		for _, senderID := range []int{7, 8} {
+			_, _, _, err := messages.SendMessage(ctx, chatID, senderID, "blocked")
+			require.ErrorIs(t, err, chaterror.ErrBlocked)
+		}
+		assert.Equal(t, before, messageCount(t, db, chatID))
+		assert.Equal(t, unread, unreadCount(t, db, chatID, 8))
+	})
+}
+
+func TestMessageRepository_GetMessagesAfter_Integration(t *testing.T) {
+	skipIfNoIntegrationDSN(t)
+
+	db, cleanup := testDBIntegration(t, intCfg.TestDatabaseURL)
+	defer cleanup("messages", "chat_members", "chats", "blocks")
+
+	ctx := context.Background()
+	chats := repository.NewChatRepository(db)
+	messages := repository.NewMessageRepository(db)
+
+	chatID, _, _, err := chats.GetOrCreateChat(ctx, 7, 8)
+	require.NoError(t, err)
+
+	firstID, _, _, err := messages.SendMessage(ctx, chatID, 7, "before mute")
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour)
+	_, err = chats.MuteChat(ctx, chatID, 8, &until)
+	require.NoError(t, err)
+
+	_, _, _, err = messages.SendMessage(ctx, chatID, 7, "after mute")
+	require.NoError(t, err)
+
+	// Пометка берётся из текущей заглушки получателя, у отправителя её нет
+	missed, err := messages.GetMessagesAfter(ctx, 8, firstID-1, 10)
+	require.NoError(t, err)
+	require.Len(t, missed, 2)
+	assert.True(t, missed[0].Muted)
+	assert.True(t, missed[1].Muted)
+
+	own, err := messages.GetMessagesAfter(ctx, 7, firstID-1, 10)
+	require.NoError(t, err)
+	require.Len(t, own, 2)
+	assert.False(t, own[0].Muted)
+}
@@ -1,7 +1,12 @@
 // Package sqlstore ...
 package sqlstore
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"github.com/XSAM/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
 
 // SQLStore ...
 type SQLStore struct {
@@ -9,8 +14,15 @@ type SQLStore struct {
 }
 
 // New ...
+// Запросы репозиториев пишутся спанами через otelsql.
 func New(databaseURL string) (*SQLStore, error) {
-	db, err := sql.Open("postgres", databaseURL)
+	db, err := otelsql.Open("postgres", databaseURL,
+		otelsql.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+		}),
+	)
 	if err != nil {
 		return nil, err
 	}
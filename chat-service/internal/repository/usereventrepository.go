@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// UserEventRepository хранит, до какого события дочитан поток событий о пользователях.
+type UserEventRepository struct {
+	db *sql.DB
+}
+
+// NewUserEventRepository ...
+func NewUserEventRepository(db *sql.DB) *UserEventRepository {
+	return &UserEventRepository{db: db}
+}
+
+// LastEventID ...
+func (r *UserEventRepository) LastEventID(ctx context.Context) (int64, error) {
+	const op = "UserEventRepository.LastEventID"
+
+	const query = `
+        SELECT last_event_id FROM user_events_cursor
+    `
+
+	var id int64
+	if err := r.db.QueryRowContext(ctx, query).Scan(&id); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// SaveLastEventID ...
+func (r *UserEventRepository) SaveLastEventID(ctx context.Context, id int64) error {
+	const op = "UserEventRepository.SaveLastEventID"
+
+	const query = `
+        UPDATE user_events_cursor
+        SET last_event_id = $1, updated_at = now()
+    `
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var messagesSent = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "chat_messages_sent_total",
+	Help: "Messages saved by SendMessage.",
+})
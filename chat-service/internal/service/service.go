@@ -2,29 +2,41 @@
 package service
 
 import (
+	"auth/pkg/authn"
 	chaterror "chat/internal/error"
-	"chat/internal/interceptor"
 	"chat/internal/model"
 	chatv1 "chat/proto/chat/v1"
 	"context"
+	"log/slog"
 	"time"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const maxMissedMessages = 500
+
 // Service ...
 type Service struct {
 	chatRepo    ChatRepository
 	messageRepo MessageRepository
+	blockRepo   BlockRepository
 	hub         Hub
+	profiles    Profiles
+	users       Users
+	logger      *slog.Logger
 }
 
 // NewService ...
-func NewService(chatRepo ChatRepository, messageRepo MessageRepository, hub Hub) *Service {
+func NewService(chatRepo ChatRepository, messageRepo MessageRepository, blockRepo BlockRepository, hub Hub, profiles Profiles, users Users, logger *slog.Logger) *Service {
 	return &Service{
 		chatRepo:    chatRepo,
 		messageRepo: messageRepo,
+		blockRepo:   blockRepo,
 		hub:         hub,
+		profiles:    profiles,
+		users:       users,
+		logger:      logger,
 	}
 }
 
@@ -40,14 +52,50 @@ type ChatRepository interface {
 	ResetUnread(ctx context.Context, chatID int, userID int) error
 	// GetParticipants ...
 	GetParticipants(ctx context.Context, chatID int) (user1ID int, user2ID int, err error)
+	// DeleteUserChats ...
+	DeleteUserChats(ctx context.Context, userID int) (deleted int, err error)
+	// MuteChat ...
+	MuteChat(ctx context.Context, chatID int, userID int, until *time.Time) (isMember bool, err error)
+}
+
+// BlockRepository ...
+type BlockRepository interface {
+	// Block ...
+	Block(ctx context.Context, blockerID int, blockedID int) error
+	// Unblock ...
+	Unblock(ctx context.Context, blockerID int, blockedID int) error
+	// ListBlocked ...
+	ListBlocked(ctx context.Context, blockerID int, limit int, offset int) ([]model.BlockedUser, error)
+	// IsBlocked ...
+	IsBlocked(ctx context.Context, user1ID int, user2ID int) (bool, error)
+	// DeleteUserBlocks ...
+	DeleteUserBlocks(ctx context.Context, userID int) error
 }
 
 // MessageRepository ...
 type MessageRepository interface {
 	// GetMessages ...
 	GetMessages(ctx context.Context, chatID int, limit int, cursor string) ([]model.MassageDTO, error)
+	// GetMessagesAfter ...
+	GetMessagesAfter(ctx context.Context, userID int, afterID int, limit int) ([]model.MassageDTO, error)
 	// SendMessage ...
-	SendMessage(ctx context.Context, chatID int, senderID int, text string) (messageID int, createdAt time.Time, err error)
+	SendMessage(ctx context.Context, chatID int, senderID int, text string) (messageID int, createdAt time.Time, recipientMuted bool, err error)
+}
+
+// Profiles ...
+type Profiles interface {
+	// BatchGetProfiles ...
+	BatchGetProfiles(ctx context.Context, userIDs []int) (map[int]model.Profile, error)
+	// ProfileByUsername ...
+	ProfileByUsername(ctx context.Context, username string) (model.Profile, error)
+}
+
+// Users ...
+type Users interface {
+	// UsersExist ...
+	UsersExist(ctx context.Context, userIDs []int) (map[int]bool, error)
+	// Forget ...
+	Forget(userID int)
 }
 
 // Hub ...
@@ -57,13 +105,53 @@ type Hub interface {
 }
 
 // GetOrCreateChat ...
+// Собеседник должен существовать в auth-service: чат с несуществующим id не создаём.
 func (s *Service) GetOrCreateChat(ctx context.Context, initiatorID int, recipientID int) (chatID int, created bool, createdAt time.Time, err error) {
+	if initiatorID == recipientID {
+		return 0, false, time.Time{}, chaterror.ErrSelfChat
+	}
+
+	exists, err := s.users.UsersExist(ctx, []int{recipientID})
+	if err != nil {
+		return 0, false, time.Time{}, err
+	}
+	if !exists[recipientID] {
+		return 0, false, time.Time{}, chaterror.ErrUserNotFound
+	}
+
+	return s.getOrCreateChat(ctx, initiatorID, recipientID)
+}
+
+// GetOrCreateChatByUsername - как GetOrCreateChat, но собеседник задан @username.
+func (s *Service) GetOrCreateChatByUsername(ctx context.Context, initiatorID int, username string) (chatID int, created bool, createdAt time.Time, err error) {
+	recipient, err := s.profiles.ProfileByUsername(ctx, username)
+	if err != nil {
+		return 0, false, time.Time{}, err
+	}
+	if recipient.UserID == initiatorID {
+		return 0, false, time.Time{}, chaterror.ErrSelfChat
+	}
+
+	return s.getOrCreateChat(ctx, initiatorID, recipient.UserID)
+}
+
+// getOrCreateChat - чат двух существующих пользователей, если никто из них не заблокировал другого.
+// Блокировка закрывает и уже существующий чат: иначе его id пригодился бы для SendMessage.
+func (s *Service) getOrCreateChat(ctx context.Context, initiatorID int, recipientID int) (chatID int, created bool, createdAt time.Time, err error) {
+	blocked, err := s.blockRepo.IsBlocked(ctx, initiatorID, recipientID)
+	if err != nil {
+		return 0, false, time.Time{}, err
+	}
+	if blocked {
+		return 0, false, time.Time{}, chaterror.ErrBlocked
+	}
+
 	return s.chatRepo.GetOrCreateChat(ctx, initiatorID, recipientID)
 }
 
 // GetMessages ...
 func (s *Service) GetMessages(ctx context.Context, chatID int, limit int, cursor string) (massages []model.MassageDTO, nextCursor string, err error) {
-	callerID, ok := ctx.Value(interceptor.UserIDKey).(int)
+	callerID, ok := authn.UserID(ctx)
 	if !ok {
 		return nil, "", chaterror.ErrUnauthenticated
 	}
@@ -109,10 +197,57 @@ func (s *Service) GetUserChats(ctx context.Context, userID int, limit int, offse
 		return nil, err
 	}
 
+	s.withCompanions(ctx, chat)
+
 	return chat, nil
 }
 
-// SendMessage ...
+// withCompanions подставляет профили собеседников одним запросом в auth-service.
+// Без профилей список чатов всё равно отдаём: клиент покажет companion_id.
+func (s *Service) withCompanions(ctx context.Context, chats []model.ChatPreviewDTO) {
+	if len(chats) == 0 {
+		return
+	}
+
+	ids := make([]int, len(chats))
+	for i := range chats {
+		ids[i] = chats[i].CompanionID
+	}
+
+	profiles, err := s.profiles.BatchGetProfiles(ctx, ids)
+	if err != nil {
+		s.logger.WarnContext(ctx, "companion profiles not loaded", slog.String("err", err.Error()))
+		return
+	}
+
+	for i := range chats {
+		chats[i].Companion = profiles[chats[i].CompanionID]
+	}
+}
+
+// MissedMessages возвращает сообщения, пришедшие пользователю после afterID.
+// Если их больше maxMissedMessages - chaterror.ErrResyncRequired: отдавать часть
+// нельзя, клиент молча потерял бы остальное. Пусть перечитает чаты через GetMessages.
+func (s *Service) MissedMessages(ctx context.Context, afterID int) ([]model.MassageDTO, error) {
+	callerID, ok := authn.UserID(ctx)
+	if !ok {
+		return nil, chaterror.ErrUnauthenticated
+	}
+
+	missed, err := s.messageRepo.GetMessagesAfter(ctx, callerID, afterID, maxMissedMessages+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(missed) > maxMissedMessages {
+		return nil, chaterror.ErrResyncRequired
+	}
+
+	return missed, nil
+}
+
+// SendMessage сохраняет сообщение и отправляет его обоим участникам. Блокировку
+// проверяет сам запрос вставки. Получатель, заглушивший чат, получает сообщение с
+// пометкой muted: оно появляется в чате, но без уведомления.
 func (s *Service) SendMessage(ctx context.Context, chatID int, senderID int, text string) (int, time.Time, error) {
 	isMember, err := s.chatRepo.IsMember(ctx, chatID, senderID)
 	if err != nil {
@@ -122,15 +257,21 @@ func (s *Service) SendMessage(ctx context.Context, chatID int, senderID int, tex
 		return 0, time.Time{}, chaterror.ErrPermissionDenied
 	}
 
-	messageID, createdAt, err := s.messageRepo.SendMessage(ctx, chatID, senderID, text)
+	user1ID, user2ID, err := s.chatRepo.GetParticipants(ctx, chatID)
 	if err != nil {
 		return 0, time.Time{}, err
 	}
 
-	user1ID, user2ID, err := s.chatRepo.GetParticipants(ctx, chatID)
+	messageID, createdAt, recipientMuted, err := s.messageRepo.SendMessage(ctx, chatID, senderID, text)
 	if err != nil {
 		return 0, time.Time{}, err
 	}
+	messagesSent.Inc()
+
+	recipientID := user1ID
+	if recipientID == senderID {
+		recipientID = user2ID
+	}
 
 	msg := &chatv1.MessageDTO{
 		Id:        int64(messageID),
@@ -139,9 +280,79 @@ func (s *Service) SendMessage(ctx context.Context, chatID int, senderID int, tex
 		Text:      text,
 		CreatedAt: timestamppb.New(createdAt),
 	}
+	s.hub.Push(senderID, msg)
 
-	s.hub.Push(user1ID, msg)
-	s.hub.Push(user2ID, msg)
+	if recipientMuted {
+		msg = proto.CloneOf(msg)
+		msg.Muted = true
+	}
+	s.hub.Push(recipientID, msg)
 
 	return messageID, createdAt, nil
 }
+
+// UserDeleted вызывается по событию удаления пользователя в auth-service:
+// его чаты удаляются у обоих участников. Повторный вызов ничего не меняет.
+func (s *Service) UserDeleted(ctx context.Context, userID int) error {
+	s.users.Forget(userID)
+
+	deleted, err := s.chatRepo.DeleteUserChats(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.blockRepo.DeleteUserBlocks(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "deleted user chats removed", slog.Int("userID", userID), slog.Int("chats", deleted))
+	return nil
+}
+
+// BlockUser - blockerID блокирует blockedID. Повторная блокировка не ошибка.
+func (s *Service) BlockUser(ctx context.Context, blockerID int, blockedID int) error {
+	if blockerID == blockedID {
+		return chaterror.ErrSelfBlock
+	}
+
+	exists, err := s.users.UsersExist(ctx, []int{blockedID})
+	if err != nil {
+		return err
+	}
+	if !exists[blockedID] {
+		return chaterror.ErrUserNotFound
+	}
+
+	return s.blockRepo.Block(ctx, blockerID, blockedID)
+}
+
+// UnblockUser ...
+func (s *Service) UnblockUser(ctx context.Context, blockerID int, blockedID int) error {
+	return s.blockRepo.Unblock(ctx, blockerID, blockedID)
+}
+
+// ListBlocked ...
+func (s *Service) ListBlocked(ctx context.Context, blockerID int, limit int, offset int) ([]model.BlockedUser, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	return s.blockRepo.ListBlocked(ctx, blockerID, limit, offset)
+}
+
+// MuteChat заглушает чат для userID до until. nil или момент в прошлом снимает заглушку,
+// тогда возвращается nil.
+func (s *Service) MuteChat(ctx context.Context, chatID int, userID int, until *time.Time) (*time.Time, error) {
+	if until != nil && !until.After(time.Now()) {
+		until = nil
+	}
+
+	isMember, err := s.chatRepo.MuteChat(ctx, chatID, userID, until)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, chaterror.ErrPermissionDenied
+	}
+
+	return until, nil
+}
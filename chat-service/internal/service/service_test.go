@@ -0,0 +1,327 @@
+package service
+
+import (
+	"auth/pkg/authn"
+	chaterror "chat/internal/error"
+	"chat/internal/model"
+	chatv1 "chat/proto/chat/v1"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChats - ChatRepository в памяти: userChats - у кого сколько чатов,
+// participants - участники чата, muted - до какого момента чат заглушён участником.
+type fakeChats struct {
+	ChatRepository
+	userChats    map[int]int
+	participants map[int][2]int
+	muted        map[[2]int]*time.Time
+	err          error
+}
+
+func (f *fakeChats) IsMember(_ context.Context, chatID int, userID int) (bool, error) {
+	p, ok := f.participants[chatID]
+	return ok && (p[0] == userID || p[1] == userID), nil
+}
+
+func (f *fakeChats) GetParticipants(_ context.Context, chatID int) (int, int, error) {
+	p := f.participants[chatID]
+	return p[0], p[1], nil
+}
+
+func (f *fakeChats) MuteChat(ctx context.Context, chatID int, userID int, until *time.Time) (bool, error) {
+	isMember, _ := f.IsMember(ctx, chatID, userID)
+	if !isMember {
+		return false, nil
+	}
+	if f.muted == nil {
+		f.muted = map[[2]int]*time.Time{}
+	}
+	f.muted[[2]int{chatID, userID}] = until
+	return true, nil
+}
+
+func (f *fakeChats) DeleteUserChats(_ context.Context, userID int) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := f.userChats[userID]
+	delete(f.userChats, userID)
+	return n, nil
+}
+
+// fakeBlocks - BlockRepository в памяти: пары blocker -> blocked.
+type fakeBlocks struct {
+	BlockRepository
+	blocks map[[2]int]bool
+}
+
+func (f *fakeBlocks) DeleteUserBlocks(_ context.Context, userID int) error {
+	for pair := range f.blocks {
+		if pair[0] == userID || pair[1] == userID {
+			delete(f.blocks, pair)
+		}
+	}
+	return nil
+}
+
+func (f *fakeBlocks) Block(_ context.Context, blockerID int, blockedID int) error {
+	f.blocks[[2]int{blockerID, blockedID}] = true
+	return nil
+}
+
+// fakeMessages отвечает как запрос вставки: blocked - ErrBlocked без сохранения,
+// иначе сохраняет текст и сообщает, заглушил ли получатель чат.
+type fakeMessages struct {
+	MessageRepository
+	blocked        bool
+	recipientMuted bool
+	sent           []string
+	after          int
+}
+
+// GetMessagesAfter отдаёт min(after, limit) сообщений: after - сколько их пропущено.
+func (f *fakeMessages) GetMessagesAfter(_ context.Context, _ int, afterID int, limit int) ([]model.MassageDTO, error) {
+	var messages []model.MassageDTO
+	for i := 1; i <= f.after && i <= limit; i++ {
+		messages = append(messages, model.MassageDTO{ID: afterID + i})
+	}
+	return messages, nil
+}
+
+func (f *fakeMessages) SendMessage(_ context.Context, _ int, _ int, text string) (int, time.Time, bool, error) {
+	if f.blocked {
+		return 0, time.Time{}, false, chaterror.ErrBlocked
+	}
+	f.sent = append(f.sent, text)
+	return len(f.sent), time.Now(), f.recipientMuted, nil
+}
+
+// fakeHub запоминает, кому что отправлено.
+type fakeHub struct {
+	pushed map[int][]*chatv1.MessageDTO
+}
+
+func (f *fakeHub) Push(userID int, msg *chatv1.MessageDTO) {
+	if f.pushed == nil {
+		f.pushed = map[int][]*chatv1.MessageDTO{}
+	}
+	f.pushed[userID] = append(f.pushed[userID], msg)
+}
+
+// fakeUsers отвечает на UsersExist из known и запоминает, кого забыли.
+type fakeUsers struct {
+	Users
+	known     map[int]bool
+	forgotten []int
+}
+
+func (f *fakeUsers) UsersExist(_ context.Context, userIDs []int) (map[int]bool, error) {
+	exists := make(map[int]bool, len(userIDs))
+	for _, id := range userIDs {
+		exists[id] = f.known[id]
+	}
+	return exists, nil
+}
+
+func (f *fakeUsers) Forget(userID int) {
+	f.forgotten = append(f.forgotten, userID)
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_UserDeleted(t *testing.T) {
+	t.Parallel()
+
+	chats := &fakeChats{userChats: map[int]int{7: 2, 8: 1}}
+	blocks := &fakeBlocks{blocks: map[[2]int]bool{{7, 8}: true, {9, 7}: true, {8, 9}: true}}
+	users := &fakeUsers{}
+	s := NewService(chats, nil, blocks, nil, nil, users, testLogger())
+	ctx := context.Background()
+
+	require.NoError(t, s.UserDeleted(ctx, 7))
+
+	assert.Equal(t, map[int]int{8: 1}, chats.userChats)
+	assert.Equal(t, map[[2]int]bool{{8, 9}: true}, blocks.blocks)
+	assert.Equal(t, []int{7}, users.forgotten)
+
+	// Событие может прийти повторно - второй вызов ничего не ломает
+	require.NoError(t, s.UserDeleted(ctx, 7))
+	assert.Equal(t, map[int]int{8: 1}, chats.userChats)
+}
+
+func TestService_UserDeletedError(t *testing.T) {
+	t.Parallel()
+
+	dbErr := errors.New("db down")
+	blocks := &fakeBlocks{blocks: map[[2]int]bool{{7, 8}: true}}
+	s := NewService(&fakeChats{err: dbErr}, nil, blocks, nil, nil, &fakeUsers{}, testLogger())
+
+	err := s.UserDeleted(context.Background(), 7)
+
+	require.ErrorIs(t, err, dbErr)
+	// Блокировки не трогаем: событие придёт снова, и удаление повторится целиком
+	assert.Len(t, blocks.blocks, 1)
+}
+
+func TestService_SendMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		senderID       int
+		blocked        bool
+		recipientMuted bool
+		wantErr        error
+		wantMuted      bool
+	}{
+		{name: "delivered", senderID: 7},
+		{name: "recipient muted", senderID: 7, recipientMuted: true, wantMuted: true},
+		{name: "blocked", senderID: 7, blocked: true, wantErr: chaterror.ErrBlocked},
+		{name: "not a member", senderID: 9, wantErr: chaterror.ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chats := &fakeChats{participants: map[int][2]int{1: {7, 8}}}
+			messages := &fakeMessages{blocked: tt.blocked, recipientMuted: tt.recipientMuted}
+			hub := &fakeHub{}
+			s := NewService(chats, messages, &fakeBlocks{}, hub, nil, &fakeUsers{}, testLogger())
+
+			_, _, err := s.SendMessage(context.Background(), 1, tt.senderID, "hi")
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, messages.sent)
+				assert.Empty(t, hub.pushed)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, hub.pushed[7], 1)
+			require.Len(t, hub.pushed[8], 1)
+			// Отправитель видит своё сообщение как обычно, пометку получает только получатель
+			assert.False(t, hub.pushed[7][0].GetMuted())
+			assert.Equal(t, tt.wantMuted, hub.pushed[8][0].GetMuted())
+			assert.Equal(t, "hi", hub.pushed[8][0].GetText())
+		})
+	}
+}
+
+func TestService_MuteChat(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		userID  int
+		until   *time.Time
+		want    *time.Time
+		wantErr error
+	}{
+		{name: "mute", userID: 7, until: &future, want: &future},
+		{name: "unmute", userID: 7},
+		{name: "past time unmutes", userID: 7, until: &past},
+		{name: "not a member", userID: 9, until: &future, wantErr: chaterror.ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chats := &fakeChats{participants: map[int][2]int{1: {7, 8}}}
+			s := NewService(chats, nil, nil, nil, nil, nil, testLogger())
+
+			got, err := s.MuteChat(context.Background(), 1, tt.userID, tt.until)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, chats.muted)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, chats.muted[[2]int{1, tt.userID}])
+		})
+	}
+}
+
+func TestService_BlockUser(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		blockedID int
+		wantErr   error
+	}{
+		{name: "block", blockedID: 8},
+		{name: "self", blockedID: 7, wantErr: chaterror.ErrSelfBlock},
+		{name: "unknown user", blockedID: 9, wantErr: chaterror.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			blocks := &fakeBlocks{blocks: map[[2]int]bool{}}
+			users := &fakeUsers{known: map[int]bool{7: true, 8: true}}
+			s := NewService(nil, nil, blocks, nil, nil, users, testLogger())
+
+			err := s.BlockUser(context.Background(), 7, tt.blockedID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, blocks.blocks)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[[2]int]bool{{7, 8}: true}, blocks.blocks)
+		})
+	}
+}
+
+func TestService_MissedMessages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		missed  int
+		wantLen int
+		wantErr error
+	}{
+		{name: "nothing missed"},
+		{name: "within limit", missed: maxMissedMessages, wantLen: maxMissedMessages},
+		// Часть истории не отдаём - клиент молча потерял бы остальное
+		{name: "too many", missed: maxMissedMessages + 1, wantErr: chaterror.ErrResyncRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewService(nil, &fakeMessages{after: tt.missed}, nil, nil, nil, nil, testLogger())
+			ctx := authn.NewContext(context.Background(), authn.Principal{UserID: 7})
+
+			got, err := s.MissedMessages(ctx, 42)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
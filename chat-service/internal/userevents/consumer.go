@@ -0,0 +1,101 @@
+// Package userevents ...
+package userevents
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Переподключение к auth-service после обрыва потока.
+const (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// Source ...
+type Source interface {
+	// WatchUserDeletions передаёт в fn удаления после afterID вместе с курсором для
+	// возобновления. Курсор - не id события: события с меньшим id могут прийти позже.
+	WatchUserDeletions(ctx context.Context, afterID int64, fn func(cursor int64, userID int) error) error
+}
+
+// Cursor хранит курсор потока между перезапусками.
+type Cursor interface {
+	// LastEventID ...
+	LastEventID(ctx context.Context) (int64, error)
+	// SaveLastEventID ...
+	SaveLastEventID(ctx context.Context, id int64) error
+}
+
+// Handler ...
+type Handler interface {
+	// UserDeleted ...
+	UserDeleted(ctx context.Context, userID int) error
+}
+
+// Consumer читает удаления пользователей из auth-service и передаёт их в Handler.
+// Курсор из события сохраняется после его обработки. Он отстаёт от последних событий
+// (auth-service держит окно для поздних коммитов), поэтому после переподключения часть
+// событий приходит повторно - обработчик должен это переживать.
+type Consumer struct {
+	source  Source
+	cursor  Cursor
+	handler Handler
+	logger  *slog.Logger
+}
+
+// New ...
+func New(source Source, cursor Cursor, handler Handler, logger *slog.Logger) *Consumer {
+	return &Consumer{
+		source:  source,
+		cursor:  cursor,
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Run читает поток до отмены ctx, переподключаясь после ошибок.
+func (c *Consumer) Run(ctx context.Context) {
+	backoff := minBackoff
+	for {
+		started := time.Now()
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// Поток долго работал - это новый сбой, а не серия неудачных подключений
+		if time.Since(started) > maxBackoff {
+			backoff = minBackoff
+		}
+		if err != nil {
+			c.logger.WarnContext(ctx, "user events stream failed", slog.String("err", err.Error()), slog.Duration("retryIn", backoff))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func (c *Consumer) consume(ctx context.Context) error {
+	afterID, err := c.cursor.LastEventID(ctx)
+	if err != nil {
+		return err
+	}
+
+	saved := afterID
+	return c.source.WatchUserDeletions(ctx, afterID, func(cursor int64, userID int) error {
+		if err := c.handler.UserDeleted(ctx, userID); err != nil {
+			return err
+		}
+		if cursor <= saved {
+			return nil
+		}
+		saved = cursor
+		return c.cursor.SaveLastEventID(ctx, cursor)
+	})
+}
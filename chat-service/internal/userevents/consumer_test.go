@@ -0,0 +1,121 @@
+package userevents
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// delivery - одно событие потока: курсор и удалённый пользователь.
+type delivery struct {
+	cursor int64
+	userID int
+}
+
+// fakeSource отдаёт заданные события и запоминает, откуда его читали.
+type fakeSource struct {
+	events  []delivery
+	afterID int64
+}
+
+func (f *fakeSource) WatchUserDeletions(_ context.Context, afterID int64, fn func(cursor int64, userID int) error) error {
+	f.afterID = afterID
+	for _, e := range f.events {
+		if err := fn(e.cursor, e.userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeCursor struct {
+	last  int64
+	saved []int64
+}
+
+func (f *fakeCursor) LastEventID(context.Context) (int64, error) {
+	return f.last, nil
+}
+
+func (f *fakeCursor) SaveLastEventID(_ context.Context, id int64) error {
+	f.last = id
+	f.saved = append(f.saved, id)
+	return nil
+}
+
+type fakeHandler struct {
+	deleted []int
+	failOn  int
+}
+
+func (f *fakeHandler) UserDeleted(_ context.Context, userID int) error {
+	if userID == f.failOn {
+		return errors.New("db down")
+	}
+	f.deleted = append(f.deleted, userID)
+	return nil
+}
+
+func newTestConsumer(source *fakeSource, cursor *fakeCursor, handler *fakeHandler) *Consumer {
+	return New(source, cursor, handler, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestConsumer_Consume(t *testing.T) {
+	t.Parallel()
+
+	// Курсор отстаёт от событий и растёт, только когда auth-service его сдвинул
+	source := &fakeSource{events: []delivery{
+		{cursor: 10, userID: 7},
+		{cursor: 10, userID: 8},
+		{cursor: 13, userID: 9},
+		{cursor: 13, userID: 9}, // повтор после переподключения
+	}}
+	cursor := &fakeCursor{last: 10}
+	handler := &fakeHandler{}
+
+	err := newTestConsumer(source, cursor, handler).consume(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), source.afterID)
+	assert.Equal(t, []int{7, 8, 9, 9}, handler.deleted)
+	assert.Equal(t, []int64{13}, cursor.saved)
+}
+
+func TestConsumer_ConsumeHandlerError(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeSource{events: []delivery{
+		{cursor: 5, userID: 7},
+		{cursor: 6, userID: 8},
+		{cursor: 7, userID: 9},
+	}}
+	cursor := &fakeCursor{}
+	handler := &fakeHandler{failOn: 8}
+
+	err := newTestConsumer(source, cursor, handler).consume(context.Background())
+
+	require.Error(t, err)
+	assert.Equal(t, []int{7}, handler.deleted)
+	// Курсор необработанного события не сохраняется - после переподключения оно придёт снова
+	assert.Equal(t, []int64{5}, cursor.saved)
+}
+
+func TestConsumer_RunStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		newTestConsumer(&fakeSource{}, &fakeCursor{}, &fakeHandler{}).Run(ctx)
+		close(done)
+	}()
+
+	<-done
+}
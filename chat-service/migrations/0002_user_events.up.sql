@@ -0,0 +1,9 @@
+-- Курсор потока AuthService.WatchUserEvents: id последнего обработанного события.
+-- Одна строка, после перезапуска чтение продолжается с неё.
+CREATE TABLE user_events_cursor (
+    id            BOOLEAN     PRIMARY KEY DEFAULT true CHECK (id),
+    last_event_id BIGINT      NOT NULL DEFAULT 0,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO user_events_cursor DEFAULT VALUES;
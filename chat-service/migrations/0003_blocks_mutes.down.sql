@@ -0,0 +1,2 @@
+ALTER TABLE chat_members DROP COLUMN IF EXISTS muted_until;
+DROP TABLE IF EXISTS blocks;
@@ -0,0 +1,17 @@
+-- Блокировки: blocker_id не получает сообщений от blocked_id, новый чат между ними
+-- не создаётся. Блокировка действует в обе стороны, но снять её может только blocker.
+CREATE TABLE blocks (
+    blocker_id BIGINT      NOT NULL,
+    blocked_id BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT chk_block_self CHECK (blocker_id <> blocked_id)
+);
+
+-- Для проверки "заблокировал ли меня собеседник"
+CREATE INDEX idx_blocks_blocked ON blocks (blocked_id);
+
+-- Заглушенный чат: пока muted_until в будущем, новые сообщения не увеличивают
+-- unread_count участника. NULL - не заглушен.
+ALTER TABLE chat_members ADD COLUMN muted_until TIMESTAMPTZ;
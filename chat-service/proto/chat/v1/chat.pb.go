@@ -11,6 +11,8 @@ import (
 	sync "sync"
 	unsafe "unsafe"
 
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -24,10 +26,15 @@ const (
 )
 
 // SubscribeRequest
+// after_message_id > 0 - возобновление: сначала придут пропущенные сообщения
+// с id больше указанного, затем новые. Если пропущено слишком много, стрим
+// завершается ошибкой OUT_OF_RANGE с кодом RESYNC_REQUIRED: клиент перечитывает
+// чаты через GetMessages и подписывается без after_message_id.
 type SubscribeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AfterMessageId int64                  `protobuf:"varint,1,opt,name=after_message_id,json=afterMessageId,proto3" json:"after_message_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SubscribeRequest) Reset() {
@@ -60,11 +67,24 @@ func (*SubscribeRequest) Descriptor() ([]byte, []int) {
 	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *SubscribeRequest) GetAfterMessageId() int64 {
+	if x != nil {
+		return x.AfterMessageId
+	}
+	return 0
+}
+
 // GetOrCreateChat
 type GetOrCreateChatRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	InitiatorId   int64                  `protobuf:"varint,1,opt,name=initiator_id,json=initiatorId,proto3" json:"initiator_id,omitempty"`
-	RecipientId   int64                  `protobuf:"varint,2,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	InitiatorId int64                  `protobuf:"varint,1,opt,name=initiator_id,json=initiatorId,proto3" json:"initiator_id,omitempty"`
+	// Собеседник по id или по @username; несуществующий - USER_NOT_FOUND
+	//
+	// Types that are valid to be assigned to Recipient:
+	//
+	//	*GetOrCreateChatRequest_RecipientId
+	//	*GetOrCreateChatRequest_RecipientUsername
+	Recipient     isGetOrCreateChatRequest_Recipient `protobuf_oneof:"recipient"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,13 +126,47 @@ func (x *GetOrCreateChatRequest) GetInitiatorId() int64 {
 	return 0
 }
 
+func (x *GetOrCreateChatRequest) GetRecipient() isGetOrCreateChatRequest_Recipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
 func (x *GetOrCreateChatRequest) GetRecipientId() int64 {
 	if x != nil {
-		return x.RecipientId
+		if x, ok := x.Recipient.(*GetOrCreateChatRequest_RecipientId); ok {
+			return x.RecipientId
+		}
 	}
 	return 0
 }
 
+func (x *GetOrCreateChatRequest) GetRecipientUsername() string {
+	if x != nil {
+		if x, ok := x.Recipient.(*GetOrCreateChatRequest_RecipientUsername); ok {
+			return x.RecipientUsername
+		}
+	}
+	return ""
+}
+
+type isGetOrCreateChatRequest_Recipient interface {
+	isGetOrCreateChatRequest_Recipient()
+}
+
+type GetOrCreateChatRequest_RecipientId struct {
+	RecipientId int64 `protobuf:"varint,2,opt,name=recipient_id,json=recipientId,proto3,oneof"`
+}
+
+type GetOrCreateChatRequest_RecipientUsername struct {
+	RecipientUsername string `protobuf:"bytes,3,opt,name=recipient_username,json=recipientUsername,proto3,oneof"`
+}
+
+func (*GetOrCreateChatRequest_RecipientId) isGetOrCreateChatRequest_Recipient() {}
+
+func (*GetOrCreateChatRequest_RecipientUsername) isGetOrCreateChatRequest_Recipient() {}
+
 type GetOrCreateChatResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
@@ -176,10 +230,11 @@ func (x *GetOrCreateChatResponse) GetCreatedAt() *timestamppb.Timestamp {
 // GetMessages — cursor-based пагинация
 // Курсор = created_at последнего полученного сообщения
 type GetMessagesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // рекомендуется 50
-	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // пустой = с самого нового, иначе — старше курсора
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Limit  int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 0 = 50 по умолчанию
+	// пустой = с самого нового, иначе — старше курсора (RFC 3339)
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -394,10 +449,11 @@ func (x *GetUserChatsResponse) GetChats() []*ChatPreviewDTO {
 
 // SendMessage
 type SendMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	SenderId      int64                  `protobuf:"varint,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ChatId   int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	SenderId int64                  `protobuf:"varint,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// Как CHECK в таблице messages: 1..4096 символов
+	Text          string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -512,6 +568,7 @@ type MessageDTO struct {
 	SenderId      int64                  `protobuf:"varint,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
 	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Muted         bool                   `protobuf:"varint,6,opt,name=muted,proto3" json:"muted,omitempty"` // Только в Subscribe: получатель заглушил чат, показать без уведомления.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -581,6 +638,13 @@ func (x *MessageDTO) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *MessageDTO) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
 // Превью чата для списка — последнее сообщение и собеседник
 type ChatPreviewDTO struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -589,8 +653,14 @@ type ChatPreviewDTO struct {
 	LastMessage   string                 `protobuf:"bytes,3,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`  // текст последнего сообщения
 	UnreadCount   int64                  `protobuf:"varint,4,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
 	LastMessageAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_message_at,json=lastMessageAt,proto3" json:"last_message_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Профиль собеседника из auth-service; пустые, если он недоступен
+	CompanionUsername    string                 `protobuf:"bytes,6,opt,name=companion_username,json=companionUsername,proto3" json:"companion_username,omitempty"`
+	CompanionDisplayName string                 `protobuf:"bytes,7,opt,name=companion_display_name,json=companionDisplayName,proto3" json:"companion_display_name,omitempty"`
+	CompanionAvatarUrl   string                 `protobuf:"bytes,8,opt,name=companion_avatar_url,json=companionAvatarUrl,proto3" json:"companion_avatar_url,omitempty"`
+	MutedUntil           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"`                     // null - чат не заглушен
+	CompanionBlocked     bool                   `protobuf:"varint,10,opt,name=companion_blocked,json=companionBlocked,proto3" json:"companion_blocked,omitempty"` // собеседник заблокирован пользователем
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *ChatPreviewDTO) Reset() {
@@ -658,43 +728,491 @@ func (x *ChatPreviewDTO) GetLastMessageAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ChatPreviewDTO) GetCompanionUsername() string {
+	if x != nil {
+		return x.CompanionUsername
+	}
+	return ""
+}
+
+func (x *ChatPreviewDTO) GetCompanionDisplayName() string {
+	if x != nil {
+		return x.CompanionDisplayName
+	}
+	return ""
+}
+
+func (x *ChatPreviewDTO) GetCompanionAvatarUrl() string {
+	if x != nil {
+		return x.CompanionAvatarUrl
+	}
+	return ""
+}
+
+func (x *ChatPreviewDTO) GetMutedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return nil
+}
+
+func (x *ChatPreviewDTO) GetCompanionBlocked() bool {
+	if x != nil {
+		return x.CompanionBlocked
+	}
+	return false
+}
+
+// BlockUser
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BlockUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{12}
+}
+
+// UnblockUser
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnblockUserRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{14}
+}
+
+// ListBlocked
+type ListBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // 0 - 50
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListBlockedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListBlockedRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*BlockedUser         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListBlockedResponse) GetUsers() []*BlockedUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type BlockedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=blocked_at,json=blockedAt,proto3" json:"blocked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockedUser) Reset() {
+	*x = BlockedUser{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockedUser) ProtoMessage() {}
+
+func (x *BlockedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockedUser.ProtoReflect.Descriptor instead.
+func (*BlockedUser) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BlockedUser) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *BlockedUser) GetBlockedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockedAt
+	}
+	return nil
+}
+
+// MuteChat
+type MuteChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"` // null или в прошлом - снять
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteChatRequest) Reset() {
+	*x = MuteChatRequest{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteChatRequest) ProtoMessage() {}
+
+func (x *MuteChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteChatRequest.ProtoReflect.Descriptor instead.
+func (*MuteChatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MuteChatRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *MuteChatRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+type MuteChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MutedUntil    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"` // null - чат не заглушен
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteChatResponse) Reset() {
+	*x = MuteChatResponse{}
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteChatResponse) ProtoMessage() {}
+
+func (x *MuteChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_chat_v1_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteChatResponse.ProtoReflect.Descriptor instead.
+func (*MuteChatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MuteChatResponse) GetMutedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return nil
+}
+
 var File_proto_chat_v1_chat_proto protoreflect.FileDescriptor
 
 const file_proto_chat_v1_chat_proto_rawDesc = "" +
 	"\n" +
-	"\x18proto/chat/v1/chat.proto\x12\achat.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x12\n" +
-	"\x10SubscribeRequest\"^\n" +
-	"\x16GetOrCreateChatRequest\x12!\n" +
-	"\finitiator_id\x18\x01 \x01(\x03R\vinitiatorId\x12!\n" +
-	"\frecipient_id\x18\x02 \x01(\x03R\vrecipientId\"\x87\x01\n" +
+	"\x18proto/chat/v1/chat.proto\x12\achat.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"E\n" +
+	"\x10SubscribeRequest\x121\n" +
+	"\x10after_message_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x0eafterMessageId\"\xc6\x02\n" +
+	"\x16GetOrCreateChatRequest\x12*\n" +
+	"\finitiator_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\vinitiatorId\x12,\n" +
+	"\frecipient_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x00R\vrecipientId\x12N\n" +
+	"\x12recipient_username\x18\x03 \x01(\tB\x1d\xbaH\x1ar\x182\x16^@?[A-Za-z0-9_]{3,32}$H\x00R\x11recipientUsername:n\xbaHk\x1ai\n" +
+	"\x13chat.distinct_users\x12*recipient_id must differ from initiator_id\x1a&this.initiator_id != this.recipient_idB\x12\n" +
+	"\trecipient\x12\x05\xbaH\x02\b\x01\"\x87\x01\n" +
 	"\x17GetOrCreateChatResponse\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x18\n" +
 	"\acreated\x18\x02 \x01(\bR\acreated\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"[\n" +
-	"\x12GetMessagesRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
-	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"g\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd6\x01\n" +
+	"\x12GetMessagesRequest\x12 \n" +
+	"\achat_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06chatId\x12\x1f\n" +
+	"\x05limit\x18\x02 \x01(\x03B\t\xbaH\x06\"\x04\x18d(\x00R\x05limit\x12}\n" +
+	"\x06cursor\x18\x03 \x01(\tBe\xbaHb\xd8\x01\x01r]2[^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(\\.[0-9]+)?(Z|[+-][0-9]{2}:[0-9]{2})$R\x06cursor\"g\n" +
 	"\x13GetMessagesResponse\x12/\n" +
 	"\bmessages\x18\x01 \x03(\v2\x13.chat.v1.MessageDTOR\bmessages\x12\x1f\n" +
 	"\vnext_cursor\x18\x02 \x01(\tR\n" +
-	"nextCursor\"\\\n" +
-	"\x13GetUserChatsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x03R\x06offset\"E\n" +
+	"nextCursor\"y\n" +
+	"\x13GetUserChatsRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\x12\x1f\n" +
+	"\x05limit\x18\x02 \x01(\x03B\t\xbaH\x06\"\x04\x18d(\x00R\x05limit\x12\x1f\n" +
+	"\x06offset\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x06offset\"E\n" +
 	"\x14GetUserChatsResponse\x12-\n" +
-	"\x05chats\x18\x01 \x03(\v2\x17.chat.v1.ChatPreviewDTOR\x05chats\"^\n" +
-	"\x12SendMessageRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1b\n" +
-	"\tsender_id\x18\x02 \x01(\x03R\bsenderId\x12\x12\n" +
-	"\x04text\x18\x03 \x01(\tR\x04text\"o\n" +
+	"\x05chats\x18\x01 \x03(\v2\x17.chat.v1.ChatPreviewDTOR\x05chats\"|\n" +
+	"\x12SendMessageRequest\x12 \n" +
+	"\achat_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06chatId\x12$\n" +
+	"\tsender_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\bsenderId\x12\x1e\n" +
+	"\x04text\x18\x03 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\x80 R\x04text\"o\n" +
 	"\x13SendMessageResponse\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\x03R\tmessageId\x129\n" +
 	"\n" +
-	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa1\x01\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb7\x01\n" +
 	"\n" +
 	"MessageDTO\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
@@ -702,19 +1220,54 @@ const file_proto_chat_v1_chat_proto_rawDesc = "" +
 	"\tsender_id\x18\x03 \x01(\x03R\bsenderId\x12\x12\n" +
 	"\x04text\x18\x04 \x01(\tR\x04text\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd6\x01\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05muted\x18\x06 \x01(\bR\x05muted\"\xd7\x03\n" +
 	"\x0eChatPreviewDTO\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12!\n" +
 	"\fcompanion_id\x18\x02 \x01(\x03R\vcompanionId\x12!\n" +
 	"\flast_message\x18\x03 \x01(\tR\vlastMessage\x12!\n" +
 	"\funread_count\x18\x04 \x01(\x03R\vunreadCount\x12B\n" +
-	"\x0flast_message_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlastMessageAt2\x83\x03\n" +
-	"\vChatService\x12T\n" +
-	"\x0fGetOrCreateChat\x12\x1f.chat.v1.GetOrCreateChatRequest\x1a .chat.v1.GetOrCreateChatResponse\x12H\n" +
-	"\vGetMessages\x12\x1b.chat.v1.GetMessagesRequest\x1a\x1c.chat.v1.GetMessagesResponse\x12K\n" +
-	"\fGetUserChats\x12\x1c.chat.v1.GetUserChatsRequest\x1a\x1d.chat.v1.GetUserChatsResponse\x12H\n" +
-	"\vSendMessage\x12\x1b.chat.v1.SendMessageRequest\x1a\x1c.chat.v1.SendMessageResponse\x12=\n" +
-	"\tSubscribe\x12\x19.chat.v1.SubscribeRequest\x1a\x13.chat.v1.MessageDTO0\x01B\x1bZ\x19chat/proto/chat/v1;chatv1b\x06proto3"
+	"\x0flast_message_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlastMessageAt\x12-\n" +
+	"\x12companion_username\x18\x06 \x01(\tR\x11companionUsername\x124\n" +
+	"\x16companion_display_name\x18\a \x01(\tR\x14companionDisplayName\x120\n" +
+	"\x14companion_avatar_url\x18\b \x01(\tR\x12companionAvatarUrl\x12;\n" +
+	"\vmuted_until\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mutedUntil\x12+\n" +
+	"\x11companion_blocked\x18\n" +
+	" \x01(\bR\x10companionBlocked\"4\n" +
+	"\x10BlockUserRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\"\x13\n" +
+	"\x11BlockUserResponse\"6\n" +
+	"\x12UnblockUserRequest\x12 \n" +
+	"\auser_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06userId\"\x15\n" +
+	"\x13UnblockUserResponse\"V\n" +
+	"\x12ListBlockedRequest\x12\x1f\n" +
+	"\x05limit\x18\x01 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05limit\x12\x1f\n" +
+	"\x06offset\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x06offset\"A\n" +
+	"\x13ListBlockedResponse\x12*\n" +
+	"\x05users\x18\x01 \x03(\v2\x14.chat.v1.BlockedUserR\x05users\"a\n" +
+	"\vBlockedUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x129\n" +
+	"\n" +
+	"blocked_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tblockedAt\"e\n" +
+	"\x0fMuteChatRequest\x12 \n" +
+	"\achat_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06chatId\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"O\n" +
+	"\x10MuteChatResponse\x12;\n" +
+	"\vmuted_until\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mutedUntil2\xe0\x06\n" +
+	"\vChatService\x12t\n" +
+	"\x0fGetOrCreateChat\x12\x1f.chat.v1.GetOrCreateChatRequest\x1a .chat.v1.GetOrCreateChatResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/chat/get-or-create\x12`\n" +
+	"\vGetMessages\x12\x1b.chat.v1.GetMessagesRequest\x1a\x1c.chat.v1.GetMessagesResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/chat/messages\x12`\n" +
+	"\fGetUserChats\x12\x1c.chat.v1.GetUserChatsRequest\x1a\x1d.chat.v1.GetUserChatsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/chat/chats\x12_\n" +
+	"\vSendMessage\x12\x1b.chat.v1.SendMessageRequest\x1a\x1c.chat.v1.SendMessageResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/chat/send\x12=\n" +
+	"\tSubscribe\x12\x19.chat.v1.SubscribeRequest\x1a\x13.chat.v1.MessageDTO0\x01\x12Z\n" +
+	"\tBlockUser\x12\x19.chat.v1.BlockUserRequest\x1a\x1a.chat.v1.BlockUserResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/chat/block\x12b\n" +
+	"\vUnblockUser\x12\x1b.chat.v1.UnblockUserRequest\x1a\x1c.chat.v1.UnblockUserResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/chat/unblock\x12_\n" +
+	"\vListBlocked\x12\x1b.chat.v1.ListBlockedRequest\x1a\x1c.chat.v1.ListBlockedResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/chat/blocked\x12V\n" +
+	"\bMuteChat\x12\x18.chat.v1.MuteChatRequest\x1a\x19.chat.v1.MuteChatResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/chat/muteB\x1bZ\x19chat/proto/chat/v1;chatv1b\x06proto3"
 
 var (
 	file_proto_chat_v1_chat_proto_rawDescOnce sync.Once
@@ -728,7 +1281,7 @@ func file_proto_chat_v1_chat_proto_rawDescGZIP() []byte {
 	return file_proto_chat_v1_chat_proto_rawDescData
 }
 
-var file_proto_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_chat_v1_chat_proto_goTypes = []any{
 	(*SubscribeRequest)(nil),        // 0: chat.v1.SubscribeRequest
 	(*GetOrCreateChatRequest)(nil),  // 1: chat.v1.GetOrCreateChatRequest
@@ -741,30 +1294,52 @@ var file_proto_chat_v1_chat_proto_goTypes = []any{
 	(*SendMessageResponse)(nil),     // 8: chat.v1.SendMessageResponse
 	(*MessageDTO)(nil),              // 9: chat.v1.MessageDTO
 	(*ChatPreviewDTO)(nil),          // 10: chat.v1.ChatPreviewDTO
-	(*timestamppb.Timestamp)(nil),   // 11: google.protobuf.Timestamp
+	(*BlockUserRequest)(nil),        // 11: chat.v1.BlockUserRequest
+	(*BlockUserResponse)(nil),       // 12: chat.v1.BlockUserResponse
+	(*UnblockUserRequest)(nil),      // 13: chat.v1.UnblockUserRequest
+	(*UnblockUserResponse)(nil),     // 14: chat.v1.UnblockUserResponse
+	(*ListBlockedRequest)(nil),      // 15: chat.v1.ListBlockedRequest
+	(*ListBlockedResponse)(nil),     // 16: chat.v1.ListBlockedResponse
+	(*BlockedUser)(nil),             // 17: chat.v1.BlockedUser
+	(*MuteChatRequest)(nil),         // 18: chat.v1.MuteChatRequest
+	(*MuteChatResponse)(nil),        // 19: chat.v1.MuteChatResponse
+	(*timestamppb.Timestamp)(nil),   // 20: google.protobuf.Timestamp
 }
 var file_proto_chat_v1_chat_proto_depIdxs = []int32{
-	11, // 0: chat.v1.GetOrCreateChatResponse.created_at:type_name -> google.protobuf.Timestamp
+	20, // 0: chat.v1.GetOrCreateChatResponse.created_at:type_name -> google.protobuf.Timestamp
 	9,  // 1: chat.v1.GetMessagesResponse.messages:type_name -> chat.v1.MessageDTO
 	10, // 2: chat.v1.GetUserChatsResponse.chats:type_name -> chat.v1.ChatPreviewDTO
-	11, // 3: chat.v1.SendMessageResponse.created_at:type_name -> google.protobuf.Timestamp
-	11, // 4: chat.v1.MessageDTO.created_at:type_name -> google.protobuf.Timestamp
-	11, // 5: chat.v1.ChatPreviewDTO.last_message_at:type_name -> google.protobuf.Timestamp
-	1,  // 6: chat.v1.ChatService.GetOrCreateChat:input_type -> chat.v1.GetOrCreateChatRequest
-	3,  // 7: chat.v1.ChatService.GetMessages:input_type -> chat.v1.GetMessagesRequest
-	5,  // 8: chat.v1.ChatService.GetUserChats:input_type -> chat.v1.GetUserChatsRequest
-	7,  // 9: chat.v1.ChatService.SendMessage:input_type -> chat.v1.SendMessageRequest
-	0,  // 10: chat.v1.ChatService.Subscribe:input_type -> chat.v1.SubscribeRequest
-	2,  // 11: chat.v1.ChatService.GetOrCreateChat:output_type -> chat.v1.GetOrCreateChatResponse
-	4,  // 12: chat.v1.ChatService.GetMessages:output_type -> chat.v1.GetMessagesResponse
-	6,  // 13: chat.v1.ChatService.GetUserChats:output_type -> chat.v1.GetUserChatsResponse
-	8,  // 14: chat.v1.ChatService.SendMessage:output_type -> chat.v1.SendMessageResponse
-	9,  // 15: chat.v1.ChatService.Subscribe:output_type -> chat.v1.MessageDTO
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	20, // 3: chat.v1.SendMessageResponse.created_at:type_name -> google.protobuf.Timestamp
+	20, // 4: chat.v1.MessageDTO.created_at:type_name -> google.protobuf.Timestamp
+	20, // 5: chat.v1.ChatPreviewDTO.last_message_at:type_name -> google.protobuf.Timestamp
+	20, // 6: chat.v1.ChatPreviewDTO.muted_until:type_name -> google.protobuf.Timestamp
+	17, // 7: chat.v1.ListBlockedResponse.users:type_name -> chat.v1.BlockedUser
+	20, // 8: chat.v1.BlockedUser.blocked_at:type_name -> google.protobuf.Timestamp
+	20, // 9: chat.v1.MuteChatRequest.until:type_name -> google.protobuf.Timestamp
+	20, // 10: chat.v1.MuteChatResponse.muted_until:type_name -> google.protobuf.Timestamp
+	1,  // 11: chat.v1.ChatService.GetOrCreateChat:input_type -> chat.v1.GetOrCreateChatRequest
+	3,  // 12: chat.v1.ChatService.GetMessages:input_type -> chat.v1.GetMessagesRequest
+	5,  // 13: chat.v1.ChatService.GetUserChats:input_type -> chat.v1.GetUserChatsRequest
+	7,  // 14: chat.v1.ChatService.SendMessage:input_type -> chat.v1.SendMessageRequest
+	0,  // 15: chat.v1.ChatService.Subscribe:input_type -> chat.v1.SubscribeRequest
+	11, // 16: chat.v1.ChatService.BlockUser:input_type -> chat.v1.BlockUserRequest
+	13, // 17: chat.v1.ChatService.UnblockUser:input_type -> chat.v1.UnblockUserRequest
+	15, // 18: chat.v1.ChatService.ListBlocked:input_type -> chat.v1.ListBlockedRequest
+	18, // 19: chat.v1.ChatService.MuteChat:input_type -> chat.v1.MuteChatRequest
+	2,  // 20: chat.v1.ChatService.GetOrCreateChat:output_type -> chat.v1.GetOrCreateChatResponse
+	4,  // 21: chat.v1.ChatService.GetMessages:output_type -> chat.v1.GetMessagesResponse
+	6,  // 22: chat.v1.ChatService.GetUserChats:output_type -> chat.v1.GetUserChatsResponse
+	8,  // 23: chat.v1.ChatService.SendMessage:output_type -> chat.v1.SendMessageResponse
+	9,  // 24: chat.v1.ChatService.Subscribe:output_type -> chat.v1.MessageDTO
+	12, // 25: chat.v1.ChatService.BlockUser:output_type -> chat.v1.BlockUserResponse
+	14, // 26: chat.v1.ChatService.UnblockUser:output_type -> chat.v1.UnblockUserResponse
+	16, // 27: chat.v1.ChatService.ListBlocked:output_type -> chat.v1.ListBlockedResponse
+	19, // 28: chat.v1.ChatService.MuteChat:output_type -> chat.v1.MuteChatResponse
+	20, // [20:29] is the sub-list for method output_type
+	11, // [11:20] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_chat_v1_chat_proto_init() }
@@ -772,13 +1347,17 @@ func file_proto_chat_v1_chat_proto_init() {
 	if File_proto_chat_v1_chat_proto != nil {
 		return
 	}
+	file_proto_chat_v1_chat_proto_msgTypes[1].OneofWrappers = []any{
+		(*GetOrCreateChatRequest_RecipientId)(nil),
+		(*GetOrCreateChatRequest_RecipientUsername)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_chat_v1_chat_proto_rawDesc), len(file_proto_chat_v1_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
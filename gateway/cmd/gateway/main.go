@@ -2,22 +2,31 @@
 package main
 
 import (
+	"auth/pkg/clientinfo"
+	"auth/pkg/metrics"
+	"auth/pkg/requestid"
+	"auth/pkg/tracing"
+	authv1 "auth/proto/auth/v1"
+	chatv1 "chat/proto/chat/v1"
+	"context"
+	"errors"
 	"flag"
 	"gateway/internal/config"
 	"gateway/internal/handler"
 	"gateway/internal/middleware"
-	authv1 "gateway/proto/auth/v1"
-	chatv1 "gateway/proto/chat/v1"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/BurntSushi/toml"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var configPath string
@@ -38,11 +47,24 @@ func main() {
 	if strings.ToUpper(cfg.LogLevel) == "DEBUG" {
 		logLevel = slog.LevelDebug
 	}
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
+	logger := slog.New(requestid.NewHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel})))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "gateway", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
 
 	authConn, err := grpc.NewClient(
 		cfg.AuthServiceAddr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		tracing.DialOption(),
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor(), clientinfo.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(requestid.StreamClientInterceptor(), clientinfo.StreamClientInterceptor()),
 	)
 	if err != nil {
 		log.Fatalf("failed to connect to auth-service: %v", err)
@@ -56,6 +78,9 @@ func main() {
 	chatConn, err := grpc.NewClient(
 		cfg.ChatServiceAddr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		tracing.DialOption(),
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(requestid.StreamClientInterceptor()),
 	)
 	if err != nil {
 		log.Fatalf("failed to connect to chat-service: %v", err)
@@ -66,39 +91,47 @@ func main() {
 		}
 	}()
 
-	authHandler := handler.NewAuthHandler(authv1.NewAuthServiceClient(authConn))
-	chatHandler := handler.NewChatHandler(chatv1.NewChatServiceClient(chatConn))
-	wsHandler := handler.NewWSHandler(chatv1.NewChatServiceClient(chatConn), logger)
+	authHandler := handler.NewAuthHandler(authv1.NewAuthServiceClient(authConn), handler.CookieConfig{
+		Enabled:          cfg.AuthCookieMode,
+		Secure:           cfg.CookieSecure,
+		SameSite:         handler.ParseSameSite(cfg.CookieSameSite),
+		ExternalRedirect: cfg.ExternalLoginRedirect,
+	})
+	oauthHandler := handler.NewOAuthHandler(authv1.NewOAuthServiceClient(authConn), cfg.OAuthIssuer)
+	adminHandler := handler.NewAdminHandler(authv1.NewAdminServiceClient(authConn), logger)
+	wsHandler := handler.NewWSHandler(chatv1.NewChatServiceClient(chatConn), authv1.NewAuthServiceClient(authConn), handler.WSConfig{
+		Audience:       cfg.JWTAudience,
+		AllowedOrigins: cfg.WSAllowedOrigins,
+		TicketTTL:      cfg.WSTicketTTL,
+		CookieSecure:   cfg.CookieSecure,
+		CookieSameSite: handler.ParseSameSite(cfg.CookieSameSite),
+	}, logger)
+
+	restMux, err := handler.NewRESTMux(context.Background(), authConn, chatConn)
+	if err != nil {
+		log.Fatalf("failed to register rest handlers: %v", err)
+	}
 
-	mux := http.NewServeMux()
+	health := handler.NewHealthHandler([]handler.Backend{
+		{Name: "auth-service", Client: healthv1.NewHealthClient(authConn)},
+		{Name: "chat-service", Client: healthv1.NewHealthClient(chatConn)},
+	}, cfg.ReadyTimeout, logger)
 
-	// Auth
-	mux.HandleFunc("POST /auth/register", authHandler.Register)
-	mux.HandleFunc("POST /auth/login", authHandler.Login)
-	mux.HandleFunc("POST /auth/logout", authHandler.Logout)
-	mux.HandleFunc("POST /auth/refresh", authHandler.Refresh)
-	mux.HandleFunc("GET /auth/is-admin", authHandler.IsAdmin)
-
-	// Chat
-	mux.HandleFunc("POST /chat/get-or-create", chatHandler.GetOrCreateChat)
-	mux.HandleFunc("GET /chat/messages", chatHandler.GetMessages)
-	mux.HandleFunc("GET /chat/chats", chatHandler.GetUserChats)
-	mux.HandleFunc("POST /chat/send", chatHandler.SendMessage)
-	mux.HandleFunc("GET /ws/subscribe", wsHandler.Subscribe)
-
-	// Health
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		if _, err := w.Write([]byte(`{"status":"ok"}`)); err != nil {
-			logger.Error("write health response", slog.String("error", err.Error()))
-		}
+	mux := http.NewServeMux()
+	registerRoutes(mux, handlers{
+		auth:   authHandler,
+		oauth:  oauthHandler,
+		admin:  adminHandler,
+		ws:     wsHandler,
+		rest:   restMux,
+		health: health,
 	})
 
 	srv := &http.Server{
 		Addr: cfg.BindAddr,
-		Handler: middleware.CORS(
-			middleware.Logger(logger, mux),
-		),
+		Handler: middleware.CORS(cfg.WSAllowedOrigins, middleware.RequestID(middleware.ClientInfo(
+			middleware.Tracing(middleware.Logger(logger, middleware.Metrics(mux))),
+		))),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
@@ -108,7 +141,54 @@ func main() {
 		slog.String("chat", cfg.ChatServiceAddr),
 	)
 
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("gateway: %v", err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("gateway stopped with error", slog.String("error", err.Error()))
+			stop()
+		}
+	}()
+
+	metricsServer := metrics.NewServer(logger, cfg.MetricsAddr)
+	go func() {
+		if err := metricsServer.Run(); err != nil {
+			logger.Error("metrics server stopped with error", slog.String("error", err.Error()))
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("gateway shutting down")
+
+	// 1. not-ready и пауза, чтобы балансировщик успел это увидеть
+	health.Drain()
+	time.Sleep(cfg.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// 2. Закрываем realtime соединения параллельно с Shutdown: WebSocket hijacked
+	// и Shutdown их не видит, а долгие SSE запросы иначе держали бы его до таймаута
+	wsDone := make(chan error, 1)
+	go func() {
+		wsDone <- wsHandler.Shutdown(shutdownCtx)
+	}()
+
+	// 3. Перестаём принимать соединения и ждём текущие HTTP запросы
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("http shutdown", slog.String("error", err.Error()))
+	}
+	if err := <-wsDone; err != nil {
+		logger.Error("ws shutdown", slog.String("error", err.Error()))
 	}
+	if err := metricsServer.Stop(shutdownCtx); err != nil {
+		logger.Error("metrics shutdown", slog.String("error", err.Error()))
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("tracing shutdown", slog.String("error", err.Error()))
+	}
+
+	// 4. gRPC соединения закрываются в defer после выхода из main
+	logger.Info("gateway stopped")
 }
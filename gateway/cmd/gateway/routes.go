@@ -0,0 +1,67 @@
+package main
+
+import (
+	"gateway/internal/docs"
+	"gateway/internal/handler"
+	"net/http"
+)
+
+// router - то, что нужно от http.ServeMux; тест маршрутов подставляет свою реализацию.
+type router interface {
+	Handle(pattern string, h http.Handler)
+	HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request))
+}
+
+// handlers ...
+type handlers struct {
+	auth   *handler.AuthHandler
+	oauth  *handler.OAuthHandler
+	admin  *handler.AdminHandler
+	ws     *handler.WSHandler
+	rest   http.Handler
+	health *handler.HealthHandler
+}
+
+// restPattern - под ним подключён grpc-gateway, его маршруты задаются аннотациями в proto.
+const restPattern = "/"
+
+// registerRoutes - все маршруты gateway. Каждый из них должен быть описан в internal/docs/openapi.json.
+func registerRoutes(mux router, h handlers) {
+	// REST по google.api.http аннотациям: /auth/register, /auth/is-admin, /chat/*
+	mux.Handle(restPattern, h.rest)
+
+	// Auth: выдача токенов вручную - cookie режим и редиректы
+	mux.HandleFunc("POST /auth/login", h.auth.Login)
+	mux.HandleFunc("POST /auth/logout", h.auth.Logout)
+	mux.HandleFunc("POST /auth/refresh", h.auth.Refresh)
+	mux.HandleFunc("GET /auth/external/{provider}/start", h.auth.ExternalStart)
+	mux.HandleFunc("GET /auth/external/{provider}/callback", h.auth.ExternalCallback)
+
+	// OAuth2 / OpenID Connect
+	mux.HandleFunc("GET /oauth/authorize", h.oauth.Authorize)
+	mux.HandleFunc("POST /oauth/authorize", h.oauth.AuthorizeSubmit)
+	mux.HandleFunc("POST /oauth/token", h.oauth.Token)
+	mux.HandleFunc("POST /oauth/revoke", h.oauth.Revoke)
+	mux.HandleFunc("POST /oauth/introspect", h.oauth.Introspect)
+	mux.HandleFunc("GET /.well-known/openid-configuration", h.oauth.Discovery)
+	mux.HandleFunc("GET /.well-known/jwks.json", h.oauth.JWKS)
+
+	// Админка: выгрузка журнала аудита потоком (JSON Lines)
+	mux.HandleFunc("GET /admin/audit-events/export", h.admin.ExportAuditEvents)
+
+	// Realtime
+	mux.HandleFunc("POST /ws/ticket", h.ws.Ticket)
+	mux.HandleFunc("GET /ws/subscribe", h.ws.Subscribe)
+	mux.HandleFunc("POST /sse/session", h.ws.SSESession)
+	mux.HandleFunc("GET /sse/subscribe", h.ws.SubscribeSSE)
+
+	// Документация
+	mux.HandleFunc("GET /openapi.json", docs.Spec)
+	mux.HandleFunc("GET /docs", docs.Page)
+
+	// Пробы оркестратора
+	mux.HandleFunc("GET /livez", h.health.Live)
+	mux.HandleFunc("GET /readyz", h.health.Ready)
+	// Deprecated: старый адрес проверки, оставлен для балансировщиков - используйте /readyz
+	mux.HandleFunc("GET /health", h.health.Ready)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	authv1 "auth/proto/auth/v1"
+	chatv1 "chat/proto/chat/v1"
+	"encoding/json"
+	"gateway/internal/docs"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// recorder запоминает шаблоны маршрутов вместо регистрации.
+type recorder struct {
+	patterns []string
+}
+
+func (r *recorder) Handle(pattern string, _ http.Handler) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+func (r *recorder) HandleFunc(pattern string, _ func(http.ResponseWriter, *http.Request)) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+// registeredRoutes - "METHOD /path" всех маршрутов gateway: ручные из registerRoutes
+// и сгенерированные grpc-gateway по google.api.http аннотациям.
+func registeredRoutes(t *testing.T) []string {
+	t.Helper()
+
+	rec := &recorder{}
+	registerRoutes(rec, handlers{})
+
+	seen := make(map[string]bool)
+	for _, pattern := range rec.patterns {
+		if pattern == restPattern {
+			for _, route := range annotatedRoutes(authv1.File_proto_auth_v1_auth_proto, chatv1.File_proto_chat_v1_chat_proto) {
+				seen[route] = true
+			}
+			continue
+		}
+
+		method, path, ok := strings.Cut(pattern, " ")
+		require.True(t, ok, "route %q must have a method", pattern)
+		seen[method+" "+path] = true
+	}
+
+	routes := make([]string, 0, len(seen))
+	for route := range seen {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func annotatedRoutes(files ...protoreflect.FileDescriptor) []string {
+	var routes []string
+	for _, fd := range files {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				rule, ok := proto.GetExtension(methods.Get(j).Options(), annotations.E_Http).(*annotations.HttpRule)
+				if !ok || rule == nil {
+					continue
+				}
+				for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+					if route := httpRoute(r); route != "" {
+						routes = append(routes, route)
+					}
+				}
+			}
+		}
+	}
+	return routes
+}
+
+func httpRoute(rule *annotations.HttpRule) string {
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet + " " + p.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost + " " + p.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut + " " + p.Put
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch + " " + p.Patch
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete + " " + p.Delete
+	case *annotations.HttpRule_Custom:
+		return p.Custom.GetKind() + " " + p.Custom.GetPath()
+	default:
+		return ""
+	}
+}
+
+// documentedRoutes - "METHOD /path" всех операций из openapi.json.
+func documentedRoutes(t *testing.T) []string {
+	t.Helper()
+
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(docs.OpenAPI, &spec))
+	require.True(t, strings.HasPrefix(spec.OpenAPI, "3."), "openapi version %q", spec.OpenAPI)
+
+	var routes []string
+	for path, ops := range spec.Paths {
+		for method := range ops {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func TestOpenAPI_DescribesAllRoutes(t *testing.T) {
+	documented := documentedRoutes(t)
+
+	for _, route := range registeredRoutes(t) {
+		assert.Contains(t, documented, route, "route is registered but missing from internal/docs/openapi.json")
+	}
+}
+
+func TestOpenAPI_NoStaleRoutes(t *testing.T) {
+	registered := registeredRoutes(t)
+
+	for _, route := range documentedRoutes(t) {
+		assert.Contains(t, registered, route, "route is documented in internal/docs/openapi.json but not registered")
+	}
+}
@@ -1,21 +1,69 @@
 // Package config ...
 package config
 
+import "time"
+
 // Config ...
 type Config struct {
 	BindAddr        string `toml:"bind_addr"`
+	MetricsAddr     string `toml:"metrics_addr"` // Prometheus /metrics - отдельный порт, не публичный API
 	AuthServiceAddr string `toml:"auth_service_addr"`
 	ChatServiceAddr string `toml:"chat_service_addr"`
 	JWTSecret       string `toml:"jwt_secret"`
 	LogLevel        string `toml:"log_level"`
+	OAuthIssuer     string `toml:"oauth_issuer"`
+
+	// refresh токен в HttpOnly cookie вместо JSON (для браузера)
+	AuthCookieMode bool   `toml:"auth_cookie_mode"`
+	CookieSecure   bool   `toml:"cookie_secure"`
+	CookieSameSite string `toml:"cookie_same_site"`
+	// Куда вернуть браузер после входа через внешнего провайдера
+	ExternalLoginRedirect string `toml:"external_login_redirect"`
+
+	// WebSocket
+	JWTAudience      string        `toml:"jwt_audience"`
+	WSAllowedOrigins []string      `toml:"ws_allowed_origins"`
+	WSTicketTTL      time.Duration `toml:"ws_ticket_ttl"`
+
+	// /readyz: сколько ждать ответа grpc.health.v1 от auth-service и chat-service
+	ReadyTimeout time.Duration `toml:"ready_timeout"`
+
+	// Graceful shutdown: сколько ждать после перевода /readyz в not-ready
+	// и сколько всего даём на завершение запросов и WebSocket соединений
+	ShutdownDelay   time.Duration `toml:"shutdown_delay"`
+	ShutdownTimeout time.Duration `toml:"shutdown_timeout"`
+
+	Tracing Tracing `toml:"tracing"`
+}
+
+// Tracing - экспорт спанов OpenTelemetry. Пустой exporter - спаны не пишутся,
+// но traceparent всё равно передаётся в auth-service и chat-service.
+type Tracing struct {
+	Exporter    string  `toml:"exporter"` // otlp или stdout
+	Endpoint    string  `toml:"endpoint"` // host:port OTLP/gRPC коллектора
+	Insecure    bool    `toml:"insecure"`
+	SampleRatio float64 `toml:"sample_ratio"`
 }
 
 // NewConfig ...
 func NewConfig() *Config {
 	return &Config{
-		BindAddr:        ":8080",
-		AuthServiceAddr: "localhost:50051",
-		ChatServiceAddr: "localhost:50052",
-		LogLevel:        "DEBUG",
+		BindAddr:              ":8080",
+		MetricsAddr:           ":9090",
+		AuthServiceAddr:       "localhost:50051",
+		ChatServiceAddr:       "localhost:50052",
+		LogLevel:              "DEBUG",
+		OAuthIssuer:           "http://localhost:8080",
+		CookieSecure:          true,
+		CookieSameSite:        "strict",
+		ExternalLoginRedirect: "/",
+		WSTicketTTL:           30 * time.Second,
+		ReadyTimeout:          2 * time.Second,
+		ShutdownTimeout:       15 * time.Second,
+		Tracing: Tracing{
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
 	}
 }
@@ -0,0 +1,33 @@
+// Package docs - OpenAPI спецификация gateway и страница документации, вшитые в бинарник.
+package docs
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// OpenAPI - спецификация всех маршрутов gateway (OpenAPI 3.1).
+// При добавлении маршрута в main.go его нужно описать здесь - иначе упадёт тест маршрутов.
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+//go:embed docs.html
+var page []byte
+
+// Spec GET /openapi.json
+func Spec(w http.ResponseWriter, _ *http.Request) {
+	write(w, "application/json", OpenAPI)
+}
+
+// Page GET /docs
+// Страница без внешних зависимостей: читает /openapi.json и рисует список маршрутов.
+func Page(w http.ResponseWriter, _ *http.Request) {
+	write(w, "text/html; charset=utf-8", page)
+}
+
+func write(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(body)
+}
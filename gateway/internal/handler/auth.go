@@ -2,49 +2,24 @@
 package handler
 
 import (
-	authv1 "gateway/proto/auth/v1"
+	"auth/pkg/apierr"
+	authv1 "auth/proto/auth/v1"
 	"net/http"
 	"time"
 )
 
 // AuthHandler ...
 type AuthHandler struct {
-	client authv1.AuthServiceClient
+	client  authv1.AuthServiceClient
+	cookies CookieConfig
 }
 
 // NewAuthHandler ...
-func NewAuthHandler(client authv1.AuthServiceClient) *AuthHandler {
-	return &AuthHandler{client: client}
-}
-
-// Register POST /auth/register
-// Body: { "email": "...", "password": "..." }
-func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-	}
-	if err := decodeJSON(r, &req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid request body")
-		return
+func NewAuthHandler(client authv1.AuthServiceClient, cookies CookieConfig) *AuthHandler {
+	return &AuthHandler{
+		client:  client,
+		cookies: cookies,
 	}
-	if req.Email == "" || req.Password == "" {
-		writeError(w, http.StatusBadRequest, "email and password are required")
-		return
-	}
-
-	resp, err := h.client.Register(r.Context(), &authv1.RegisterRequest{
-		Email:    req.Email,
-		Password: req.Password,
-	})
-	if err != nil {
-		writeError(w, grpcStatusToHTTP(err), grpcMessage(err))
-		return
-	}
-
-	writeJSON(w, http.StatusCreated, map[string]any{
-		"user_id": resp.GetUserId(),
-	})
 }
 
 // Login POST /auth/login
@@ -59,10 +34,6 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if req.Email == "" || req.Password == "" {
-		writeError(w, http.StatusBadRequest, "email and password are required")
-		return
-	}
 	if req.AppID == 0 {
 		req.AppID = 1
 	}
@@ -73,20 +44,21 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		AppId:    req.AppID,
 	})
 	if err != nil {
-		writeError(w, grpcStatusToHTTP(err), grpcMessage(err))
+		writeGRPCError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{
-		"access_token":       resp.GetAccessToken(),
-		"refresh_token":      resp.GetRefreshToken(),
-		"access_expires_at":  timeOrNil(resp.GetAccessExpiresAt().AsTime()),
-		"refresh_expires_at": timeOrNil(resp.GetRefreshExpiresAt().AsTime()),
-	})
+	h.writeTokens(w,
+		resp.GetAccessToken(),
+		resp.GetRefreshToken(),
+		resp.GetAccessExpiresAt().AsTime(),
+		resp.GetRefreshExpiresAt().AsTime(),
+	)
 }
 
 // Logout POST /auth/logout
 // Body: { "refresh_token": "..." }
+// В cookie режиме тело может быть {}: токен берётся из cookie, нужен заголовок X-CSRF-Token.
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		RefreshToken string `json:"refresh_token"`
@@ -95,19 +67,21 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if req.RefreshToken == "" {
-		writeError(w, http.StatusBadRequest, "refresh_token is required")
+	refreshToken, ok := h.refreshTokenFrom(w, r, req.RefreshToken)
+	if !ok {
 		return
 	}
 
 	resp, err := h.client.Logout(r.Context(), &authv1.LogoutRequest{
-		RefreshToken: req.RefreshToken,
+		RefreshToken: refreshToken,
 	})
 	if err != nil {
-		writeError(w, grpcStatusToHTTP(err), grpcMessage(err))
+		writeGRPCError(w, err)
 		return
 	}
 
+	h.clearCookies(w)
+
 	writeJSON(w, http.StatusOK, map[string]any{
 		"success": resp.GetSuccess(),
 	})
@@ -115,6 +89,7 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 
 // Refresh POST /auth/refresh
 // Body: { "refresh_token": "..." }
+// В cookie режиме тело может быть {}: токен берётся из cookie, нужен заголовок X-CSRF-Token.
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		RefreshToken string `json:"refresh_token"`
@@ -123,46 +98,98 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	if req.RefreshToken == "" {
-		writeError(w, http.StatusBadRequest, "refresh_token is required")
+	refreshToken, ok := h.refreshTokenFrom(w, r, req.RefreshToken)
+	if !ok {
 		return
 	}
 
 	resp, err := h.client.RefreshToken(r.Context(), &authv1.RefreshTokenRequest{
-		RefreshToken: req.RefreshToken,
+		RefreshToken: refreshToken,
 	})
 	if err != nil {
-		writeError(w, grpcStatusToHTTP(err), grpcMessage(err))
+		writeGRPCError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{
-		"access_token":       resp.GetAccessToken(),
-		"refresh_token":      resp.GetRefreshToken(),
-		"access_expires_at":  timeOrNil(resp.GetAccessExpiresAt().AsTime()),
-		"refresh_expires_at": timeOrNil(resp.GetRefreshExpiresAt().AsTime()),
-	})
+	h.writeTokens(w,
+		resp.GetAccessToken(),
+		resp.GetRefreshToken(),
+		resp.GetAccessExpiresAt().AsTime(),
+		resp.GetRefreshExpiresAt().AsTime(),
+	)
 }
 
-// IsAdmin GET /auth/is-admin?user_id=42
-func (h *AuthHandler) IsAdmin(w http.ResponseWriter, r *http.Request) {
-	userID, err := queryInt64(r, "user_id")
-	if err != nil {
-		writeError(w, http.StatusBadRequest, "user_id query param required")
+// ExternalStart GET /auth/external/{provider}/start?app_id=1
+// Редиректит браузер на страницу входа внешнего провайдера. state запоминается
+// в HttpOnly cookie: callback примет только тот браузер, который начал вход.
+// nonce auth-service хранит вместе с state, поэтому он привязан к браузеру так же.
+func (h *AuthHandler) ExternalStart(w http.ResponseWriter, r *http.Request) {
+	// Токены отдаются только в cookie, без cookie режима войти не получится
+	if !h.cookies.Enabled {
+		writeErrorCode(w, http.StatusNotFound, apierr.CodeUnknownProvider, "external login requires auth_cookie_mode")
 		return
 	}
 
-	resp, err := h.client.IsAdmin(r.Context(), &authv1.IsAdminRequest{
-		UserId: userID,
+	appID := int64(1)
+	if r.URL.Query().Get("app_id") != "" {
+		var err error
+		appID, err = queryInt64(r, "app_id")
+		if err != nil {
+			writeFieldError(w, apierr.FieldViolation{Field: "app_id", Description: "must be an integer"})
+			return
+		}
+	}
+
+	resp, err := h.client.StartExternalLogin(r.Context(), &authv1.StartExternalLoginRequest{
+		Provider: r.PathValue("provider"),
+		AppId:    int32(appID), // #nosec G115 -- app_id в БД INT
 	})
 	if err != nil {
-		writeError(w, grpcStatusToHTTP(err), grpcMessage(err))
+		writeGRPCError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{
-		"is_admin": resp.GetIsAdmin(),
+	h.setExternalState(w, resp.GetState())
+	http.Redirect(w, r, resp.GetAuthUrl(), http.StatusFound)
+}
+
+// ExternalCallback GET /auth/external/{provider}/callback?code=...&state=...
+// Сюда провайдер возвращает браузер после входа. state должен совпасть с cookie
+// из ExternalStart - иначе это чужой code, подсунутый по ссылке (login CSRF).
+// Токены не попадают ни в тело, ни в URL: refresh уходит в HttpOnly cookie, браузер
+// возвращается на external_login_redirect и берёт access токен через POST /auth/refresh.
+func (h *AuthHandler) ExternalCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	stateOK := externalStateMatches(r, q.Get("state"))
+	// state одноразовый: cookie больше не нужна при любом исходе
+	h.setExternalState(w, "")
+
+	if q.Get("error") != "" {
+		writeErrorCode(w, http.StatusUnauthorized, apierr.CodeExternalLoginFailed, "external login failed")
+		return
+	}
+	if !stateOK {
+		writeErrorCode(w, http.StatusUnauthorized, apierr.CodeExternalLoginFailed, "external login state mismatch")
+		return
+	}
+
+	resp, err := h.client.CompleteExternalLogin(r.Context(), &authv1.CompleteExternalLoginRequest{
+		Provider: r.PathValue("provider"),
+		Code:     q.Get("code"),
+		State:    q.Get("state"),
 	})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+
+	if _, err := h.setTokenCookies(w, resp.GetRefreshToken(), resp.GetRefreshExpiresAt().AsTime()); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, h.cookies.ExternalRedirect, http.StatusFound)
 }
 
 func timeOrNil(t time.Time) any {
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeExternalAuth выдаёт state STATE и запоминает запрос CompleteExternalLogin.
+type fakeExternalAuth struct {
+	authv1.AuthServiceClient
+	complete *authv1.CompleteExternalLoginRequest
+}
+
+func (f *fakeExternalAuth) StartExternalLogin(_ context.Context, _ *authv1.StartExternalLoginRequest, _ ...grpc.CallOption) (*authv1.StartExternalLoginResponse, error) {
+	return &authv1.StartExternalLoginResponse{AuthUrl: "https://idp.example.com/authorize?state=STATE", State: "STATE"}, nil
+}
+
+func (f *fakeExternalAuth) CompleteExternalLogin(_ context.Context, req *authv1.CompleteExternalLoginRequest, _ ...grpc.CallOption) (*authv1.LoginResponse, error) {
+	f.complete = req
+	return &authv1.LoginResponse{
+		AccessToken:      "ACCESS",
+		RefreshToken:     "REFRESH",
+		AccessExpiresAt:  timestamppb.New(time.Now().Add(time.Minute)),
+		RefreshExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}, nil
+}
+
+func newTestExternalHandler(client *fakeExternalAuth, enabled bool) *AuthHandler {
+	return NewAuthHandler(client, CookieConfig{
+		Enabled:          enabled,
+		Secure:           true,
+		SameSite:         http.SameSiteStrictMode,
+		ExternalRedirect: "https://app.example.com/",
+	})
+}
+
+func cookieByName(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestAuthHandler_ExternalStart(t *testing.T) {
+	t.Parallel()
+
+	h := newTestExternalHandler(&fakeExternalAuth{}, true)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/auth/external/google/start", nil)
+	req.SetPathValue("provider", "google")
+	h.ExternalStart(rec, req)
+
+	require.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://idp.example.com/authorize?state=STATE", rec.Header().Get("Location"))
+
+	state := cookieByName(rec.Result().Cookies(), externalStateCookie)
+	require.NotNil(t, state)
+	assert.Equal(t, "STATE", state.Value)
+	assert.Equal(t, externalStatePath, state.Path)
+	assert.True(t, state.HttpOnly)
+	assert.True(t, state.Secure)
+	// Провайдер возвращает браузер межсайтовой навигацией - Strict cookie не пришла бы
+	assert.Equal(t, http.SameSiteLaxMode, state.SameSite)
+}
+
+func TestAuthHandler_ExternalStartWithoutCookieMode(t *testing.T) {
+	t.Parallel()
+
+	h := newTestExternalHandler(&fakeExternalAuth{}, false)
+
+	rec := httptest.NewRecorder()
+	h.ExternalStart(rec, httptest.NewRequest(http.MethodGet, "/auth/external/google/start", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Empty(t, rec.Result().Cookies())
+}
+
+func TestAuthHandler_ExternalCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		cookie       string
+		query        string
+		wantStatus   int
+		wantComplete bool
+	}{
+		{
+			name:         "success",
+			cookie:       "STATE",
+			query:        "code=CODE&state=STATE",
+			wantStatus:   http.StatusFound,
+			wantComplete: true,
+		},
+		{
+			name:       "state from another browser",
+			cookie:     "MINE",
+			query:      "code=CODE&state=STATE",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no state cookie",
+			query:      "code=CODE&state=STATE",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "provider error",
+			cookie:     "STATE",
+			query:      "error=access_denied&state=STATE",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeExternalAuth{}
+			h := newTestExternalHandler(client, true)
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/external/google/callback?"+tt.query, nil)
+			req.SetPathValue("provider", "google")
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: externalStateCookie, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+
+			h.ExternalCallback(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			cookies := rec.Result().Cookies()
+
+			// state одноразовый - cookie удаляется при любом исходе
+			state := cookieByName(cookies, externalStateCookie)
+			require.NotNil(t, state)
+			assert.Equal(t, -1, state.MaxAge)
+
+			// Токенов нет ни в теле, ни в адресе редиректа
+			assert.NotContains(t, rec.Body.String(), "REFRESH")
+			assert.NotContains(t, rec.Body.String(), "ACCESS")
+			assert.NotContains(t, rec.Header().Get("Location"), "ACCESS")
+
+			if !tt.wantComplete {
+				assert.Nil(t, client.complete)
+				assert.Nil(t, cookieByName(cookies, refreshCookieName))
+				return
+			}
+			require.NotNil(t, client.complete)
+			assert.Equal(t, "STATE", client.complete.GetState())
+			assert.Equal(t, "https://app.example.com/", rec.Header().Get("Location"))
+
+			refresh := cookieByName(cookies, refreshCookieName)
+			require.NotNil(t, refresh)
+			assert.Equal(t, "REFRESH", refresh.Value)
+			assert.True(t, refresh.HttpOnly)
+			assert.NotNil(t, cookieByName(cookies, csrfCookieName))
+		})
+	}
+}
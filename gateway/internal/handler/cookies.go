@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"auth/pkg/apierr"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"time"
+)
+
+const (
+	refreshCookieName = "refresh_token"
+	refreshCookiePath = "/auth"
+	csrfCookieName    = "csrf_token"
+	csrfHeaderName    = "X-CSRF-Token"
+	csrfTokenBytes    = 32
+
+	// externalStateCookie привязывает state внешнего входа к браузеру, который его начал.
+	// SameSite=Lax: провайдер возвращает браузер межсайтовой навигацией, Strict cookie не придёт.
+	externalStateCookie = "external_login_state"
+	externalStatePath   = "/auth/external"
+	externalStateTTL    = 10 * time.Minute
+)
+
+// CookieConfig - режим, в котором refresh токен живёт в HttpOnly cookie,
+// а не в JSON ответе. Для запросов с cookie включается CSRF защита (double-submit).
+type CookieConfig struct {
+	Enabled  bool
+	Secure   bool
+	SameSite http.SameSite
+	// ExternalRedirect - куда вернуть браузер после входа через внешнего провайдера
+	ExternalRedirect string
+}
+
+// ParseSameSite ...
+func ParseSameSite(v string) http.SameSite {
+	switch v {
+	case "lax", "Lax":
+		return http.SameSiteLaxMode
+	case "none", "None":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+// writeTokens отдаёт пару токенов: в cookie режиме refresh уходит в HttpOnly cookie,
+// а в теле вместо него csrf_token для заголовка X-CSRF-Token.
+func (h *AuthHandler) writeTokens(w http.ResponseWriter, accessToken string, refreshToken string, accExp time.Time, refExp time.Time) {
+	body := map[string]any{
+		"access_token":       accessToken,
+		"refresh_token":      refreshToken,
+		"access_expires_at":  timeOrNil(accExp),
+		"refresh_expires_at": timeOrNil(refExp),
+	}
+
+	if h.cookies.Enabled {
+		csrf, err := h.setTokenCookies(w, refreshToken, refExp)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		delete(body, "refresh_token")
+		body["csrf_token"] = csrf
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+// setTokenCookies кладёт refresh токен в HttpOnly cookie, а новый CSRF токен -
+// в cookie, которую читает клиент. Возвращает CSRF токен.
+func (h *AuthHandler) setTokenCookies(w http.ResponseWriter, refreshToken string, refExp time.Time) (string, error) {
+	csrf, err := newCSRFToken()
+	if err != nil {
+		return "", err
+	}
+
+	http.SetCookie(w, h.cookie(refreshCookieName, refreshToken, refreshCookiePath, true, refExp))
+	http.SetCookie(w, h.cookie(csrfCookieName, csrf, "/", false, refExp))
+
+	return csrf, nil
+}
+
+// refreshTokenFrom берёт refresh токен из тела, а если его нет - из cookie с проверкой CSRF.
+// При ошибке ответ уже записан.
+func (h *AuthHandler) refreshTokenFrom(w http.ResponseWriter, r *http.Request, fromBody string) (string, bool) {
+	if fromBody != "" {
+		return fromBody, true
+	}
+
+	cookie, err := r.Cookie(refreshCookieName)
+	if !h.cookies.Enabled || err != nil || cookie.Value == "" {
+		writeFieldError(w, apierr.FieldViolation{Field: "refresh_token", Description: "is required"})
+		return "", false
+	}
+
+	csrf, err := r.Cookie(csrfCookieName)
+	header := r.Header.Get(csrfHeaderName)
+	if err != nil || header == "" || subtle.ConstantTimeCompare([]byte(csrf.Value), []byte(header)) != 1 {
+		writeErrorCode(w, http.StatusForbidden, apierr.CodeCSRFMismatch, "csrf token mismatch")
+		return "", false
+	}
+
+	return cookie.Value, true
+}
+
+// clearCookies ...
+func (h *AuthHandler) clearCookies(w http.ResponseWriter) {
+	if !h.cookies.Enabled {
+		return
+	}
+	http.SetCookie(w, h.cookie(refreshCookieName, "", refreshCookiePath, true, time.Unix(0, 0)))
+	http.SetCookie(w, h.cookie(csrfCookieName, "", "/", false, time.Unix(0, 0)))
+}
+
+func (h *AuthHandler) cookie(name string, value string, path string, httpOnly bool, expires time.Time) *http.Cookie {
+	c := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Expires:  expires,
+		HttpOnly: httpOnly,
+		Secure:   h.cookies.Secure,
+		SameSite: h.cookies.SameSite,
+	}
+	if value == "" {
+		c.MaxAge = -1
+	}
+	return c
+}
+
+// setExternalState запоминает state внешнего входа в браузере; пустой state - удалить cookie.
+func (h *AuthHandler) setExternalState(w http.ResponseWriter, state string) {
+	c := &http.Cookie{
+		Name:     externalStateCookie,
+		Value:    state,
+		Path:     externalStatePath,
+		MaxAge:   int(externalStateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   h.cookies.Secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if state == "" {
+		c.MaxAge = -1
+	}
+	http.SetCookie(w, c)
+}
+
+// externalStateMatches сверяет state из callback с cookie браузера.
+func externalStateMatches(r *http.Request, state string) bool {
+	cookie, err := r.Cookie(externalStateCookie)
+	return err == nil && state != "" && subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) == 1
+}
+
+func newCSRFToken() (string, error) {
+	b := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
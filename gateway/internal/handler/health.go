@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Backend - gRPC сервис, от которого зависит готовность gateway.
+type Backend struct {
+	Name   string
+	Client healthv1.HealthClient
+}
+
+// HealthHandler - пробы оркестратора. /livez - процесс жив и отвечает, зависимости
+// не проверяются, чтобы падение backend'а не приводило к перезапуску gateway.
+// /readyz - можно слать трафик: gateway не останавливается и все backend'ы
+// отвечают SERVING по grpc.health.v1.
+type HealthHandler struct {
+	backends []Backend
+	timeout  time.Duration
+	logger   *slog.Logger
+	draining atomic.Bool
+}
+
+// NewHealthHandler ... timeout - сколько ждать ответа каждого backend'а.
+func NewHealthHandler(backends []Backend, timeout time.Duration, logger *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		backends: backends,
+		timeout:  timeout,
+		logger:   logger,
+	}
+}
+
+// healthBody - ответ проб. checks - статус каждого backend'а:
+// SERVING, NOT_SERVING или код gRPC ошибки, если он не ответил.
+type healthBody struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Drain переводит /readyz в 503 при остановке, чтобы балансировщик перестал слать трафик.
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Live GET /livez
+func (h *HealthHandler) Live(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, healthBody{Status: "ok"})
+}
+
+// Ready GET /readyz
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, healthBody{Status: "shutting down"})
+		return
+	}
+
+	checks := h.check(r.Context())
+
+	body := healthBody{Status: "ok", Checks: checks}
+	code := http.StatusOK
+	for name, st := range checks {
+		if st != healthv1.HealthCheckResponse_SERVING.String() {
+			body.Status = "unavailable"
+			code = http.StatusServiceUnavailable
+			h.logger.WarnContext(r.Context(), "backend not ready", slog.String("backend", name), slog.String("status", st))
+		}
+	}
+	writeJSON(w, code, body)
+}
+
+// check опрашивает backend'ы параллельно, общий таймаут - один timeout.
+func (h *HealthHandler) check(ctx context.Context) map[string]string {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	type result struct {
+		name   string
+		status string
+	}
+	results := make(chan result, len(h.backends))
+	for _, b := range h.backends {
+		go func() {
+			resp, err := b.Client.Check(ctx, &healthv1.HealthCheckRequest{})
+			if err != nil {
+				results <- result{name: b.Name, status: status.Code(err).String()}
+				return
+			}
+			results <- result{name: b.Name, status: resp.GetStatus().String()}
+		}()
+	}
+
+	checks := make(map[string]string, len(h.backends))
+	for range h.backends {
+		r := <-results
+		checks[r.name] = r.status
+	}
+	return checks
+}
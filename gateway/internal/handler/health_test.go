@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// fakeHealth отвечает заданным статусом или ошибкой; hang - ждёт отмены контекста.
+type fakeHealth struct {
+	healthv1.HealthClient
+	status healthv1.HealthCheckResponse_ServingStatus
+	err    error
+	hang   bool
+}
+
+func (f *fakeHealth) Check(ctx context.Context, _ *healthv1.HealthCheckRequest, _ ...grpc.CallOption) (*healthv1.HealthCheckResponse, error) {
+	if f.hang {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &healthv1.HealthCheckResponse{Status: f.status}, nil
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	t.Parallel()
+
+	serving := &fakeHealth{status: healthv1.HealthCheckResponse_SERVING}
+
+	tests := []struct {
+		name       string
+		chat       *fakeHealth
+		wantCode   int
+		wantStatus string
+		wantChat   string
+	}{
+		{
+			name:       "all serving",
+			chat:       serving,
+			wantCode:   http.StatusOK,
+			wantStatus: "ok",
+			wantChat:   "SERVING",
+		},
+		{
+			name:       "backend not serving",
+			chat:       &fakeHealth{status: healthv1.HealthCheckResponse_NOT_SERVING},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "unavailable",
+			wantChat:   "NOT_SERVING",
+		},
+		{
+			name:       "backend unreachable",
+			chat:       &fakeHealth{err: status.Error(codes.Unavailable, "connection refused")},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "unavailable",
+			wantChat:   "Unavailable",
+		},
+		{
+			name:       "backend timeout",
+			chat:       &fakeHealth{hang: true},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: "unavailable",
+			wantChat:   "DeadlineExceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHealthHandler([]Backend{
+				{Name: "auth-service", Client: serving},
+				{Name: "chat-service", Client: tt.chat},
+			}, 50*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			rec := httptest.NewRecorder()
+			start := time.Now()
+			h.Ready(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Less(t, time.Since(start), time.Second)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			var body healthBody
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+			assert.Equal(t, tt.wantStatus, body.Status)
+			assert.Equal(t, map[string]string{"auth-service": "SERVING", "chat-service": tt.wantChat}, body.Checks)
+		})
+	}
+}
+
+func TestHealthHandler_ReadyDraining(t *testing.T) {
+	t.Parallel()
+
+	h := NewHealthHandler([]Backend{
+		{Name: "auth-service", Client: &fakeHealth{status: healthv1.HealthCheckResponse_SERVING}},
+	}, time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	h.Drain()
+
+	rec := httptest.NewRecorder()
+	h.Ready(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"shutting down"}`, rec.Body.String())
+}
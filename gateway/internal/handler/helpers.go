@@ -2,6 +2,7 @@
 package handler
 
 import (
+	"auth/pkg/apierr"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -13,8 +14,70 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
+// errorBody - формат ошибок gateway. error - текст для людей, code - стабильный код
+// из каталога apierr, по которому клиент ветвится; details - нарушения по полям.
+type errorBody struct {
+	Error   string        `json:"error"`
+	Code    apierr.Code   `json:"code"`
+	Details *errorDetails `json:"details,omitempty"`
+}
+
+type errorDetails struct {
+	FieldViolations []apierr.FieldViolation `json:"field_violations"`
+}
+
+// writeError - ошибка самого gateway, код выводится из HTTP статуса.
 func writeError(w http.ResponseWriter, status int, msg string) {
-	writeJSON(w, status, map[string]string{"error": msg})
+	writeErrorCode(w, status, httpErrorCode(status), msg)
+}
+
+// writeErrorCode - ошибка gateway со своим кодом.
+func writeErrorCode(w http.ResponseWriter, status int, code apierr.Code, msg string) {
+	writeJSON(w, status, errorBody{Error: msg, Code: code})
+}
+
+// writeGRPCError - ошибка gRPC вызова: HTTP статус, код и нарушения по полям из статуса сервиса.
+func writeGRPCError(w http.ResponseWriter, err error) {
+	apiErr := apierr.Parse(err)
+
+	body := errorBody{Error: grpcMessage(err), Code: apiErr.Code}
+	if len(apiErr.Violations) > 0 {
+		body.Details = &errorDetails{FieldViolations: apiErr.Violations}
+	}
+	writeJSON(w, grpcStatusToHTTP(err), body)
+}
+
+// writeFieldError - 400 VALIDATION_FAILED с нарушениями по полям, как у ошибок валидации сервисов.
+func writeFieldError(w http.ResponseWriter, violations ...apierr.FieldViolation) {
+	writeJSON(w, http.StatusBadRequest, errorBody{
+		Error:   "invalid request",
+		Code:    apierr.CodeValidation,
+		Details: &errorDetails{FieldViolations: violations},
+	})
+}
+
+// httpErrorCode - общий код для ошибок, у которых есть только HTTP статус.
+func httpErrorCode(status int) apierr.Code {
+	switch status {
+	case http.StatusBadRequest:
+		return apierr.CodeInvalidArgument
+	case http.StatusUnauthorized:
+		return apierr.CodeUnauthenticated
+	case http.StatusForbidden:
+		return apierr.CodePermissionDenied
+	case http.StatusNotFound:
+		return apierr.CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return apierr.CodeMethodNotAllowed
+	case http.StatusConflict:
+		return apierr.CodeConflict
+	case http.StatusTooManyRequests:
+		return apierr.CodeRateLimited
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return apierr.CodeUnavailable
+	default:
+		return apierr.CodeInternal
+	}
 }
 
 func decodeJSON(r *http.Request, v any) error {
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Значения label transport.
+const (
+	transportWebSocket = "websocket"
+	transportSSE       = "sse"
+)
+
+var realtimeConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "gateway_realtime_connections",
+	Help: "Open realtime subscriptions by transport (websocket, sse).",
+}, []string{"transport"})
@@ -0,0 +1,371 @@
+// Package handler ...
+package handler
+
+import (
+	authv1 "auth/proto/auth/v1"
+	"crypto/subtle"
+	_ "embed"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OAuthHandler ...
+type OAuthHandler struct {
+	client authv1.OAuthServiceClient
+	issuer string
+}
+
+// NewOAuthHandler ...
+func NewOAuthHandler(client authv1.OAuthServiceClient, issuer string) *OAuthHandler {
+	return &OAuthHandler{
+		client: client,
+		issuer: strings.TrimRight(issuer, "/"),
+	}
+}
+
+// authorizeParams - параметры запроса авторизации, которые страница входа передаёт дальше.
+var authorizeParams = []string{
+	"response_type",
+	"client_id",
+	"redirect_uri",
+	"scope",
+	"state",
+	"code_challenge",
+	"code_challenge_method",
+	"nonce",
+}
+
+const (
+	authorizeCSRFCookie = "oauth_csrf"
+	authorizeCSRFField  = "csrf_token"
+	authorizeCookieTTL  = 10 * time.Minute
+)
+
+//go:embed oauth_authorize.html
+var authorizePageSrc string
+
+var authorizePage = template.Must(template.New("authorize").Parse(authorizePageSrc))
+
+// authorizePageData ...
+type authorizePageData struct {
+	ClientID string
+	Scope    string
+	Email    string
+	Error    string
+	CSRF     string
+	Params   map[string]string
+}
+
+// Authorize GET /oauth/authorize?response_type=code&client_id=..&redirect_uri=..&code_challenge=..&code_challenge_method=S256&state=..
+// Страница входа и согласия: пользователь вводит email и пароль прямо здесь, форма
+// уходит на POST /oauth/authorize вместе с параметрами запроса.
+func (h *OAuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	params := make(map[string]string, len(authorizeParams))
+	for _, name := range authorizeParams {
+		if v := r.URL.Query().Get(name); v != "" {
+			params[name] = v
+		}
+	}
+	if params["client_id"] == "" || params["redirect_uri"] == "" || params["response_type"] == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	csrf, err := newCSRFToken()
+	if err != nil {
+		writeOAuthError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	http.SetCookie(w, h.authorizeCookie(csrf, time.Now().Add(authorizeCookieTTL)))
+
+	h.writeAuthorizePage(w, http.StatusOK, authorizePageData{
+		ClientID: params["client_id"],
+		Scope:    params["scope"],
+		CSRF:     csrf,
+		Params:   params,
+	})
+}
+
+// AuthorizeSubmit POST /oauth/authorize
+// Body: application/x-www-form-urlencoded - email, password, csrf_token и параметры запроса авторизации.
+// Неверный пароль - та же страница с ошибкой, успех - редирект на redirect_uri с code и state.
+func (h *OAuthHandler) AuthorizeSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	// double-submit: форму можно отправить только со страницы, которую отдал gateway
+	cookie, err := r.Cookie(authorizeCSRFCookie)
+	field := r.PostForm.Get(authorizeCSRFField)
+	if err != nil || field == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(field)) != 1 {
+		writeOAuthError(w, http.StatusForbidden, "invalid_request")
+		return
+	}
+
+	params := make(map[string]string, len(authorizeParams))
+	for _, name := range authorizeParams {
+		if v := r.PostForm.Get(name); v != "" {
+			params[name] = v
+		}
+	}
+
+	resp, err := h.client.Authorize(r.Context(), &authv1.AuthorizeRequest{
+		Email:               r.PostForm.Get("email"),
+		Password:            r.PostForm.Get("password"),
+		ClientId:            params["client_id"],
+		RedirectUri:         params["redirect_uri"],
+		ResponseType:        params["response_type"],
+		Scope:               params["scope"],
+		CodeChallenge:       params["code_challenge"],
+		CodeChallengeMethod: params["code_challenge_method"],
+		Nonce:               params["nonce"],
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated && grpcMessage(err) == "login_required" {
+			h.writeAuthorizePage(w, http.StatusUnauthorized, authorizePageData{
+				ClientID: params["client_id"],
+				Scope:    params["scope"],
+				Email:    r.PostForm.Get("email"),
+				Error:    "Неверный email или пароль",
+				CSRF:     cookie.Value,
+				Params:   params,
+			})
+			return
+		}
+		// redirect_uri не проверен - редиректить на него с ошибкой нельзя.
+		writeOAuthError(w, oauthStatusToHTTP(err), grpcMessage(err))
+		return
+	}
+
+	redirect, err := url.Parse(resp.GetRedirectUri())
+	if err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	q := redirect.Query()
+	q.Set("code", resp.GetCode())
+	if state := params["state"]; state != "" {
+		q.Set("state", state)
+	}
+	redirect.RawQuery = q.Encode()
+
+	http.SetCookie(w, h.authorizeCookie("", time.Unix(0, 0)))
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// writeAuthorizePage - страница входа. Её нельзя встроить в чужой фрейм (clickjacking)
+// и нельзя кэшировать.
+func (h *OAuthHandler) writeAuthorizePage(w http.ResponseWriter, httpStatus int, data authorizePageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	w.WriteHeader(httpStatus)
+	_ = authorizePage.Execute(w, data)
+}
+
+// authorizeCookie - CSRF cookie страницы входа, живёт только на /oauth/authorize.
+func (h *OAuthHandler) authorizeCookie(value string, expires time.Time) *http.Cookie {
+	c := &http.Cookie{
+		Name:     authorizeCSRFCookie,
+		Value:    value,
+		Path:     "/oauth/authorize",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(h.issuer, "https://"),
+		SameSite: http.SameSiteStrictMode,
+	}
+	if value == "" {
+		c.MaxAge = -1
+	}
+	return c
+}
+
+// Token POST /oauth/token
+// Body: application/x-www-form-urlencoded (RFC 6749 §4.1.3, §4.4, §6)
+func (h *OAuthHandler) Token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret := clientCredentials(r)
+
+	resp, err := h.client.Token(r.Context(), &authv1.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientId:     clientID,
+		ClientSecret: clientSecret,
+		Code:         r.PostForm.Get("code"),
+		RedirectUri:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+	})
+	if err != nil {
+		writeOAuthError(w, oauthStatusToHTTP(err), grpcMessage(err))
+		return
+	}
+
+	body := map[string]any{
+		"access_token": resp.GetAccessToken(),
+		"token_type":   resp.GetTokenType(),
+		"expires_in":   resp.GetExpiresIn(),
+	}
+	if resp.GetRefreshToken() != "" {
+		body["refresh_token"] = resp.GetRefreshToken()
+	}
+	if resp.GetIdToken() != "" {
+		body["id_token"] = resp.GetIdToken()
+	}
+	if resp.GetScope() != "" {
+		body["scope"] = resp.GetScope()
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	writeJSON(w, http.StatusOK, body)
+}
+
+// Revoke POST /oauth/revoke
+// Body: token=...&token_type_hint=refresh_token (RFC 7009)
+// Клиент аутентифицируется так же, как на token endpoint: HTTP Basic или client_id/client_secret в форме.
+func (h *OAuthHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret := clientCredentials(r)
+
+	_, err := h.client.Revoke(r.Context(), &authv1.RevokeRequest{
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+		ClientId:      clientID,
+		ClientSecret:  clientSecret,
+	})
+	if err != nil {
+		writeOAuthError(w, oauthStatusToHTTP(err), grpcMessage(err))
+		return
+	}
+
+	// RFC 7009: неизвестный токен - тоже 200.
+	w.WriteHeader(http.StatusOK)
+}
+
+// Introspect POST /oauth/introspect
+// Body: token=... (RFC 7662). Только для конфиденциальных клиентов: HTTP Basic или client_id/client_secret в форме.
+func (h *OAuthHandler) Introspect(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret := clientCredentials(r)
+
+	resp, err := h.client.Introspect(r.Context(), &authv1.IntrospectRequest{
+		Token:        r.PostForm.Get("token"),
+		ClientId:     clientID,
+		ClientSecret: clientSecret,
+	})
+	if err != nil {
+		writeOAuthError(w, oauthStatusToHTTP(err), grpcMessage(err))
+		return
+	}
+
+	if !resp.GetActive() {
+		writeJSON(w, http.StatusOK, map[string]any{"active": false})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"active":     true,
+		"sub":        resp.GetSub(),
+		"client_id":  resp.GetClientId(),
+		"scope":      resp.GetScope(),
+		"token_type": resp.GetTokenType(),
+		"exp":        resp.GetExpiresAt().AsTime().Unix(),
+		"iss":        h.issuer,
+	})
+}
+
+// Discovery GET /.well-known/openid-configuration
+func (h *OAuthHandler) Discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                        h.issuer,
+		"authorization_endpoint":                        h.issuer + "/oauth/authorize",
+		"token_endpoint":                                h.issuer + "/oauth/token",
+		"revocation_endpoint":                           h.issuer + "/oauth/revoke",
+		"introspection_endpoint":                        h.issuer + "/oauth/introspect",
+		"jwks_uri":                                      h.issuer + "/.well-known/jwks.json",
+		"response_types_supported":                      []string{"code"},
+		"grant_types_supported":                         []string{"authorization_code", "refresh_token", "client_credentials"},
+		"subject_types_supported":                       []string{"public"},
+		"id_token_signing_alg_values_supported":         []string{"RS256"},
+		"scopes_supported":                              []string{"openid"},
+		"token_endpoint_auth_methods_supported":         []string{"client_secret_basic", "client_secret_post", "none"},
+		"revocation_endpoint_auth_methods_supported":    []string{"client_secret_basic", "client_secret_post", "none"},
+		"introspection_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"code_challenge_methods_supported":              []string{"S256"},
+	})
+}
+
+// JWKS GET /.well-known/jwks.json
+func (h *OAuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.GetJWKS(r.Context(), &authv1.GetJWKSRequest{})
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+
+	keys := make([]map[string]string, 0, len(resp.GetKeys()))
+	for _, k := range resp.GetKeys() {
+		keys = append(keys, map[string]string{
+			"kty": k.GetKty(),
+			"kid": k.GetKid(),
+			"use": k.GetUse(),
+			"alg": k.GetAlg(),
+			"n":   k.GetN(),
+			"e":   k.GetE(),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"keys": keys})
+}
+
+// clientCredentials берёт client_id/client_secret из HTTP Basic или из тела формы.
+func clientCredentials(r *http.Request) (string, string) {
+	if id, secret, ok := r.BasicAuth(); ok {
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+		return id, secret
+	}
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+// oauthStatusToHTTP - RFC 6749 §5.2: ошибки token endpoint отдаются 400, invalid_client - 401.
+func oauthStatusToHTTP(err error) int {
+	s, ok := status.FromError(err)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	switch s.Code() {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.PermissionDenied:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return grpcStatusToHTTP(err)
+	}
+}
+
+func writeOAuthError(w http.ResponseWriter, httpStatus int, code string) {
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, httpStatus, map[string]string{"error": code})
+}
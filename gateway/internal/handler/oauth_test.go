@@ -0,0 +1,260 @@
+package handler
+
+import (
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeOAuth запоминает последние запросы и отвечает заданной ошибкой.
+type fakeOAuth struct {
+	authv1.OAuthServiceClient
+	err        error
+	authorize  *authv1.AuthorizeRequest
+	revoke     *authv1.RevokeRequest
+	introspect *authv1.IntrospectRequest
+}
+
+func (f *fakeOAuth) Authorize(_ context.Context, req *authv1.AuthorizeRequest, _ ...grpc.CallOption) (*authv1.AuthorizeResponse, error) {
+	f.authorize = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &authv1.AuthorizeResponse{Code: "CODE", RedirectUri: req.GetRedirectUri()}, nil
+}
+
+func (f *fakeOAuth) Revoke(_ context.Context, req *authv1.RevokeRequest, _ ...grpc.CallOption) (*authv1.RevokeResponse, error) {
+	f.revoke = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &authv1.RevokeResponse{}, nil
+}
+
+func (f *fakeOAuth) Introspect(_ context.Context, req *authv1.IntrospectRequest, _ ...grpc.CallOption) (*authv1.IntrospectResponse, error) {
+	f.introspect = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &authv1.IntrospectResponse{Active: false}, nil
+}
+
+const authorizeQuery = "response_type=code&client_id=web&redirect_uri=https%3A%2F%2Fapp.example.com%2Fcb" +
+	"&code_challenge=CHALLENGE&code_challenge_method=S256&state=xyz&scope=openid"
+
+func TestOAuthHandler_AuthorizePage(t *testing.T) {
+	t.Parallel()
+
+	h := NewOAuthHandler(&fakeOAuth{}, "https://id.example.com")
+
+	rec := httptest.NewRecorder()
+	h.Authorize(rec, httptest.NewRequest(http.MethodGet, "/oauth/authorize?"+authorizeQuery, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "frame-ancestors 'none'")
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, authorizeCSRFCookie, cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `name="password"`)
+	assert.Contains(t, body, `name="csrf_token" value="`+cookies[0].Value+`"`)
+	assert.Contains(t, body, `name="state" value="xyz"`)
+	assert.Contains(t, body, `name="redirect_uri" value="https://app.example.com/cb"`)
+}
+
+func TestOAuthHandler_AuthorizePageMissingParams(t *testing.T) {
+	t.Parallel()
+
+	h := NewOAuthHandler(&fakeOAuth{}, "https://id.example.com")
+
+	rec := httptest.NewRecorder()
+	h.Authorize(rec, httptest.NewRequest(http.MethodGet, "/oauth/authorize?client_id=web", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"error":"invalid_request"}`, rec.Body.String())
+}
+
+func TestOAuthHandler_AuthorizeSubmit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		cookie       string
+		csrf         string
+		err          error
+		wantStatus   int
+		wantLocation string
+		wantCalled   bool
+		wantPage     bool
+	}{
+		{
+			name:         "success",
+			cookie:       "CSRF",
+			csrf:         "CSRF",
+			wantStatus:   http.StatusFound,
+			wantLocation: "https://app.example.com/cb?code=CODE&state=xyz",
+			wantCalled:   true,
+		},
+		{
+			name:       "wrong password",
+			cookie:     "CSRF",
+			csrf:       "CSRF",
+			err:        status.Error(codes.Unauthenticated, "login_required"),
+			wantStatus: http.StatusUnauthorized,
+			wantCalled: true,
+			wantPage:   true,
+		},
+		{
+			name:       "unknown client",
+			cookie:     "CSRF",
+			csrf:       "CSRF",
+			err:        status.Error(codes.Unauthenticated, "invalid_client"),
+			wantStatus: http.StatusUnauthorized,
+			wantCalled: true,
+		},
+		{
+			name:       "csrf mismatch",
+			cookie:     "CSRF",
+			csrf:       "OTHER",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no csrf cookie",
+			csrf:       "CSRF",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeOAuth{err: tt.err}
+			h := NewOAuthHandler(client, "https://id.example.com")
+
+			form, err := url.ParseQuery(authorizeQuery)
+			require.NoError(t, err)
+			form.Set("email", "user@example.com")
+			form.Set("password", "password")
+			form.Set("csrf_token", tt.csrf)
+
+			req := httptest.NewRequest(http.MethodPost, "/oauth/authorize", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: authorizeCSRFCookie, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+
+			h.AuthorizeSubmit(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get("Location"))
+			if !tt.wantCalled {
+				assert.Nil(t, client.authorize)
+				return
+			}
+			require.NotNil(t, client.authorize)
+			assert.Equal(t, "user@example.com", client.authorize.GetEmail())
+			assert.Equal(t, "password", client.authorize.GetPassword())
+			assert.Equal(t, "web", client.authorize.GetClientId())
+			assert.Equal(t, "CHALLENGE", client.authorize.GetCodeChallenge())
+			if tt.wantPage {
+				assert.Contains(t, rec.Body.String(), "Неверный email или пароль")
+				assert.Contains(t, rec.Body.String(), `value="user@example.com"`)
+			}
+		})
+	}
+}
+
+func TestOAuthHandler_ClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		call  func(h *OAuthHandler, w http.ResponseWriter, r *http.Request)
+		basic bool
+		got   func(f *fakeOAuth) (string, string)
+	}{
+		{
+			name:  "revoke basic",
+			call:  (*OAuthHandler).Revoke,
+			basic: true,
+			got:   func(f *fakeOAuth) (string, string) { return f.revoke.GetClientId(), f.revoke.GetClientSecret() },
+		},
+		{
+			name: "revoke form",
+			call: (*OAuthHandler).Revoke,
+			got:  func(f *fakeOAuth) (string, string) { return f.revoke.GetClientId(), f.revoke.GetClientSecret() },
+		},
+		{
+			name:  "introspect basic",
+			call:  (*OAuthHandler).Introspect,
+			basic: true,
+			got:   func(f *fakeOAuth) (string, string) { return f.introspect.GetClientId(), f.introspect.GetClientSecret() },
+		},
+		{
+			name: "introspect form",
+			call: (*OAuthHandler).Introspect,
+			got:  func(f *fakeOAuth) (string, string) { return f.introspect.GetClientId(), f.introspect.GetClientSecret() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeOAuth{}
+			h := NewOAuthHandler(client, "https://id.example.com")
+
+			form := url.Values{"token": {"TOKEN"}}
+			if !tt.basic {
+				form.Set("client_id", "billing")
+				form.Set("client_secret", "s3cret")
+			}
+			req := httptest.NewRequest(http.MethodPost, "/oauth/x", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basic {
+				req.SetBasicAuth("billing", "s3cret")
+			}
+			rec := httptest.NewRecorder()
+
+			tt.call(h, rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			id, secret := tt.got(client)
+			assert.Equal(t, "billing", id)
+			assert.Equal(t, "s3cret", secret)
+		})
+	}
+}
+
+func TestOAuthHandler_IntrospectUnauthenticatedClient(t *testing.T) {
+	t.Parallel()
+
+	h := NewOAuthHandler(&fakeOAuth{err: status.Error(codes.Unauthenticated, "invalid_client")}, "https://id.example.com")
+
+	req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader("token=TOKEN"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	h.Introspect(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"invalid_client"}`, rec.Body.String())
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	chatv1 "chat/proto/chat/v1"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// messageEvent - JSON сообщения для realtime клиентов, одинаковый для WebSocket и SSE.
+type messageEvent struct {
+	ID        int64     `json:"id"`
+	ChatID    int64     `json:"chat_id"`
+	SenderID  int64     `json:"sender_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	Muted     bool      `json:"muted,omitempty"`
+}
+
+func newMessageEvent(msg *chatv1.MessageDTO) messageEvent {
+	return messageEvent{
+		ID:        msg.GetId(),
+		ChatID:    msg.GetChatId(),
+		SenderID:  msg.GetSenderId(),
+		Text:      msg.GetText(),
+		CreatedAt: msg.GetCreatedAt().AsTime(),
+		Muted:     msg.GetMuted(),
+	}
+}
+
+// subscribe открывает gRPC стрим к chat-service от имени токена и читает его
+// в отдельной горутине, чтобы вызывающий мог параллельно слать heartbeat.
+// Горутина завершается при отмене ctx.
+func (h *WSHandler) subscribe(ctx context.Context, token string, afterID int64) (<-chan *chatv1.MessageDTO, <-chan error, error) {
+	md := metadata.Pairs("authorization", "Bearer "+token)
+	stream, err := h.client.Subscribe(metadata.NewOutgoingContext(ctx, md), &chatv1.SubscribeRequest{
+		AfterMessageId: afterID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msgs := make(chan *chatv1.MessageDTO)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return msgs, recvErr, nil
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"auth/pkg/clientinfo"
+	authv1 "auth/proto/auth/v1"
+	"context"
+	"encoding/json"
+	"fmt"
+	"gateway/internal/middleware"
+	authgw "gateway/proto/auth/v1"
+	chatgw "gateway/proto/chat/v1"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewRESTMux - REST API, сгенерированный grpc-gateway по google.api.http аннотациям
+// в auth.proto и chat.proto. Новый RPC с аннотацией получает HTTP маршрут без ручного кода.
+// Маршруты с особой логикой (cookie, редиректы) перекрываются ручными хендлерами в основном mux.
+func NewRESTMux(ctx context.Context, authConn *grpc.ClientConn, chatConn *grpc.ClientConn) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &restMarshaler{
+			JSONPb: runtime.JSONPb{
+				UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+			},
+		}),
+		runtime.WithErrorHandler(restError),
+		runtime.WithRoutingErrorHandler(restRoutingError),
+		runtime.WithForwardResponseOption(restStatus),
+		runtime.WithMiddlewares(restRoute),
+		runtime.WithIncomingHeaderMatcher(restHeaderMatcher),
+	)
+
+	if err := authgw.RegisterAuthServiceHandler(ctx, mux, authConn); err != nil {
+		return nil, fmt.Errorf("register auth handlers: %w", err)
+	}
+	if err := authgw.RegisterProfileServiceHandler(ctx, mux, authConn); err != nil {
+		return nil, fmt.Errorf("register profile handlers: %w", err)
+	}
+	if err := authgw.RegisterAdminServiceHandler(ctx, mux, authConn); err != nil {
+		return nil, fmt.Errorf("register admin handlers: %w", err)
+	}
+	if err := chatgw.RegisterChatServiceHandler(ctx, mux, chatConn); err != nil {
+		return nil, fmt.Errorf("register chat handlers: %w", err)
+	}
+
+	return mux, nil
+}
+
+// restError - ошибки в том же виде, что и у ручных хендлеров: { "error": "..." }.
+func restError(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	writeGRPCError(w, err)
+}
+
+// restRoutingError - 404/405 для путей без маршрута.
+func restRoutingError(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	middleware.SetRoute(r.Context(), middleware.UnmatchedRoute)
+	writeError(w, httpStatus, http.StatusText(httpStatus))
+}
+
+// restRoute сообщает метрикам шаблон сгенерированного маршрута вместо общего "/".
+func restRoute(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		if pattern, ok := runtime.HTTPPathPattern(r.Context()); ok {
+			middleware.SetRoute(r.Context(), r.Method+" "+pattern)
+		}
+		next(w, r, pathParams)
+	}
+}
+
+// restHeaderMatcher - как runtime.DefaultHeaderMatcher, но не пропускает
+// Grpc-Metadata-X-Client-Ip и Grpc-Metadata-X-Client-User-Agent: их ставит сам gateway
+// (clientinfo), иначе клиент подменил бы свой адрес в журнале аудита.
+func restHeaderMatcher(key string) (string, bool) {
+	if name, ok := strings.CutPrefix(textproto.CanonicalMIMEHeaderKey(key), runtime.MetadataHeaderPrefix); ok && clientinfo.IsMetadataKey(name) {
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// restStatus - коды ответа, отличные от 200.
+func restStatus(_ context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if _, ok := resp.(*authv1.RegisterResponse); ok {
+		w.WriteHeader(http.StatusCreated)
+	}
+	return nil
+}
+
+// restMarshaler отдаёт ответы в прежнем формате API: имена полей как в proto,
+// int64 числами (protojson пишет их строками), Timestamp в RFC 3339, пустой Timestamp - null.
+// Разбор запросов остаётся за protojson - он принимает int64 и числом, и строкой.
+type restMarshaler struct {
+	runtime.JSONPb
+}
+
+// Marshal ...
+func (m *restMarshaler) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return json.Marshal(v)
+	}
+	return json.Marshal(messageToJSON(msg.ProtoReflect()))
+}
+
+// ContentType ...
+func (m *restMarshaler) ContentType(_ any) string {
+	return "application/json"
+}
+
+func messageToJSON(m protoreflect.Message) any {
+	if ts, ok := m.Interface().(*timestamppb.Timestamp); ok {
+		return ts.AsTime()
+	}
+
+	fields := m.Descriptor().Fields()
+	out := make(map[string]any, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		out[string(fd.Name())] = fieldToJSON(fd, m)
+	}
+	return out
+}
+
+func fieldToJSON(fd protoreflect.FieldDescriptor, m protoreflect.Message) any {
+	v := m.Get(fd)
+
+	switch {
+	case fd.IsList():
+		list := v.List()
+		out := make([]any, list.Len())
+		for i := range out {
+			out[i] = valueToJSON(fd, list.Get(i))
+		}
+		return out
+	case fd.IsMap():
+		out := make(map[string]any, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			out[k.String()] = valueToJSON(fd.MapValue(), mv)
+			return true
+		})
+		return out
+	case fd.Message() != nil && !m.Has(fd):
+		return nil
+	default:
+		return valueToJSON(fd, v)
+	}
+}
+
+func valueToJSON(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageToJSON(v.Message())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int32(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestHeaderMatcher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header  string
+		wantKey string
+		wantOK  bool
+	}{
+		{header: "Grpc-Metadata-X-Client-Ip", wantOK: false},
+		{header: "grpc-metadata-x-client-user-agent", wantOK: false},
+		{header: "Grpc-Metadata-X-Tenant", wantKey: "X-Tenant", wantOK: true},
+		{header: "Authorization", wantKey: "grpcgateway-Authorization", wantOK: true},
+		{header: "X-Client-Ip", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			key, ok := restHeaderMatcher(tt.header)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"auth/pkg/apierr"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	sseHeartbeatPeriod = 15 * time.Second
+	sseRetry           = 3 * time.Second
+
+	sseSessionCookie = "sse_session"
+	sseSessionPath   = "/sse"
+	// defaultSSESessionTTL - срок SSE сессии, если у access токена нет exp
+	defaultSSESessionTTL = 15 * time.Minute
+)
+
+// SSESession POST /sse/session
+// Header: Authorization: Bearer <access>
+// Кладёт в HttpOnly cookie SSE сессию, которая действует, пока жив access токен.
+// В отличие от одноразового тикета, с ней EventSource сам переподключается
+// с Last-Event-ID. После обновления access токена сессию выдают заново.
+func (h *WSHandler) SSESession(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		writeError(w, http.StatusUnauthorized, "missing authorization header")
+		return
+	}
+	expiresAt, httpStatus, ok := h.verify(r, token)
+	if !ok {
+		writeError(w, httpStatus, http.StatusText(httpStatus))
+		return
+	}
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(defaultSSESessionTTL)
+	}
+
+	session, err := h.sessions.issueUntil(token, expiresAt)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sseSessionCookie,
+		Value:    session,
+		Path:     sseSessionPath,
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   h.cfg.CookieSecure,
+		SameSite: h.cfg.CookieSameSite,
+	})
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"expires_at": expiresAt,
+	})
+}
+
+// SubscribeSSE GET /sse/subscribe?last_event_id=...
+// Фолбэк для сетей, где прокси ломают WebSocket: тот же стрим chat-service,
+// отдаётся как text/event-stream. id события = id сообщения, при переподключении
+// Last-Event-ID (заголовок или last_event_id) догружает пропущенное. Если пропущено
+// слишком много, приходит событие resync: клиент перечитывает чаты через REST,
+// а стрим продолжается с новых сообщений.
+// Токен - cookie из POST /sse/session, одноразовый тикет из POST /ws/ticket
+// или заголовок Authorization.
+func (h *WSHandler) SubscribeSSE(w http.ResponseWriter, r *http.Request) {
+	if !h.originAllowed(r) {
+		writeErrorCode(w, http.StatusForbidden, apierr.CodeOriginNotAllowed, "origin not allowed")
+		return
+	}
+
+	token, ok := h.sseTokenFrom(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "missing token, session or ticket")
+		return
+	}
+	expiresAt, httpStatus, ok := h.verify(r, token)
+	if !ok {
+		writeError(w, httpStatus, http.StatusText(httpStatus))
+		return
+	}
+
+	lastEventID, err := lastEventID(r)
+	if err != nil {
+		writeFieldError(w, apierr.FieldViolation{Field: "Last-Event-ID", Description: "must be a message id"})
+		return
+	}
+
+	// SSE соединение не hijacked, но живёт долго - учитываем его как WebSocket при shutdown
+	if !h.track() {
+		writeErrorCode(w, http.StatusServiceUnavailable, apierr.CodeShuttingDown, "server shutting down")
+		return
+	}
+	defer h.conns.Done()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	msgs, recvErr, err := h.subscribe(ctx, token, lastEventID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "grpc subscribe failed", slog.String("err", err.Error()))
+		writeGRPCError(w, err)
+		return
+	}
+
+	realtimeConnections.WithLabelValues(transportSSE).Inc()
+	defer realtimeConnections.WithLabelValues(transportSSE).Dec()
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// nginx иначе буферизует ответ и события приходят пачками
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// send пишет кусок потока с дедлайном и сразу отправляет его клиенту
+	send := func(chunk string) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		if _, err := fmt.Fprint(w, chunk); err != nil {
+			h.logger.DebugContext(ctx, "sse write failed", slog.String("err", err.Error()))
+			return false
+		}
+		if err := rc.Flush(); err != nil {
+			h.logger.DebugContext(ctx, "sse flush failed", slog.String("err", err.Error()))
+			return false
+		}
+		return true
+	}
+
+	if !send(fmt.Sprintf("retry: %d\n\n", sseRetry.Milliseconds())) {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatPeriod)
+	defer heartbeat.Stop()
+
+	var expired <-chan time.Time
+	if !expiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		select {
+		case msg := <-msgs:
+			data, err := json.Marshal(newMessageEvent(msg))
+			if err != nil {
+				h.logger.ErrorContext(ctx, "sse marshal failed", slog.String("err", err.Error()))
+				return
+			}
+			if !send(fmt.Sprintf("id: %d\nevent: message\ndata: %s\n\n", msg.GetId(), data)) {
+				return
+			}
+
+		case <-heartbeat.C:
+			// Комментарий не виден клиенту, но не даёт прокси закрыть простаивающее соединение
+			if !send(": heartbeat\n\n") {
+				return
+			}
+
+		case <-expired:
+			h.sendSSEClose(send, wsCloseAuthExpired, "auth expired")
+			return
+
+		case err := <-recvErr:
+			if ctx.Err() != nil {
+				return
+			}
+			if apierr.Is(err, apierr.CodeResyncRequired) {
+				// Историю клиент перечитает сам, стрим продолжаем с новых сообщений
+				if !send("event: resync\ndata: {}\n\n") {
+					return
+				}
+				msgs, recvErr, err = h.subscribe(ctx, token, 0)
+				if err != nil {
+					h.logger.ErrorContext(ctx, "grpc subscribe failed", slog.String("err", err.Error()))
+					code, reason := wsCloseCode(err)
+					h.sendSSEClose(send, code, reason)
+					return
+				}
+				continue
+			}
+			h.logger.DebugContext(ctx, "grpc stream closed", slog.String("err", err.Error()))
+			code, reason := wsCloseCode(err)
+			h.sendSSEClose(send, code, reason)
+			return
+
+		case <-h.shutdown:
+			h.sendSSEClose(send, websocket.CloseServiceRestart, "server shutdown")
+			return
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendSSEClose - аналог close фрейма WebSocket: событие close с тем же кодом,
+// чтобы клиент одинаково обрабатывал оба транспорта.
+func (h *WSHandler) sendSSEClose(send func(string) bool, code int, reason string) {
+	data, _ := json.Marshal(map[string]any{"code": code, "reason": reason})
+	send(fmt.Sprintf("event: close\ndata: %s\n\n", data))
+}
+
+// sseTokenFrom - EventSource не умеет заголовки, поэтому для браузера cookie сессии
+// или тикет, для остальных клиентов обычный Authorization.
+func (h *WSHandler) sseTokenFrom(r *http.Request) (string, bool) {
+	if cookie, err := r.Cookie(sseSessionCookie); err == nil && cookie.Value != "" {
+		if token, ok := h.sessions.lookup(cookie.Value); ok {
+			return token, true
+		}
+	}
+	if ticket := r.URL.Query().Get("ticket"); ticket != "" {
+		return h.tickets.consume(ticket)
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token, token != ""
+}
+
+// lastEventID - браузер сам шлёт Last-Event-ID при автопереподключении,
+// при ручном переподключении с новой сессией id передаётся в query.
+func lastEventID(r *http.Request) (int64, error) {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	if v == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event id %q", v)
+	}
+	return id, nil
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"auth/pkg/apierr"
+	authv1 "auth/proto/auth/v1"
+	chatv1 "chat/proto/chat/v1"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeIntrospect считает активными только токены из active.
+type fakeIntrospect struct {
+	authv1.AuthServiceClient
+	active    map[string]bool
+	expiresAt time.Time
+}
+
+func (f *fakeIntrospect) IntrospectToken(_ context.Context, req *authv1.IntrospectTokenRequest, _ ...grpc.CallOption) (*authv1.IntrospectTokenResponse, error) {
+	if !f.active[req.GetAccessToken()] {
+		return &authv1.IntrospectTokenResponse{Active: false}, nil
+	}
+	return &authv1.IntrospectTokenResponse{Active: true, ExpiresAt: timestamppb.New(f.expiresAt)}, nil
+}
+
+// subscribeScript - ответ одного стрима Subscribe: сообщения, затем err.
+type subscribeScript struct {
+	msgs []*chatv1.MessageDTO
+	err  error
+}
+
+// fakeChatStream отдаёт стримы Subscribe по порядку из scripts и запоминает,
+// с каким токеном и after_message_id их открывали.
+type fakeChatStream struct {
+	chatv1.ChatServiceClient
+
+	mu       sync.Mutex
+	scripts  []subscribeScript
+	tokens   []string
+	afterIDs []int64
+}
+
+func (f *fakeChatStream) Subscribe(ctx context.Context, req *chatv1.SubscribeRequest, _ ...grpc.CallOption) (grpc.ServerStreamingClient[chatv1.MessageDTO], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.tokens = append(f.tokens, strings.TrimPrefix(strings.Join(md.Get("authorization"), ""), "Bearer "))
+	f.afterIDs = append(f.afterIDs, req.GetAfterMessageId())
+
+	script := subscribeScript{err: io.EOF}
+	if len(f.scripts) > 0 {
+		script, f.scripts = f.scripts[0], f.scripts[1:]
+	}
+	return &fakeMessageStream{msgs: script.msgs, err: script.err}, nil
+}
+
+type fakeMessageStream struct {
+	grpc.ServerStreamingClient[chatv1.MessageDTO]
+	msgs []*chatv1.MessageDTO
+	err  error
+}
+
+func (s *fakeMessageStream) Recv() (*chatv1.MessageDTO, error) {
+	if len(s.msgs) == 0 {
+		return nil, s.err
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+func newTestSSEHandler(chat *fakeChatStream) *WSHandler {
+	auth := &fakeIntrospect{active: map[string]bool{"ACCESS": true}, expiresAt: time.Now().Add(time.Hour)}
+	return NewWSHandler(chat, auth, WSConfig{CookieSecure: true, CookieSameSite: http.SameSiteStrictMode},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func sseSession(t *testing.T, h *WSHandler) *http.Cookie {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/sse/session", nil)
+	req.Header.Set("Authorization", "Bearer ACCESS")
+	rec := httptest.NewRecorder()
+
+	h.SSESession(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	return cookies[0]
+}
+
+func TestWSHandler_SSESession(t *testing.T) {
+	t.Parallel()
+
+	h := newTestSSEHandler(&fakeChatStream{})
+
+	cookie := sseSession(t, h)
+
+	assert.Equal(t, sseSessionCookie, cookie.Name)
+	assert.Equal(t, sseSessionPath, cookie.Path)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+	assert.NotEqual(t, "ACCESS", cookie.Value)
+	// Сессия живёт вместе с access токеном
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cookie.Expires, time.Minute)
+}
+
+func TestWSHandler_SSESessionUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	h := newTestSSEHandler(&fakeChatStream{})
+
+	for _, header := range []string{"", "Bearer EXPIRED"} {
+		req := httptest.NewRequest(http.MethodPost, "/sse/session", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+
+		h.SSESession(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+		assert.Empty(t, rec.Result().Cookies(), header)
+	}
+}
+
+func TestWSHandler_SubscribeSSEReconnectWithSession(t *testing.T) {
+	t.Parallel()
+
+	chat := &fakeChatStream{scripts: []subscribeScript{
+		{msgs: []*chatv1.MessageDTO{{Id: 41, ChatId: 1, SenderId: 7, Text: "hi"}}, err: io.EOF},
+		{msgs: []*chatv1.MessageDTO{{Id: 42, ChatId: 1, SenderId: 8, Text: "hey", Muted: true}}, err: io.EOF},
+	}}
+	h := newTestSSEHandler(chat)
+	cookie := sseSession(t, h)
+
+	// Первое подключение и автопереподключение EventSource: та же cookie и Last-Event-ID
+	var bodies []string
+	for _, lastID := range []string{"", "41"} {
+		req := httptest.NewRequest(http.MethodGet, "/sse/subscribe", nil)
+		req.AddCookie(cookie)
+		if lastID != "" {
+			req.Header.Set("Last-Event-ID", lastID)
+		}
+		rec := httptest.NewRecorder()
+
+		h.SubscribeSSE(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		bodies = append(bodies, rec.Body.String())
+	}
+
+	assert.Equal(t, []string{"ACCESS", "ACCESS"}, chat.tokens)
+	assert.Equal(t, []int64{0, 41}, chat.afterIDs)
+	assert.Contains(t, bodies[0], "id: 41\nevent: message\n")
+	assert.Contains(t, bodies[1], "id: 42\nevent: message\n")
+	assert.Contains(t, bodies[1], `"muted":true`)
+}
+
+func TestWSHandler_SubscribeSSEUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		prepare func(h *WSHandler, req *http.Request)
+	}{
+		{
+			name:    "no credentials",
+			prepare: func(*WSHandler, *http.Request) {},
+		},
+		{
+			name: "unknown session",
+			prepare: func(_ *WSHandler, req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: sseSessionCookie, Value: "forged"})
+			},
+		},
+		{
+			name: "expired session",
+			prepare: func(h *WSHandler, req *http.Request) {
+				session, err := h.sessions.issueUntil("ACCESS", time.Now().Add(-time.Second))
+				require.NoError(t, err)
+				req.AddCookie(&http.Cookie{Name: sseSessionCookie, Value: session})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chat := &fakeChatStream{}
+			h := newTestSSEHandler(chat)
+
+			req := httptest.NewRequest(http.MethodGet, "/sse/subscribe", nil)
+			tt.prepare(h, req)
+			rec := httptest.NewRecorder()
+
+			h.SubscribeSSE(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Empty(t, chat.afterIDs)
+		})
+	}
+}
+
+func TestWSHandler_SubscribeSSEResync(t *testing.T) {
+	t.Parallel()
+
+	chat := &fakeChatStream{scripts: []subscribeScript{
+		{err: apierr.New(codes.OutOfRange, apierr.CodeResyncRequired, "too many missed messages, resync required")},
+		{msgs: []*chatv1.MessageDTO{{Id: 900, ChatId: 1, SenderId: 8, Text: "new"}}, err: io.EOF},
+	}}
+	h := newTestSSEHandler(chat)
+
+	req := httptest.NewRequest(http.MethodGet, "/sse/subscribe", nil)
+	req.AddCookie(sseSession(t, h))
+	req.Header.Set("Last-Event-ID", "42")
+	rec := httptest.NewRecorder()
+
+	h.SubscribeSSE(rec, req)
+
+	body := rec.Body.String()
+	// Вместо обрезанной истории - resync, затем стрим продолжается с новых сообщений
+	assert.Equal(t, []int64{42, 0}, chat.afterIDs)
+	resync := strings.Index(body, "event: resync\n")
+	message := strings.Index(body, "id: 900\nevent: message\n")
+	require.NotEqual(t, -1, resync)
+	require.NotEqual(t, -1, message)
+	assert.Less(t, resync, message)
+	assert.Contains(t, body, "event: close\n")
+}
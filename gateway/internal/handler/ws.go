@@ -2,72 +2,334 @@
 package handler
 
 import (
-	chatv1 "gateway/proto/chat/v1"
+	"auth/pkg/apierr"
+	authv1 "auth/proto/auth/v1"
+	chatv1 "chat/proto/chat/v1"
+	"context"
+	"errors"
+	"gateway/internal/middleware"
+	"io"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-var upgrader = websocket.Upgrader{
-	// В продакшене здесь нужно проверять Origin
-	CheckOrigin: func(r *http.Request) bool { return true },
+// wsBearerProtocol - браузер не умеет ставить Authorization на WebSocket,
+// поэтому токен можно передать подпротоколом: new WebSocket(url, ["bearer", token]).
+const wsBearerProtocol = "bearer"
+
+const (
+	wsWriteWait      = 10 * time.Second
+	wsPongWait       = 60 * time.Second
+	wsPingPeriod     = wsPongWait * 9 / 10
+	wsMaxMessageSize = 512
+
+	// wsCloseAuthExpired - код приложения (4000-4999): токен истёк или отозван,
+	// клиенту нужно обновить токен, а не просто переподключиться.
+	wsCloseAuthExpired = 4001
+)
+
+// WSConfig ...
+type WSConfig struct {
+	// Audience - ожидаемый aud access токена (пусто - не проверяется)
+	Audience string
+	// AllowedOrigins - разрешённые Origin; пусто - только тот же хост, "*" - любой
+	AllowedOrigins []string
+	// TicketTTL - время жизни одноразового тикета из POST /ws/ticket
+	TicketTTL time.Duration
+	// CookieSecure, CookieSameSite - атрибуты cookie SSE сессии из POST /sse/session
+	CookieSecure   bool
+	CookieSameSite http.SameSite
 }
 
 // WSHandler держит gRPC-клиент chat-сервиса.
 type WSHandler struct {
-	client chatv1.ChatServiceClient
-	logger *slog.Logger
+	client   chatv1.ChatServiceClient
+	auth     authv1.AuthServiceClient
+	cfg      WSConfig
+	tickets  *ticketStore
+	sessions *ticketStore
+	upgrader websocket.Upgrader
+	logger   *slog.Logger
+
+	// Активные соединения - для graceful shutdown
+	mu       sync.Mutex
+	closing  bool
+	shutdown chan struct{}
+	conns    sync.WaitGroup
 }
 
 // NewWSHandler ...
-func NewWSHandler(client chatv1.ChatServiceClient, logger *slog.Logger) *WSHandler {
-	return &WSHandler{client: client, logger: logger}
+func NewWSHandler(client chatv1.ChatServiceClient, auth authv1.AuthServiceClient, cfg WSConfig, logger *slog.Logger) *WSHandler {
+	h := &WSHandler{
+		client:   client,
+		auth:     auth,
+		cfg:      cfg,
+		tickets:  newTicketStore(cfg.TicketTTL),
+		sessions: newTicketStore(defaultSSESessionTTL),
+		logger:   logger,
+		shutdown: make(chan struct{}),
+	}
+	h.upgrader = websocket.Upgrader{
+		CheckOrigin:  h.originAllowed,
+		Subprotocols: []string{wsBearerProtocol},
+	}
+	return h
 }
 
-// Subscribe GET /ws/subscribe
+// Subscribe GET /ws/subscribe?ticket=... или с подпротоколом ["bearer", token]
 // Апгрейдит HTTP соединение до WebSocket, открывает gRPC стрим
 // к chat-service и пушит входящие сообщения клиенту.
 func (h *WSHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
-	// 1. Апгрейд до WebSocket
-	conn, err := upgrader.Upgrade(w, r, nil)
+	// 1. Проверяем Origin и токен до апгрейда, чтобы клиент получил нормальный HTTP статус
+	if !h.originAllowed(r) {
+		writeErrorCode(w, http.StatusForbidden, apierr.CodeOriginNotAllowed, "origin not allowed")
+		return
+	}
+
+	token, ok := h.tokenFrom(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "missing token or ticket")
+		return
+	}
+	expiresAt, httpStatus, ok := h.verify(r, token)
+	if !ok {
+		writeError(w, httpStatus, http.StatusText(httpStatus))
+		return
+	}
+
+	// 2. Апгрейд до WebSocket
+	if !h.track() {
+		writeErrorCode(w, http.StatusServiceUnavailable, apierr.CodeShuttingDown, "server shutting down")
+		return
+	}
+	defer h.conns.Done()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		h.logger.Error("ws upgrade failed", slog.String("err", err.Error()))
+		h.logger.ErrorContext(r.Context(), "ws upgrade failed", slog.String("err", err.Error()))
 		return
 	}
 	defer conn.Close()
 
-	// 2. Открываем gRPC стрим к chat-service, прокидываем JWT
-	token := r.URL.Query().Get("token")
-	md := metadata.Pairs("authorization", "Bearer "+token)
-	ctx := metadata.NewOutgoingContext(r.Context(), md)
-	stream, err := h.client.Subscribe(ctx, &chatv1.SubscribeRequest{})
+	realtimeConnections.WithLabelValues(transportWebSocket).Inc()
+	defer realtimeConnections.WithLabelValues(transportWebSocket).Dec()
+
+	// Контекст живёт, пока жив клиент: отмена закрывает gRPC стрим
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// 3. Открываем gRPC стрим к chat-service, прокидываем JWT
+	msgs, recvErr, err := h.subscribe(ctx, token, 0)
 	if err != nil {
-		h.logger.Error("grpc subscribe failed", slog.String("err", err.Error()))
+		h.logger.ErrorContext(ctx, "grpc subscribe failed", slog.String("err", err.Error()))
+		code, reason := wsCloseCode(err)
+		h.closeWS(conn, code, reason)
 		return
 	}
 
-	// 3. Читаем сообщения из gRPC стрима и пушим в WebSocket
+	// 4. Читатель: клиенту писать нечего, но чтение нужно для pong и close фреймов.
+	// Ошибка чтения = клиент ушёл или перестал отвечать на ping.
+	conn.SetReadLimit(wsMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+
+	// Закрываем соединение сами, когда истекает access токен - клиент обновит его и переподключится
+	var expired <-chan time.Time
+	if !expiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(expiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	// 5. Пушим сообщения в WebSocket, все записи только из этой горутины
 	for {
-		msg, err := stream.Recv()
-		if err != nil {
-			// Клиент отключился или chat-service упал — выходим
-			h.logger.Debug("grpc stream closed", slog.String("err", err.Error()))
+		select {
+		case msg := <-msgs:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(newMessageEvent(msg)); err != nil {
+				h.logger.DebugContext(ctx, "ws write failed", slog.String("err", err.Error()))
+				return
+			}
+
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				h.logger.DebugContext(ctx, "ws ping failed", slog.String("err", err.Error()))
+				return
+			}
+
+		case <-expired:
+			h.closeWS(conn, wsCloseAuthExpired, "auth expired")
 			return
-		}
 
-		payload := map[string]any{
-			"id":         msg.GetId(),
-			"chat_id":    msg.GetChatId(),
-			"sender_id":  msg.GetSenderId(),
-			"text":       msg.GetText(),
-			"created_at": msg.GetCreatedAt().AsTime(),
-		}
+		case err := <-recvErr:
+			if ctx.Err() != nil {
+				// Стрим отменён, потому что клиент уже отключился
+				return
+			}
+			// chat-service закрыл стрим или упал - объясняем клиенту почему
+			h.logger.DebugContext(ctx, "grpc stream closed", slog.String("err", err.Error()))
+			code, reason := wsCloseCode(err)
+			h.closeWS(conn, code, reason)
+			return
 
-		if err := conn.WriteJSON(payload); err != nil {
-			h.logger.Debug("ws write failed", slog.String("err", err.Error()))
+		case <-h.shutdown:
+			h.closeWS(conn, websocket.CloseServiceRestart, "server shutdown")
+			return
+
+		case <-ctx.Done():
+			// Клиент отключился
 			return
 		}
 	}
 }
+
+// Shutdown перестаёт принимать новые подписки, отправляет всем открытым
+// соединениям close фрейм и ждёт их завершения или отмены ctx.
+func (h *WSHandler) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	if !h.closing {
+		h.closing = true
+		close(h.shutdown)
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.conns.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// track регистрирует новое соединение, если сервер ещё не останавливается.
+func (h *WSHandler) track() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closing {
+		return false
+	}
+	h.conns.Add(1)
+	return true
+}
+
+// Ticket POST /ws/ticket
+// Header: Authorization: Bearer <access>
+// Выдаёт короткоживущий одноразовый тикет для ?ticket= в /ws/subscribe,
+// чтобы access токен не попадал в URL и логи.
+func (h *WSHandler) Ticket(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		writeError(w, http.StatusUnauthorized, "missing authorization header")
+		return
+	}
+	if _, httpStatus, ok := h.verify(r, token); !ok {
+		writeError(w, httpStatus, http.StatusText(httpStatus))
+		return
+	}
+
+	ticket, expiresAt, err := h.tickets.issue(token)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"ticket":     ticket,
+		"expires_at": expiresAt,
+	})
+}
+
+// tokenFrom достаёт access токен из тикета или из подпротокола "bearer".
+func (h *WSHandler) tokenFrom(r *http.Request) (string, bool) {
+	if ticket := r.URL.Query().Get("ticket"); ticket != "" {
+		return h.tickets.consume(ticket)
+	}
+
+	protocols := websocket.Subprotocols(r)
+	i := slices.Index(protocols, wsBearerProtocol)
+	if i < 0 || i+1 >= len(protocols) || protocols[i+1] == "" {
+		return "", false
+	}
+	return protocols[i+1], true
+}
+
+// verify проверяет токен через IntrospectToken auth-сервиса и возвращает его срок жизни.
+// Если токен не принят - HTTP статус для ответа.
+func (h *WSHandler) verify(r *http.Request, token string) (time.Time, int, bool) {
+	resp, err := h.auth.IntrospectToken(r.Context(), &authv1.IntrospectTokenRequest{
+		AccessToken: token,
+		Audience:    h.cfg.Audience,
+	})
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "introspect token failed", slog.String("err", err.Error()))
+		return time.Time{}, http.StatusServiceUnavailable, false
+	}
+	if !resp.GetActive() {
+		return time.Time{}, http.StatusUnauthorized, false
+	}
+	if resp.GetExpiresAt() == nil {
+		return time.Time{}, http.StatusOK, true
+	}
+	return resp.GetExpiresAt().AsTime(), http.StatusOK, true
+}
+
+// originAllowed сверяет Origin с ws_allowed_origins (см. middleware.OriginAllowed).
+func (h *WSHandler) originAllowed(r *http.Request) bool {
+	return middleware.OriginAllowed(r, h.cfg.AllowedOrigins)
+}
+
+// closeWS отправляет close фрейм с кодом и причиной; ошибки не важны - соединение всё равно закрываем.
+func (h *WSHandler) closeWS(conn *websocket.Conn, code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait)); err != nil {
+		h.logger.Debug("ws close failed", slog.String("err", err.Error()))
+	}
+}
+
+// wsCloseCode переводит ошибку gRPC стрима в close код WebSocket,
+// чтобы клиент отличал рестарт сервера от проблем с авторизацией.
+func wsCloseCode(err error) (int, string) {
+	if errors.Is(err, io.EOF) {
+		return websocket.CloseGoingAway, "stream closed"
+	}
+
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return wsCloseAuthExpired, "auth expired"
+	case codes.Unavailable:
+		return websocket.CloseServiceRestart, "server shutdown"
+	case codes.ResourceExhausted:
+		return websocket.CloseTryAgainLater, "overloaded"
+	default:
+		return websocket.CloseInternalServerErr, "internal error"
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTicketTTL = 30 * time.Second
+	ticketBytes      = 32
+)
+
+// ticketStore - тикеты realtime подписок в памяти gateway: одноразовые для WebSocket
+// (consume) и многоразовые SSE сессии в cookie (lookup).
+// Одноразовый тикет действует один раз и недолго, поэтому его не страшно передать в URL.
+type ticketStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	tickets map[string]wsTicket
+}
+
+type wsTicket struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+func newTicketStore(ttl time.Duration) *ticketStore {
+	if ttl <= 0 {
+		ttl = defaultTicketTTL
+	}
+	return &ticketStore{
+		ttl:     ttl,
+		tickets: make(map[string]wsTicket),
+	}
+}
+
+func (s *ticketStore) issue(accessToken string) (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.ttl)
+	ticket, err := s.issueUntil(accessToken, expiresAt)
+	return ticket, expiresAt, err
+}
+
+// issueUntil выдаёт тикет, который действует до expiresAt.
+func (s *ticketStore) issueUntil(accessToken string, expiresAt time.Time) (string, error) {
+	b := make([]byte, ticketBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	ticket := base64.RawURLEncoding.EncodeToString(b)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Заодно чистим протухшие, чтобы map не рос от неиспользованных тикетов
+	for k, t := range s.tickets {
+		if now.After(t.expiresAt) {
+			delete(s.tickets, k)
+		}
+	}
+	s.tickets[ticket] = wsTicket{accessToken: accessToken, expiresAt: expiresAt}
+
+	return ticket, nil
+}
+
+// consume возвращает токен тикета и удаляет его - повторно тикет не сработает.
+func (s *ticketStore) consume(ticket string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tickets[ticket]
+	if !ok {
+		return "", false
+	}
+	delete(s.tickets, ticket)
+
+	if time.Now().After(t.expiresAt) {
+		return "", false
+	}
+	return t.accessToken, true
+}
+
+// lookup возвращает токен тикета, не удаляя его: SSE сессия переживает
+// автопереподключения EventSource, пока не истечёт.
+func (s *ticketStore) lookup(ticket string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tickets[ticket]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(t.expiresAt) {
+		delete(s.tickets, ticket)
+		return "", false
+	}
+	return t.accessToken, true
+}
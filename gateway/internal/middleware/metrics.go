@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled by the gateway by route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency (seconds) by route. WebSocket and SSE requests last as long as the connection.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// UnmatchedRoute - label для запросов, не попавших ни в один маршрут:
+// сырой путь в label раздул бы число серий.
+const UnmatchedRoute = "unmatched"
+
+type routeKey struct{}
+
+// SetRoute уточняет маршрут запроса для метрик и имени спана. Нужен хендлерам со своим роутингом
+// (grpc-gateway под "/"), иначе все их запросы попали бы в один маршрут.
+func SetRoute(ctx context.Context, route string) {
+	if r, ok := ctx.Value(routeKey{}).(*string); ok {
+		*r = route
+	}
+}
+
+// withRoute кладёт в контекст ячейку для маршрута. Если её уже положил внешний
+// middleware, запрос не копируется: ServeMux запишет r.Pattern в тот же запрос.
+func withRoute(r *http.Request) (*http.Request, *string) {
+	if route, ok := r.Context().Value(routeKey{}).(*string); ok {
+		return r, route
+	}
+	route := new(string)
+	return r.WithContext(context.WithValue(r.Context(), routeKey{}, route)), route
+}
+
+// resolveRoute - маршрут обработанного запроса: из SetRoute или шаблон, найденный
+// ServeMux (он записывает его в r.Pattern). Результат остаётся в ячейке для внешних middleware.
+func resolveRoute(r *http.Request, route *string) string {
+	if *route == "" {
+		*route = r.Pattern
+	}
+	if *route == "" {
+		return UnmatchedRoute
+	}
+	return *route
+}
+
+// Metrics считает запросы и их длительность по шаблону маршрута ServeMux, а не по пути.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		r, cell := withRoute(r)
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		route := resolveRoute(r, cell)
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(rw.status)).Inc()
+		httpRequestSeconds.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
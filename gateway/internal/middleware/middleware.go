@@ -2,22 +2,48 @@
 package middleware
 
 import (
+	"auth/pkg/clientinfo"
+	"auth/pkg/requestid"
 	"bufio"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
-// CORS ...
-func CORS(next http.Handler) http.Handler {
+// CORS отвечает на запросы из браузера только разрешённым Origin (тот же список, что и для
+// WebSocket): им Origin возвращается вместе с Allow-Credentials, иначе браузер не пошлёт cookie
+// с refresh токеном. "*" в списке открывает API любому сайту, но без cookie.
+// Остальные Origin CORS заголовков не получают, а их preflight - 403.
+func CORS(allowedOrigins []string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		origin := r.Header.Get("Origin")
+		allowed := origin != "" && OriginAllowed(r, allowedOrigins)
+		if allowed {
+			if originListed(origin, allowedOrigins) || len(allowedOrigins) == 0 {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-CSRF-Token, X-Request-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
+		}
+		if origin != "" {
+			// Ответ зависит от Origin - кэши не должны отдавать его другому сайту
+			w.Header().Add("Vary", "Origin")
+		}
 
 		if r.Method == http.MethodOptions {
+			if origin != "" && !allowed {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
@@ -26,13 +52,61 @@ func CORS(next http.Handler) http.Handler {
 	})
 }
 
+// OriginAllowed - запросы без Origin (не браузер) пропускаем,
+// остальные сверяем со списком или, если он пуст, с Host.
+func OriginAllowed(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if len(allowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+
+	return slices.Contains(allowedOrigins, "*") || originListed(origin, allowedOrigins)
+}
+
+func originListed(origin string, allowedOrigins []string) bool {
+	for _, allowed := range allowedOrigins {
+		if strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestID берёт X-Request-ID клиента или создаёт новый, кладёт его в контекст
+// (оттуда он уходит в gRPC метаданные и в логи) и возвращает в ответе.
+// Невалидный ID клиента заменяется, чтобы в логи не попало произвольное содержимое.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		w.Header().Set(requestid.Header, id)
+
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+// ClientInfo кладёт в контекст адрес и User-Agent клиента: auth-service
+// получает их в gRPC метаданных и пишет в журнал аудита.
+func ClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(clientinfo.NewContext(r.Context(), clientinfo.FromRequest(r))))
+	})
+}
+
 // Logger ...
 func Logger(log *slog.Logger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
 		next.ServeHTTP(rw, r)
-		log.Info("http",
+		log.InfoContext(r.Context(), "http",
 			slog.String("method", r.Method),
 			slog.String("path", r.URL.Path),
 			slog.Int("status", rw.status),
@@ -51,11 +125,21 @@ func (rw *responseWriter) WriteHeader(status int) {
 	rw.ResponseWriter.WriteHeader(status)
 }
 
+// Unwrap нужен http.ResponseController (Flush и дедлайны для SSE).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Hijack ...
 func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	h, ok := rw.ResponseWriter.(http.Hijacker)
 	if !ok {
 		return nil, nil, fmt.Errorf("underlying ResponseWriter does not implement http.Hijacker")
 	}
-	return h.Hijack()
+	conn, buf, err := h.Hijack()
+	if err == nil {
+		// Дальше ответ пишется в conn напрямую (апгрейд до WebSocket), WriteHeader не будет
+		rw.status = http.StatusSwitchingProtocols
+	}
+	return conn, buf, err
 }
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		allowed        []string
+		method         string
+		origin         string
+		wantStatus     int
+		wantNext       bool
+		wantOrigin     string
+		wantCredential bool
+	}{
+		{
+			name:           "allowed origin",
+			allowed:        []string{"https://app.example.com"},
+			method:         http.MethodGet,
+			origin:         "https://app.example.com",
+			wantStatus:     http.StatusOK,
+			wantNext:       true,
+			wantOrigin:     "https://app.example.com",
+			wantCredential: true,
+		},
+		{
+			name:       "denied origin",
+			allowed:    []string{"https://app.example.com"},
+			method:     http.MethodPost,
+			origin:     "https://evil.example.com",
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name:           "allowed preflight",
+			allowed:        []string{"https://app.example.com"},
+			method:         http.MethodOptions,
+			origin:         "https://app.example.com",
+			wantStatus:     http.StatusNoContent,
+			wantOrigin:     "https://app.example.com",
+			wantCredential: true,
+		},
+		{
+			name:       "denied preflight",
+			allowed:    []string{"https://app.example.com"},
+			method:     http.MethodOptions,
+			origin:     "https://evil.example.com",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:           "empty list allows same host",
+			method:         http.MethodGet,
+			origin:         "http://gateway.test",
+			wantStatus:     http.StatusOK,
+			wantNext:       true,
+			wantOrigin:     "http://gateway.test",
+			wantCredential: true,
+		},
+		{
+			name:       "empty list denies other host",
+			method:     http.MethodGet,
+			origin:     "http://other.test",
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name:       "wildcard without credentials",
+			allowed:    []string{"*"},
+			method:     http.MethodGet,
+			origin:     "https://any.example.com",
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+			wantOrigin: "*",
+		},
+		{
+			name:       "no origin",
+			allowed:    []string{"https://app.example.com"},
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "http://gateway.test/auth/login", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			rec := httptest.NewRecorder()
+
+			CORS(tt.allowed, next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantNext, called)
+			assert.Equal(t, tt.wantOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			if tt.wantCredential {
+				assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+			}
+			if tt.origin != "" {
+				assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "gateway/internal/middleware"
+
+// Tracing начинает серверный спан на каждый запрос и продолжает трейс клиента, если
+// тот прислал traceparent. Вызовы gRPC из хендлеров становятся дочерними спанами.
+// Спан называется по шаблону маршрута ("POST /chat/send"), как метрики.
+func Tracing(next http.Handler) http.Handler {
+	tracer := otel.Tracer(tracerName)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		r, cell := withRoute(r.WithContext(ctx))
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		if route := resolveRoute(r, cell); route != UnmatchedRoute {
+			// Шаблоны ServeMux и grpc-gateway бывают с методом ("GET /chat/{id}") и без ("/ws")
+			path := route
+			if _, p, ok := strings.Cut(route, " "); ok {
+				path = p
+			}
+			span.SetName(r.Method + " " + path)
+			span.SetAttributes(semconv.HTTPRoute(path))
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(rw.status))
+		if rw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rw.status))
+		}
+	})
+}
@@ -1,792 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.11
-// 	protoc        v7.34.0--rc2
-// source: proto/chat/v1/chat.proto
-
-package chatv1
-
-import (
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-// SubscribeRequest
-type SubscribeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SubscribeRequest) Reset() {
-	*x = SubscribeRequest{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *SubscribeRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*SubscribeRequest) ProtoMessage() {}
-
-func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{0}
-}
-
-// GetOrCreateChat
-type GetOrCreateChatRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	InitiatorId   int64                  `protobuf:"varint,1,opt,name=initiator_id,json=initiatorId,proto3" json:"initiator_id,omitempty"`
-	RecipientId   int64                  `protobuf:"varint,2,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetOrCreateChatRequest) Reset() {
-	*x = GetOrCreateChatRequest{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetOrCreateChatRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetOrCreateChatRequest) ProtoMessage() {}
-
-func (x *GetOrCreateChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetOrCreateChatRequest.ProtoReflect.Descriptor instead.
-func (*GetOrCreateChatRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *GetOrCreateChatRequest) GetInitiatorId() int64 {
-	if x != nil {
-		return x.InitiatorId
-	}
-	return 0
-}
-
-func (x *GetOrCreateChatRequest) GetRecipientId() int64 {
-	if x != nil {
-		return x.RecipientId
-	}
-	return 0
-}
-
-type GetOrCreateChatResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"` // true если чат только что создан
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetOrCreateChatResponse) Reset() {
-	*x = GetOrCreateChatResponse{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetOrCreateChatResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetOrCreateChatResponse) ProtoMessage() {}
-
-func (x *GetOrCreateChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[2]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetOrCreateChatResponse.ProtoReflect.Descriptor instead.
-func (*GetOrCreateChatResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *GetOrCreateChatResponse) GetChatId() int64 {
-	if x != nil {
-		return x.ChatId
-	}
-	return 0
-}
-
-func (x *GetOrCreateChatResponse) GetCreated() bool {
-	if x != nil {
-		return x.Created
-	}
-	return false
-}
-
-func (x *GetOrCreateChatResponse) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-// GetMessages — cursor-based пагинация
-// Курсор = created_at последнего полученного сообщения
-type GetMessagesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // рекомендуется 50
-	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // пустой = с самого нового, иначе — старше курсора
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetMessagesRequest) Reset() {
-	*x = GetMessagesRequest{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetMessagesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetMessagesRequest) ProtoMessage() {}
-
-func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[3]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
-func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *GetMessagesRequest) GetChatId() int64 {
-	if x != nil {
-		return x.ChatId
-	}
-	return 0
-}
-
-func (x *GetMessagesRequest) GetLimit() int64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *GetMessagesRequest) GetCursor() string {
-	if x != nil {
-		return x.Cursor
-	}
-	return ""
-}
-
-type GetMessagesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*MessageDTO          `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
-	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // пустой = больше сообщений нет
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetMessagesResponse) Reset() {
-	*x = GetMessagesResponse{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetMessagesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetMessagesResponse) ProtoMessage() {}
-
-func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[4]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
-func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *GetMessagesResponse) GetMessages() []*MessageDTO {
-	if x != nil {
-		return x.Messages
-	}
-	return nil
-}
-
-func (x *GetMessagesResponse) GetNextCursor() string {
-	if x != nil {
-		return x.NextCursor
-	}
-	return ""
-}
-
-// GetUserChats
-type GetUserChatsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserChatsRequest) Reset() {
-	*x = GetUserChatsRequest{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserChatsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserChatsRequest) ProtoMessage() {}
-
-func (x *GetUserChatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[5]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserChatsRequest.ProtoReflect.Descriptor instead.
-func (*GetUserChatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *GetUserChatsRequest) GetUserId() int64 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
-}
-
-func (x *GetUserChatsRequest) GetLimit() int64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *GetUserChatsRequest) GetOffset() int64 {
-	if x != nil {
-		return x.Offset
-	}
-	return 0
-}
-
-type GetUserChatsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chats         []*ChatPreviewDTO      `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetUserChatsResponse) Reset() {
-	*x = GetUserChatsResponse{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetUserChatsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetUserChatsResponse) ProtoMessage() {}
-
-func (x *GetUserChatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[6]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetUserChatsResponse.ProtoReflect.Descriptor instead.
-func (*GetUserChatsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *GetUserChatsResponse) GetChats() []*ChatPreviewDTO {
-	if x != nil {
-		return x.Chats
-	}
-	return nil
-}
-
-// SendMessage
-type SendMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	SenderId      int64                  `protobuf:"varint,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SendMessageRequest) Reset() {
-	*x = SendMessageRequest{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *SendMessageRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*SendMessageRequest) ProtoMessage() {}
-
-func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[7]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
-func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *SendMessageRequest) GetChatId() int64 {
-	if x != nil {
-		return x.ChatId
-	}
-	return 0
-}
-
-func (x *SendMessageRequest) GetSenderId() int64 {
-	if x != nil {
-		return x.SenderId
-	}
-	return 0
-}
-
-func (x *SendMessageRequest) GetText() string {
-	if x != nil {
-		return x.Text
-	}
-	return ""
-}
-
-type SendMessageResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *SendMessageResponse) Reset() {
-	*x = SendMessageResponse{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *SendMessageResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*SendMessageResponse) ProtoMessage() {}
-
-func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[8]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
-func (*SendMessageResponse) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *SendMessageResponse) GetMessageId() int64 {
-	if x != nil {
-		return x.MessageId
-	}
-	return 0
-}
-
-func (x *SendMessageResponse) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-type MessageDTO struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ChatId        int64                  `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	SenderId      int64                  `protobuf:"varint,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *MessageDTO) Reset() {
-	*x = MessageDTO{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *MessageDTO) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*MessageDTO) ProtoMessage() {}
-
-func (x *MessageDTO) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[9]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use MessageDTO.ProtoReflect.Descriptor instead.
-func (*MessageDTO) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *MessageDTO) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *MessageDTO) GetChatId() int64 {
-	if x != nil {
-		return x.ChatId
-	}
-	return 0
-}
-
-func (x *MessageDTO) GetSenderId() int64 {
-	if x != nil {
-		return x.SenderId
-	}
-	return 0
-}
-
-func (x *MessageDTO) GetText() string {
-	if x != nil {
-		return x.Text
-	}
-	return ""
-}
-
-func (x *MessageDTO) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-// Превью чата для списка — последнее сообщение и собеседник
-type ChatPreviewDTO struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	CompanionId   int64                  `protobuf:"varint,2,opt,name=companion_id,json=companionId,proto3" json:"companion_id,omitempty"` // ID собеседника
-	LastMessage   string                 `protobuf:"bytes,3,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`  // текст последнего сообщения
-	UnreadCount   int64                  `protobuf:"varint,4,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
-	LastMessageAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_message_at,json=lastMessageAt,proto3" json:"last_message_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ChatPreviewDTO) Reset() {
-	*x = ChatPreviewDTO{}
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ChatPreviewDTO) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ChatPreviewDTO) ProtoMessage() {}
-
-func (x *ChatPreviewDTO) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_chat_v1_chat_proto_msgTypes[10]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ChatPreviewDTO.ProtoReflect.Descriptor instead.
-func (*ChatPreviewDTO) Descriptor() ([]byte, []int) {
-	return file_proto_chat_v1_chat_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *ChatPreviewDTO) GetChatId() int64 {
-	if x != nil {
-		return x.ChatId
-	}
-	return 0
-}
-
-func (x *ChatPreviewDTO) GetCompanionId() int64 {
-	if x != nil {
-		return x.CompanionId
-	}
-	return 0
-}
-
-func (x *ChatPreviewDTO) GetLastMessage() string {
-	if x != nil {
-		return x.LastMessage
-	}
-	return ""
-}
-
-func (x *ChatPreviewDTO) GetUnreadCount() int64 {
-	if x != nil {
-		return x.UnreadCount
-	}
-	return 0
-}
-
-func (x *ChatPreviewDTO) GetLastMessageAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastMessageAt
-	}
-	return nil
-}
-
-var File_proto_chat_v1_chat_proto protoreflect.FileDescriptor
-
-const file_proto_chat_v1_chat_proto_rawDesc = "" +
-	"\n" +
-	"\x18proto/chat/v1/chat.proto\x12\achat.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x12\n" +
-	"\x10SubscribeRequest\"^\n" +
-	"\x16GetOrCreateChatRequest\x12!\n" +
-	"\finitiator_id\x18\x01 \x01(\x03R\vinitiatorId\x12!\n" +
-	"\frecipient_id\x18\x02 \x01(\x03R\vrecipientId\"\x87\x01\n" +
-	"\x17GetOrCreateChatResponse\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x18\n" +
-	"\acreated\x18\x02 \x01(\bR\acreated\x129\n" +
-	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"[\n" +
-	"\x12GetMessagesRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
-	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"g\n" +
-	"\x13GetMessagesResponse\x12/\n" +
-	"\bmessages\x18\x01 \x03(\v2\x13.chat.v1.MessageDTOR\bmessages\x12\x1f\n" +
-	"\vnext_cursor\x18\x02 \x01(\tR\n" +
-	"nextCursor\"\\\n" +
-	"\x13GetUserChatsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x03R\x06offset\"E\n" +
-	"\x14GetUserChatsResponse\x12-\n" +
-	"\x05chats\x18\x01 \x03(\v2\x17.chat.v1.ChatPreviewDTOR\x05chats\"^\n" +
-	"\x12SendMessageRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1b\n" +
-	"\tsender_id\x18\x02 \x01(\x03R\bsenderId\x12\x12\n" +
-	"\x04text\x18\x03 \x01(\tR\x04text\"o\n" +
-	"\x13SendMessageResponse\x12\x1d\n" +
-	"\n" +
-	"message_id\x18\x01 \x01(\x03R\tmessageId\x129\n" +
-	"\n" +
-	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xa1\x01\n" +
-	"\n" +
-	"MessageDTO\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
-	"\achat_id\x18\x02 \x01(\x03R\x06chatId\x12\x1b\n" +
-	"\tsender_id\x18\x03 \x01(\x03R\bsenderId\x12\x12\n" +
-	"\x04text\x18\x04 \x01(\tR\x04text\x129\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd6\x01\n" +
-	"\x0eChatPreviewDTO\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12!\n" +
-	"\fcompanion_id\x18\x02 \x01(\x03R\vcompanionId\x12!\n" +
-	"\flast_message\x18\x03 \x01(\tR\vlastMessage\x12!\n" +
-	"\funread_count\x18\x04 \x01(\x03R\vunreadCount\x12B\n" +
-	"\x0flast_message_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlastMessageAt2\x83\x03\n" +
-	"\vChatService\x12T\n" +
-	"\x0fGetOrCreateChat\x12\x1f.chat.v1.GetOrCreateChatRequest\x1a .chat.v1.GetOrCreateChatResponse\x12H\n" +
-	"\vGetMessages\x12\x1b.chat.v1.GetMessagesRequest\x1a\x1c.chat.v1.GetMessagesResponse\x12K\n" +
-	"\fGetUserChats\x12\x1c.chat.v1.GetUserChatsRequest\x1a\x1d.chat.v1.GetUserChatsResponse\x12H\n" +
-	"\vSendMessage\x12\x1b.chat.v1.SendMessageRequest\x1a\x1c.chat.v1.SendMessageResponse\x12=\n" +
-	"\tSubscribe\x12\x19.chat.v1.SubscribeRequest\x1a\x13.chat.v1.MessageDTO0\x01B\x1bZ\x19chat/proto/chat/v1;chatv1b\x06proto3"
-
-var (
-	file_proto_chat_v1_chat_proto_rawDescOnce sync.Once
-	file_proto_chat_v1_chat_proto_rawDescData []byte
-)
-
-func file_proto_chat_v1_chat_proto_rawDescGZIP() []byte {
-	file_proto_chat_v1_chat_proto_rawDescOnce.Do(func() {
-		file_proto_chat_v1_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_chat_v1_chat_proto_rawDesc), len(file_proto_chat_v1_chat_proto_rawDesc)))
-	})
-	return file_proto_chat_v1_chat_proto_rawDescData
-}
-
-var file_proto_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
-var file_proto_chat_v1_chat_proto_goTypes = []any{
-	(*SubscribeRequest)(nil),        // 0: chat.v1.SubscribeRequest
-	(*GetOrCreateChatRequest)(nil),  // 1: chat.v1.GetOrCreateChatRequest
-	(*GetOrCreateChatResponse)(nil), // 2: chat.v1.GetOrCreateChatResponse
-	(*GetMessagesRequest)(nil),      // 3: chat.v1.GetMessagesRequest
-	(*GetMessagesResponse)(nil),     // 4: chat.v1.GetMessagesResponse
-	(*GetUserChatsRequest)(nil),     // 5: chat.v1.GetUserChatsRequest
-	(*GetUserChatsResponse)(nil),    // 6: chat.v1.GetUserChatsResponse
-	(*SendMessageRequest)(nil),      // 7: chat.v1.SendMessageRequest
-	(*SendMessageResponse)(nil),     // 8: chat.v1.SendMessageResponse
-	(*MessageDTO)(nil),              // 9: chat.v1.MessageDTO
-	(*ChatPreviewDTO)(nil),          // 10: chat.v1.ChatPreviewDTO
-	(*timestamppb.Timestamp)(nil),   // 11: google.protobuf.Timestamp
-}
-var file_proto_chat_v1_chat_proto_depIdxs = []int32{
-	11, // 0: chat.v1.GetOrCreateChatResponse.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 1: chat.v1.GetMessagesResponse.messages:type_name -> chat.v1.MessageDTO
-	10, // 2: chat.v1.GetUserChatsResponse.chats:type_name -> chat.v1.ChatPreviewDTO
-	11, // 3: chat.v1.SendMessageResponse.created_at:type_name -> google.protobuf.Timestamp
-	11, // 4: chat.v1.MessageDTO.created_at:type_name -> google.protobuf.Timestamp
-	11, // 5: chat.v1.ChatPreviewDTO.last_message_at:type_name -> google.protobuf.Timestamp
-	1,  // 6: chat.v1.ChatService.GetOrCreateChat:input_type -> chat.v1.GetOrCreateChatRequest
-	3,  // 7: chat.v1.ChatService.GetMessages:input_type -> chat.v1.GetMessagesRequest
-	5,  // 8: chat.v1.ChatService.GetUserChats:input_type -> chat.v1.GetUserChatsRequest
-	7,  // 9: chat.v1.ChatService.SendMessage:input_type -> chat.v1.SendMessageRequest
-	0,  // 10: chat.v1.ChatService.Subscribe:input_type -> chat.v1.SubscribeRequest
-	2,  // 11: chat.v1.ChatService.GetOrCreateChat:output_type -> chat.v1.GetOrCreateChatResponse
-	4,  // 12: chat.v1.ChatService.GetMessages:output_type -> chat.v1.GetMessagesResponse
-	6,  // 13: chat.v1.ChatService.GetUserChats:output_type -> chat.v1.GetUserChatsResponse
-	8,  // 14: chat.v1.ChatService.SendMessage:output_type -> chat.v1.SendMessageResponse
-	9,  // 15: chat.v1.ChatService.Subscribe:output_type -> chat.v1.MessageDTO
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
-}
-
-func init() { file_proto_chat_v1_chat_proto_init() }
-func file_proto_chat_v1_chat_proto_init() {
-	if File_proto_chat_v1_chat_proto != nil {
-		return
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_chat_v1_chat_proto_rawDesc), len(file_proto_chat_v1_chat_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_proto_chat_v1_chat_proto_goTypes,
-		DependencyIndexes: file_proto_chat_v1_chat_proto_depIdxs,
-		MessageInfos:      file_proto_chat_v1_chat_proto_msgTypes,
-	}.Build()
-	File_proto_chat_v1_chat_proto = out.File
-	file_proto_chat_v1_chat_proto_goTypes = nil
-	file_proto_chat_v1_chat_proto_depIdxs = nil
-}